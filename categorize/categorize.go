@@ -0,0 +1,206 @@
+// Package categorize assigns categories to transactions by categorization rules. A rule names a category and the
+// conditions a transaction must meet to get it: its account, text its description contains, a regular expression it
+// matches, the counterparty it starts with and bounds of its amount. Rules are tried by descending priority, then
+// by ID, and the first rule whose conditions all hold categorizes the transaction.
+package categorize
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+// ErrInvalidRule is returned for rules that cannot be matched, such as rules without conditions
+var ErrInvalidRule = errors.New("invalid categorization rule")
+
+const (
+	// maxName and maxText are the lengths of the name and text columns of the categorization_rules table
+	maxName = 100
+	maxText = 255
+	// maxCounterpartyWords bounds the words CounterpartyOf takes from a description
+	maxCounterpartyWords = 3
+)
+
+// maxAmount is the exclusive bound of amounts, given by the NUMERIC(10, 2) columns
+var maxAmount = money.FromCents(1e10)
+
+// Validate rejects rules without a name, category or condition, with a text condition too long for its column,
+// an invalid regular expression or reversed amount bounds
+func Validate(rule entity.CategorizationRule) error {
+	_, err := compile(rule)
+	return err
+}
+
+// Rules are compiled categorization rules in the order they are tried
+type Rules struct {
+	rules []compiled
+}
+
+// compiled is a rule with its text conditions prepared for matching
+type compiled struct {
+	rule         entity.CategorizationRule
+	contains     string
+	pattern      *regexp.Regexp
+	counterparty string
+}
+
+// Compile validates the rules and orders them by descending priority, then by ID
+func Compile(rules []entity.CategorizationRule) (*Rules, error) {
+	compiledRules := make([]compiled, 0, len(rules))
+	for _, rule := range rules {
+		c, err := compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", rule.RuleID, err)
+		}
+		compiledRules = append(compiledRules, c)
+	}
+	sort.SliceStable(compiledRules, func(i, j int) bool {
+		a, b := compiledRules[i].rule, compiledRules[j].rule
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.RuleID < b.RuleID
+	})
+	return &Rules{rules: compiledRules}, nil
+}
+
+func compile(rule entity.CategorizationRule) (compiled, error) {
+	c := compiled{rule: rule}
+	switch {
+	case strings.TrimSpace(rule.Name) == "":
+		return c, fmt.Errorf("%w: name is required", ErrInvalidRule)
+	case utf8.RuneCountInString(rule.Name) > maxName:
+		return c, fmt.Errorf("%w: name must be at most %d characters", ErrInvalidRule, maxName)
+	case rule.CategoryID == 0:
+		return c, fmt.Errorf("%w: category is required", ErrInvalidRule)
+	}
+
+	conditions := 0
+	if rule.AccountID != nil {
+		conditions++
+	}
+	for _, text := range []struct {
+		name  string
+		value *string
+		into  *string
+	}{
+		{"description_contains", rule.DescriptionContains, &c.contains},
+		{"counterparty", rule.Counterparty, &c.counterparty},
+		{"description_pattern", rule.DescriptionPattern, nil},
+	} {
+		if text.value == nil {
+			continue
+		}
+		conditions++
+		if normalize(*text.value) == "" {
+			return c, fmt.Errorf("%w: %s must not be empty", ErrInvalidRule, text.name)
+		}
+		if utf8.RuneCountInString(*text.value) > maxText {
+			return c, fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidRule, text.name, maxText)
+		}
+		if text.into != nil {
+			*text.into = normalize(*text.value)
+		}
+	}
+	if rule.DescriptionPattern != nil {
+		pattern, err := regexp.Compile("(?i)" + *rule.DescriptionPattern)
+		if err != nil {
+			return c, fmt.Errorf("%w: description_pattern is not a regular expression: %v", ErrInvalidRule, err)
+		}
+		c.pattern = pattern
+	}
+	for _, bound := range []*money.Amount{rule.MinAmount, rule.MaxAmount} {
+		if bound == nil {
+			continue
+		}
+		conditions++
+		if bound.Abs() >= maxAmount {
+			return c, fmt.Errorf("%w: amounts must be less than %s", ErrInvalidRule, maxAmount)
+		}
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && *rule.MinAmount > *rule.MaxAmount {
+		return c, fmt.Errorf("%w: min_amount is greater than max_amount", ErrInvalidRule)
+	}
+	if conditions == 0 {
+		// A rule without conditions would categorize every transaction, shadowing all rules after it
+		return c, fmt.Errorf("%w: at least one condition is required", ErrInvalidRule)
+	}
+	return c, nil
+}
+
+// Len returns the number of rules
+func (r *Rules) Len() int {
+	return len(r.rules)
+}
+
+// Match returns the first rule whose conditions the transaction meets, nil when none does
+func (r *Rules) Match(t entity.Transaction) *entity.CategorizationRule {
+	var description string
+	if t.Description != nil {
+		description = *t.Description
+	}
+	normalized := normalize(description)
+	for i := range r.rules {
+		if r.rules[i].matches(t, description, normalized) {
+			return &r.rules[i].rule
+		}
+	}
+	return nil
+}
+
+func (c *compiled) matches(t entity.Transaction, description, normalized string) bool {
+	rule := c.rule
+	switch {
+	case rule.AccountID != nil && *rule.AccountID != t.AccountID:
+		return false
+	case rule.MinAmount != nil && t.Amount < *rule.MinAmount:
+		return false
+	case rule.MaxAmount != nil && t.Amount > *rule.MaxAmount:
+		return false
+	case rule.DescriptionContains != nil && !strings.Contains(normalized, c.contains):
+		return false
+	case rule.Counterparty != nil && !startsWithWords(normalized, c.counterparty):
+		return false
+	case c.pattern != nil && !c.pattern.MatchString(description):
+		return false
+	}
+	return true
+}
+
+// startsWithWords reports whether s starts with prefix followed by the end of s or a character ending a word,
+// so that the counterparty "Shell" does not match "Shellfish Market"
+func startsWithWords(s, prefix string) bool {
+	if !strings.HasPrefix(s, prefix) {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(s[len(prefix):])
+	return next == utf8.RuneError || !unicode.IsLetter(next) && !unicode.IsDigit(next)
+}
+
+// CounterpartyOf guesses the counterparty of a transaction from its description, which bank statements start with:
+// up to three words before the first holding a digit, such as a date or a reference number. It returns an empty
+// string when the description starts with such a word.
+func CounterpartyOf(description string) string {
+	var words []string
+	for _, word := range strings.Fields(description) {
+		if len(words) == maxCounterpartyWords || strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+			break
+		}
+		words = append(words, word)
+	}
+	return strings.TrimRightFunc(strings.Join(words, " "), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalize lower-cases text and collapses its runs of spaces, which differ between the exports of the same bank
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
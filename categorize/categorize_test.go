@@ -0,0 +1,122 @@
+package categorize
+
+import (
+	"errors"
+	"testing"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func text(s string) *string { return &s }
+
+func amount(s string) *money.Amount {
+	a := money.MustParse(s)
+	return &a
+}
+
+func transaction(accountID uint, amount, description string) entity.Transaction {
+	return entity.Transaction{AccountID: accountID, Amount: money.MustParse(amount), Description: &description}
+}
+
+// TestRules_Match verifies that every condition of a rule must hold and that the first matching rule by priority,
+// then by ID, wins
+func TestRules_Match(t *testing.T) {
+	checking := uint(1)
+	rules, err := Compile([]entity.CategorizationRule{
+		{RuleID: 1, Name: "Streaming", CategoryID: 10, Counterparty: text("Netflix")},
+		{RuleID: 2, Name: "Fuel", CategoryID: 11, DescriptionPattern: text(`^(shell|bp)\b`), MaxAmount: amount("-0.01")},
+		{RuleID: 3, Name: "Rent", CategoryID: 12, AccountID: &checking, DescriptionContains: text("rent  march"),
+			MinAmount: amount("-1500"), MaxAmount: amount("-1000")},
+		{RuleID: 4, Name: "Large streaming", Priority: 5, CategoryID: 13, Counterparty: text("netflix"), MaxAmount: amount("-50")},
+		{RuleID: 5, Name: "Payroll", CategoryID: 14, DescriptionContains: text("payroll"), MinAmount: amount("0.01")},
+	})
+	if err != nil {
+		t.Fatalf("Error compiling rules: %v", err)
+	}
+	if rules.Len() != 5 {
+		t.Fatalf("Expected 5 rules, got %d", rules.Len())
+	}
+
+	tests := []struct {
+		name        string
+		transaction entity.Transaction
+		rule        uint
+	}{
+		{"counterparty", transaction(2, "-15.99", "NETFLIX.COM 866-579-7172"), 1},
+		{"counterparty ends a word", transaction(2, "-15.99", "Netflixation Ltd"), 0},
+		{"higher priority first", transaction(2, "-59.99", "Netflix annual"), 4},
+		{"pattern and amount", transaction(2, "-40.00", "SHELL 0815 Hamburg"), 2},
+		{"pattern but income", transaction(2, "40.00", "Shell refund"), 0},
+		{"pattern is anchored", transaction(2, "-4.20", "Shellfish Market"), 0},
+		{"account, text and range", transaction(1, "-1200", "Rent March 2024"), 3},
+		{"other account", transaction(2, "-1200", "Rent March 2024"), 0},
+		{"out of range", transaction(1, "-900", "Rent March 2024"), 0},
+		{"income", transaction(3, "2500", "ACME Corp PAYROLL"), 5},
+		{"no description", entity.Transaction{AccountID: 2, Amount: money.MustParse("2500")}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := rules.Match(tt.transaction)
+			switch {
+			case tt.rule == 0 && rule != nil:
+				t.Errorf("Expected no rule to match, got rule %d", rule.RuleID)
+			case tt.rule != 0 && (rule == nil || rule.RuleID != tt.rule):
+				t.Errorf("Expected rule %d to match, got %+v", tt.rule, rule)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := entity.CategorizationRule{Name: "Streaming", CategoryID: 10, Counterparty: text("Netflix")}
+	if err := Validate(valid); err != nil {
+		t.Errorf("Expected the rule to be valid, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		rule    entity.CategorizationRule
+		message string
+	}{
+		{"no name", entity.CategorizationRule{CategoryID: 10, Counterparty: text("Netflix")},
+			"invalid categorization rule: name is required"},
+		{"no category", entity.CategorizationRule{Name: "Streaming", Counterparty: text("Netflix")},
+			"invalid categorization rule: category is required"},
+		{"no condition", entity.CategorizationRule{Name: "Everything", CategoryID: 10},
+			"invalid categorization rule: at least one condition is required"},
+		{"empty text", entity.CategorizationRule{Name: "Streaming", CategoryID: 10, DescriptionContains: text("  ")},
+			"invalid categorization rule: description_contains must not be empty"},
+		{"invalid pattern", entity.CategorizationRule{Name: "Fuel", CategoryID: 10, DescriptionPattern: text("(shell")},
+			"invalid categorization rule: description_pattern is not a regular expression: error parsing regexp: missing closing ): `(?i)(shell`"},
+		{"reversed range", entity.CategorizationRule{Name: "Rent", CategoryID: 10, MinAmount: amount("-1000"), MaxAmount: amount("-1500")},
+			"invalid categorization rule: min_amount is greater than max_amount"},
+		{"amount too large", entity.CategorizationRule{Name: "Rent", CategoryID: 10, MinAmount: amount("100000000")},
+			"invalid categorization rule: amounts must be less than 100000000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.rule)
+			if !errors.Is(err, ErrInvalidRule) || err.Error() != tt.message {
+				t.Errorf("Expected %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestCounterpartyOf(t *testing.T) {
+	tests := map[string]string{
+		"NETFLIX.COM 866-579-7172":         "NETFLIX.COM",
+		"Stadtwerke München GmbH Abschlag": "Stadtwerke München GmbH",
+		"Gym Membership Fee":               "Gym Membership Fee",
+		"Café Central, 05.03.2024":         "Café Central",
+		"  Shell   0815 Hamburg":           "Shell",
+		"2024-03-05 Transfer":              "",
+		"":                                 "",
+	}
+	for description, want := range tests {
+		if got := CounterpartyOf(description); got != want {
+			t.Errorf("CounterpartyOf(%q): expected %q, got %q", description, want, got)
+		}
+	}
+}
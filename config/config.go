@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"sample-mcp/pkg/auth"
 	"sample-mcp/pkg/db"
 )
 
@@ -31,15 +32,81 @@ func DefaultConnectionConfig() *db.ConnectionConfig {
 	}
 }
 
+// Transport selects how the MCP server is exposed to clients
+type Transport string
+
+const (
+	// TransportStdio serves a single client over stdin/stdout
+	TransportStdio Transport = "stdio"
+	// TransportHTTP serves clients over the streamable HTTP and SSE transports
+	TransportHTTP Transport = "http"
+)
+
+// ServerConfig represents the MCP server transport configuration
+type ServerConfig struct {
+	Transport       Transport     `yaml:"transport" validate:"required,oneof=stdio http"`
+	Address         string        `yaml:"address" validate:"required_if=Transport http"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	AllowedOrigins  []string      `yaml:"allowedOrigins"`
+}
+
+// DefaultServerConfig returns the default server configuration
+func DefaultServerConfig() *ServerConfig {
+	return &ServerConfig{
+		Transport:       TransportStdio,
+		Address:         ":8080",
+		ShutdownTimeout: 10 * time.Second,
+	}
+}
+
+// DefaultAuthConfig returns the default authentication configuration
+func DefaultAuthConfig() *auth.Config {
+	return &auth.Config{
+		Mode: auth.ModeNone,
+	}
+}
+
+// WritesConfig selects the write tools the server registers
+type WritesConfig struct {
+	// Enabled registers the write tools and allows them to create, update and import records
+	Enabled bool `yaml:"enabled"`
+	// AllowDelete additionally allows deleting transactions
+	AllowDelete bool `yaml:"allowDelete"`
+}
+
+// DefaultWritesConfig returns the default writes configuration, which keeps the server read-only
+func DefaultWritesConfig() *WritesConfig {
+	return &WritesConfig{}
+}
+
+// ExchangeRatesConfig configures the exchange rates used to convert amounts between currencies
+type ExchangeRatesConfig struct {
+	// File is a CSV file of date,base,quote,rate rows upserted into the exchange_rates table at startup
+	File string `yaml:"file"`
+}
+
+// DefaultExchangeRatesConfig returns the default exchange rates configuration, which loads no file
+func DefaultExchangeRatesConfig() *ExchangeRatesConfig {
+	return &ExchangeRatesConfig{}
+}
+
 // Config represents the application configuration
 type Config struct {
-	Database *db.ConnectionConfig `yaml:"database"`
+	Database      *db.ConnectionConfig `yaml:"database"`
+	Server        *ServerConfig        `yaml:"server"`
+	Auth          *auth.Config         `yaml:"auth"`
+	Writes        *WritesConfig        `yaml:"writes"`
+	ExchangeRates *ExchangeRatesConfig `yaml:"exchangeRates"`
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Database: DefaultConnectionConfig(),
+		Database:      DefaultConnectionConfig(),
+		Server:        DefaultServerConfig(),
+		Auth:          DefaultAuthConfig(),
+		Writes:        DefaultWritesConfig(),
+		ExchangeRates: DefaultExchangeRatesConfig(),
 	}
 }
 
@@ -79,5 +146,21 @@ func LoadConfig() (*Config, error) {
 		return config, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if config.Database == nil {
+		config.Database = DefaultConnectionConfig()
+	}
+	if config.Server == nil {
+		config.Server = DefaultServerConfig()
+	}
+	if config.Auth == nil {
+		config.Auth = DefaultAuthConfig()
+	}
+	if config.Writes == nil {
+		config.Writes = DefaultWritesConfig()
+	}
+	if config.ExchangeRates == nil {
+		config.ExchangeRates = DefaultExchangeRatesConfig()
+	}
+
 	return config, nil
 }
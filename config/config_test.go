@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 
+	"sample-mcp/pkg/auth"
 	"sample-mcp/pkg/db"
 )
 
@@ -39,6 +40,20 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 3*time.Second, config.Database.Timeout)
 	assert.Equal(t, 5, config.Database.MaxIdleConns)
 	assert.Equal(t, 10, config.Database.MaxOpenConns)
+
+	assert.NotNil(t, config.Server)
+	assert.Equal(t, TransportStdio, config.Server.Transport)
+	assert.Equal(t, ":8080", config.Server.Address)
+	assert.Equal(t, 10*time.Second, config.Server.ShutdownTimeout)
+
+	assert.NotNil(t, config.Auth)
+	assert.Equal(t, auth.ModeNone, config.Auth.Mode)
+
+	assert.NotNil(t, config.Writes)
+	assert.False(t, config.Writes.Enabled, "writes must be disabled by default")
+
+	assert.NotNil(t, config.ExchangeRates)
+	assert.Empty(t, config.ExchangeRates.File)
 }
 
 func TestLoadConfig_NoConfigFile(t *testing.T) {
@@ -96,4 +111,97 @@ func TestLoadConfig_FromEnvVar(t *testing.T) {
 	assert.Equal(t, 5*time.Second, config.Database.Timeout)
 	assert.Equal(t, 10, config.Database.MaxIdleConns)
 	assert.Equal(t, 20, config.Database.MaxOpenConns)
+	assert.Equal(t, TransportStdio, config.Server.Transport)
+}
+
+func TestLoadConfig_ServerSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yml")
+
+	data := []byte(`
+server:
+  transport: http
+  address: 127.0.0.1:9090
+`)
+	err := os.WriteFile(configPath, data, 0644)
+	assert.NoError(t, err)
+
+	os.Setenv(EnvMCPServerConfig, configPath)
+	defer os.Unsetenv(EnvMCPServerConfig)
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, TransportHTTP, config.Server.Transport)
+	assert.Equal(t, "127.0.0.1:9090", config.Server.Address)
+	// Unset fields keep their defaults
+	assert.Equal(t, 10*time.Second, config.Server.ShutdownTimeout)
+	assert.Equal(t, db.Postgresql, config.Database.DbType)
+}
+
+func TestLoadConfig_AuthSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yml")
+
+	data := []byte(`
+auth:
+  mode: api_key
+  apiKeys:
+    - key: 0123456789abcdef0123
+      subject: reporting
+      accountIds: [1, 2]
+      accountTypes: [Savings]
+`)
+	err := os.WriteFile(configPath, data, 0644)
+	assert.NoError(t, err)
+
+	os.Setenv(EnvMCPServerConfig, configPath)
+	defer os.Unsetenv(EnvMCPServerConfig)
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, auth.ModeAPIKey, config.Auth.Mode)
+	assert.Equal(t, []auth.APIKey{{
+		Key:     "0123456789abcdef0123",
+		Subject: "reporting",
+		Grant:   auth.Grant{AccountIDs: []uint{1, 2}, AccountTypes: []string{"Savings"}},
+	}}, config.Auth.APIKeys)
+}
+
+func TestLoadConfig_WritesSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yml")
+
+	data := []byte(`
+writes:
+  enabled: true
+`)
+	err := os.WriteFile(configPath, data, 0644)
+	assert.NoError(t, err)
+
+	os.Setenv(EnvMCPServerConfig, configPath)
+	defer os.Unsetenv(EnvMCPServerConfig)
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, &WritesConfig{Enabled: true}, config.Writes)
+}
+
+func TestLoadConfig_ExchangeRatesSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yml")
+
+	data := []byte(`
+exchangeRates:
+  file: /etc/sample-mcp/rates.csv
+`)
+	err := os.WriteFile(configPath, data, 0644)
+	assert.NoError(t, err)
+
+	os.Setenv(EnvMCPServerConfig, configPath)
+	defer os.Unsetenv(EnvMCPServerConfig)
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/sample-mcp/rates.csv", config.ExchangeRates.File)
+	assert.NotNil(t, config.Writes)
 }
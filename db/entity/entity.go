@@ -2,12 +2,16 @@ package entity
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
+	"sample-mcp/pkg/money"
 )
 
 type Account struct {
 	AccountID   uint      `gorm:"primaryKey" json:"account_id"`
 	Name        string    `gorm:"not null" json:"name"`
 	AccountType string    `gorm:"column:account_type;not null" json:"account_type"`
+	Currency    string    `gorm:"type:char(3);not null;default:USD" json:"currency"` // ISO 4217 code of the amounts
 	CreatedAt   time.Time `gorm:"not null;default:now()" json:"created_at"`
 	UpdatedAt   time.Time `gorm:"not null;default:now()" json:"updated_at"`
 }
@@ -21,15 +25,95 @@ type Category struct {
 }
 
 type Transaction struct {
-	TransactionID   uint      `gorm:"primaryKey" json:"transaction_id"`
-	AccountID       uint      `gorm:"not null" json:"account_id"`
-	CategoryID      uint      `gorm:"not null" json:"category_id"`
-	Amount          float64   `gorm:"type:numeric(10,2);not null" json:"amount"`
-	TransactionDate time.Time `gorm:"type:date;not null" json:"transaction_date"`
-	Description     *string   `json:"description,omitempty"` // nullable
-	CreatedAt       time.Time `gorm:"not null;default:now()" json:"created_at"`
-	UpdatedAt       time.Time `gorm:"not null;default:now()" json:"updated_at"`
-
-	Account  *Account  `gorm:"foreignKey:AccountID" json:"account,omitempty"`
-	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	TransactionID   uint         `gorm:"primaryKey" json:"transaction_id"`
+	AccountID       uint         `gorm:"not null" json:"account_id"`
+	CategoryID      uint         `gorm:"not null" json:"category_id"`
+	Amount          money.Amount `gorm:"type:numeric(10,2);not null" json:"amount"`
+	TransactionDate time.Time    `gorm:"type:date;not null" json:"transaction_date"`
+	Description     *string      `json:"description,omitempty"`                          // nullable
+	ExternalID      *string      `gorm:"type:varchar(255)" json:"external_id,omitempty"` // the bank's ID, unique per account; nullable
+	CreatedAt       time.Time    `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt       time.Time    `gorm:"not null;default:now()" json:"updated_at"`
+
+	Account  *Account  `gorm:"foreignKey:AccountID;references:AccountID" json:"account,omitempty"`
+	Category *Category `gorm:"foreignKey:CategoryID;references:CategoryID" json:"category,omitempty"`
+}
+
+// ExchangeRate is the value of one unit of BaseCurrency in QuoteCurrency, effective from RateDate
+// until the next rate of the same pair
+type ExchangeRate struct {
+	RateDate      time.Time       `gorm:"primaryKey;type:date" json:"rate_date"`
+	BaseCurrency  string          `gorm:"primaryKey;type:char(3)" json:"base_currency"`
+	QuoteCurrency string          `gorm:"primaryKey;type:char(3)" json:"quote_currency"`
+	Rate          decimal.Decimal `gorm:"type:numeric(18,8);not null" json:"rate"`
+}
+
+// Budget limits the spending in a category to Amount per Period (week, month, quarter or year),
+// from the period containing StartDate. With Rollover the remainder of each period carries over to the next.
+type Budget struct {
+	BudgetID   uint         `gorm:"primaryKey" json:"budget_id"`
+	CategoryID uint         `gorm:"not null" json:"category_id"`
+	Period     string       `gorm:"type:varchar(10);not null" json:"period"`
+	Amount     money.Amount `gorm:"type:numeric(10,2);not null" json:"amount"`
+	Rollover   bool         `gorm:"not null;default:false" json:"rollover"`
+	StartDate  time.Time    `gorm:"type:date;not null" json:"start_date"`
+	CreatedAt  time.Time    `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt  time.Time    `gorm:"not null;default:now()" json:"updated_at"`
+
+	Category *Category `gorm:"foreignKey:CategoryID;references:CategoryID" json:"category,omitempty"`
+}
+
+// RecurringPayment is a payment detected as recurring among the transactions of an account in a category
+// whose descriptions normalize to DescriptionKey. Frequency is weekly, monthly or annual.
+type RecurringPayment struct {
+	RecurringPaymentID uint         `gorm:"primaryKey" json:"recurring_payment_id"`
+	AccountID          uint         `gorm:"not null" json:"account_id"`
+	CategoryID         uint         `gorm:"not null" json:"category_id"`
+	DescriptionKey     string       `gorm:"type:varchar(255);not null" json:"description_key"`
+	Description        string       `gorm:"not null" json:"description"` // of the latest occurrence
+	Frequency          string       `gorm:"type:varchar(10);not null" json:"frequency"`
+	TypicalAmount      money.Amount `gorm:"type:numeric(10,2);not null" json:"typical_amount"`
+	Occurrences        int          `gorm:"not null" json:"occurrences"`
+	FirstDate          time.Time    `gorm:"type:date;not null" json:"first_date"`
+	LastDate           time.Time    `gorm:"type:date;not null" json:"last_date"`
+	LastAmount         money.Amount `gorm:"type:numeric(10,2);not null" json:"last_amount"`
+	NextDueDate        time.Time    `gorm:"type:date;not null" json:"next_due_date"`
+	CreatedAt          time.Time    `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt          time.Time    `gorm:"not null;default:now()" json:"updated_at"`
+
+	Account  *Account  `gorm:"foreignKey:AccountID;references:AccountID" json:"account,omitempty"`
+	Category *Category `gorm:"foreignKey:CategoryID;references:CategoryID" json:"category,omitempty"`
+}
+
+// StatementBalance is the ledger balance of an account at the end of BalanceDate, as stated by an imported bank
+// statement, to compare with the sum of its recorded transactions
+type StatementBalance struct {
+	AccountID   uint         `gorm:"primaryKey" json:"account_id"`
+	BalanceDate time.Time    `gorm:"primaryKey;type:date" json:"balance_date"`
+	Balance     money.Amount `gorm:"type:numeric(12,2);not null" json:"balance"`
+	CreatedAt   time.Time    `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt   time.Time    `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// CategorizationRule assigns CategoryID to the transactions matching all of its conditions; nil conditions match
+// every transaction. Rules are tried by descending Priority, then by RuleID, and the first match wins.
+type CategorizationRule struct {
+	RuleID     uint   `gorm:"primaryKey" json:"rule_id"`
+	Name       string `gorm:"type:varchar(100);not null" json:"name"`
+	Priority   int    `gorm:"not null;default:0" json:"priority"`
+	CategoryID uint   `gorm:"not null" json:"category_id"`
+	AccountID  *uint  `json:"account_id,omitempty"` // nullable
+	// DescriptionContains matches descriptions containing the text, DescriptionPattern those matching the regular
+	// expression and Counterparty those starting with the name, all case-insensitively
+	DescriptionContains *string `gorm:"type:varchar(255)" json:"description_contains,omitempty"`
+	DescriptionPattern  *string `gorm:"type:varchar(255)" json:"description_pattern,omitempty"`
+	Counterparty        *string `gorm:"type:varchar(255)" json:"counterparty,omitempty"`
+	// MinAmount and MaxAmount bound the signed amount, inclusive
+	MinAmount *money.Amount `gorm:"type:numeric(10,2)" json:"min_amount,omitempty"`
+	MaxAmount *money.Amount `gorm:"type:numeric(10,2)" json:"max_amount,omitempty"`
+	CreatedAt time.Time     `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time     `gorm:"not null;default:now()" json:"updated_at"`
+
+	Account  *Account  `gorm:"foreignKey:AccountID;references:AccountID" json:"account,omitempty"`
+	Category *Category `gorm:"foreignKey:CategoryID;references:CategoryID" json:"category,omitempty"`
 }
@@ -1,35 +1,81 @@
 package db
 
 import (
+	"database/sql"
 	"embed"
 	"errors"
 	"fmt"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlserver"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"gorm.io/gorm"
+	pkgdb "sample-mcp/pkg/db"
 )
 
-//go:embed migrations/*.sql
+// migrationsFS holds one directory of migrations per dialect, see migrationDir
+//
+//go:embed migrations/*/*.sql
 var migrationsFS embed.FS
 
-func RunMigrations(db *gorm.DB) error {
+// migrationDir returns the directory of migrationsFS holding the migrations of a database type
+func migrationDir(dbType pkgdb.DatabaseType) (string, error) {
+	switch dbType {
+	case pkgdb.Postgresql:
+		return "migrations/postgres", nil
+	case pkgdb.Mysql:
+		return "migrations/mysql", nil
+	case pkgdb.MSSQL:
+		return "migrations/sqlserver", nil
+	case pkgdb.Sqlite:
+		return "migrations/sqlite", nil
+	default:
+		return "", fmt.Errorf("unsupported database type: %s", dbType)
+	}
+}
+
+// migrationDriver creates the migrate driver of a database type on an open connection
+func migrationDriver(dbType pkgdb.DatabaseType, sqlDb *sql.DB) (database.Driver, error) {
+	switch dbType {
+	case pkgdb.Postgresql:
+		return postgres.WithInstance(sqlDb, &postgres.Config{})
+	case pkgdb.Mysql:
+		// The migrations hold several statements per file, so the DSN must enable multiStatements
+		return mysql.WithInstance(sqlDb, &mysql.Config{})
+	case pkgdb.MSSQL:
+		return sqlserver.WithInstance(sqlDb, &sqlserver.Config{})
+	case pkgdb.Sqlite:
+		return newSqliteDriver(sqlDb)
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+}
+
+// RunMigrations applies the migrations of the given database type that have not been applied yet
+func RunMigrations(db *gorm.DB, dbType pkgdb.DatabaseType) error {
+	dir, err := migrationDir(dbType)
+	if err != nil {
+		return err
+	}
+
 	sqlDb, err := db.DB()
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	driver, err := postgres.WithInstance(sqlDb, &postgres.Config{})
+	driver, err := migrationDriver(dbType, sqlDb)
 	if err != nil {
 		return fmt.Errorf("failed to create database driver: %w", err)
 	}
 
-	d, err := iofs.New(migrationsFS, "migrations")
+	d, err := iofs.New(migrationsFS, dir)
 	if err != nil {
 		return fmt.Errorf("failed to create migration source: %w", err)
 	}
 
-	m, err := migrate.NewWithInstance("iofs", d, "", driver)
+	m, err := migrate.NewWithInstance("iofs", d, string(dbType), driver)
 	if err != nil {
 		return fmt.Errorf("failed to create migrate instance: %w", err)
 	}
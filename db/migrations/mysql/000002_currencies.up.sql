@@ -0,0 +1,14 @@
+-- +migrate Up
+
+-- Existing accounts predate currencies and are all in US dollars
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+-- One unit of base_currency is worth rate units of quote_currency from rate_date until the next rate of the pair
+CREATE TABLE exchange_rates
+(
+    rate_date      DATE           NOT NULL,
+    base_currency  CHAR(3)        NOT NULL,
+    quote_currency CHAR(3)        NOT NULL,
+    rate           DECIMAL(18, 8) NOT NULL,
+    PRIMARY KEY (rate_date, base_currency, quote_currency)
+);
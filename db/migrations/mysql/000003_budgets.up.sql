@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- A spending limit of a category per period, from the period containing start_date.
+-- With rollover the unspent amount of each period, or the overspending, carries over to the next one.
+CREATE TABLE budgets
+(
+    budget_id   INT AUTO_INCREMENT PRIMARY KEY,
+    category_id INT            NOT NULL,
+    period      VARCHAR(10)    NOT NULL CHECK (period IN ('week', 'month', 'quarter', 'year')),
+    amount      DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    rollover    BOOLEAN        NOT NULL DEFAULT FALSE,
+    start_date  DATE           NOT NULL,
+    created_at  DATETIME(6)    NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at  DATETIME(6)    NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    UNIQUE (category_id, period)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS statement_balances;
+DROP INDEX ux_transactions_external_id ON transactions;
+ALTER TABLE transactions DROP COLUMN external_id;
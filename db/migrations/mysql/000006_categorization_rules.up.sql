@@ -0,0 +1,23 @@
+-- +migrate Up
+
+-- A rule assigning category_id to the transactions matching all of its conditions; null conditions match every
+-- transaction. Rules are tried by descending priority, then by rule_id, and the first match wins.
+CREATE TABLE categorization_rules
+(
+    rule_id              INT AUTO_INCREMENT PRIMARY KEY,
+    name                 VARCHAR(100)   NOT NULL,
+    priority             INT            NOT NULL DEFAULT 0,
+    category_id          INT            NOT NULL,
+    account_id           INT,
+    description_contains VARCHAR(255),
+    description_pattern  VARCHAR(255),
+    counterparty         VARCHAR(255),
+    min_amount           DECIMAL(10, 2),
+    max_amount           DECIMAL(10, 2),
+    created_at           DATETIME(6)    NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at           DATETIME(6)    NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+    CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount)
+);
+CREATE INDEX ix_categorization_rules_priority ON categorization_rules (priority DESC, rule_id);
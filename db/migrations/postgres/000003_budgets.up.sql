@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- A spending limit of a category per period, from the period containing start_date.
+-- With rollover the unspent amount of each period, or the overspending, carries over to the next one.
+CREATE TABLE budgets
+(
+    budget_id   SERIAL PRIMARY KEY,
+    category_id INT            NOT NULL,
+    period      VARCHAR(10)    NOT NULL CHECK (period IN ('week', 'month', 'quarter', 'year')),
+    amount      NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    rollover    BOOLEAN        NOT NULL DEFAULT FALSE,
+    start_date  DATE           NOT NULL,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    UNIQUE (category_id, period)
+);
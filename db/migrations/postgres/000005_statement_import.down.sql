@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS statement_balances;
+DROP INDEX IF EXISTS ux_transactions_external_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- The bank's ID of an imported transaction, such as the FITID of an OFX statement, so that re-imports skip it
+ALTER TABLE transactions ADD COLUMN external_id VARCHAR(255);
+CREATE UNIQUE INDEX ux_transactions_external_id ON transactions (account_id, external_id);
+
+-- The ledger balance of an account as of a day, as stated by an imported bank statement
+CREATE TABLE statement_balances
+(
+    account_id   INT            NOT NULL,
+    balance_date DATE           NOT NULL,
+    balance      NUMERIC(12, 2) NOT NULL,
+    created_at   TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    PRIMARY KEY (account_id, balance_date),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- A spending limit of a category per period, from the period containing start_date.
+-- With rollover the unspent amount of each period, or the overspending, carries over to the next one.
+CREATE TABLE budgets
+(
+    budget_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    category_id INTEGER        NOT NULL,
+    period      TEXT           NOT NULL CHECK (period IN ('week', 'month', 'quarter', 'year')),
+    amount      NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    rollover    BOOLEAN        NOT NULL DEFAULT FALSE,
+    start_date  DATE           NOT NULL,
+    created_at  DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    UNIQUE (category_id, period)
+);
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+-- A rule assigning category_id to the transactions matching all of its conditions; null conditions match every
+-- transaction. Rules are tried by descending priority, then by rule_id, and the first match wins.
+CREATE TABLE categorization_rules
+(
+    rule_id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    name                 TEXT           NOT NULL,
+    priority             INTEGER        NOT NULL DEFAULT 0,
+    category_id          INTEGER        NOT NULL,
+    account_id           INTEGER,
+    description_contains TEXT,
+    description_pattern  TEXT,
+    counterparty         TEXT,
+    min_amount           NUMERIC(10, 2),
+    max_amount           NUMERIC(10, 2),
+    created_at           DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+    CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount)
+);
+CREATE INDEX ix_categorization_rules_priority ON categorization_rules (priority DESC, rule_id);
@@ -0,0 +1,961 @@
+-- +migrate Up
+
+CREATE TABLE accounts
+(
+    account_id   INT IDENTITY (1, 1) PRIMARY KEY,
+    name         NVARCHAR(255)  NOT NULL,
+    account_type NVARCHAR(50)   NOT NULL,
+    created_at   DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    updated_at   DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET()
+);
+
+CREATE TABLE categories
+(
+    category_id   INT IDENTITY (1, 1) PRIMARY KEY,
+    name          NVARCHAR(255)  NOT NULL,
+    category_type NVARCHAR(50)   NOT NULL,
+    created_at    DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    updated_at    DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    UNIQUE (name)
+);
+
+CREATE TABLE transactions
+(
+    transaction_id   INT IDENTITY (1, 1) PRIMARY KEY,
+    account_id       INT            NOT NULL,
+    category_id      INT            NOT NULL,
+    amount           DECIMAL(10, 2) NOT NULL,
+    transaction_date DATE           NOT NULL,
+    description      NVARCHAR(MAX),
+    created_at       DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    updated_at       DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+    FOREIGN KEY (category_id) REFERENCES categories (category_id)
+);
+
+CREATE INDEX idx_transactions_account_id ON transactions (account_id);
+CREATE INDEX idx_transactions_category_id ON transactions (category_id);
+CREATE INDEX idx_transactions_date ON transactions (transaction_date);
+
+INSERT INTO accounts (name, account_type, created_at, updated_at)
+VALUES ('Checking Account ****0001', 'Checking', '2018-11-21 15:46:51+00:00', '2021-02-11 15:46:51+00:00'),
+       ('Checking Account ****0002', 'Checking', '2020-02-24 20:47:08+00:00', '2020-02-24 20:47:08+00:00'),
+       ('Checking Account ****0003', 'Checking', '2021-05-26 22:24:45+00:00', '2023-10-18 22:24:45+00:00'),
+       ('Checking Account ****0004', 'Checking', '2020-09-25 01:46:40+00:00', '2020-09-25 01:46:40+00:00'),
+       ('Checking Account ****0005', 'Checking', '2023-04-22 07:50:07+00:00', '2024-07-13 07:50:07+00:00'),
+       ('Checking Account ****0006', 'Checking', '2021-01-30 06:45:41+00:00', '2021-01-30 06:45:41+00:00'),
+       ('Checking Account ****0007', 'Checking', '2018-07-07 22:00:49+00:00', '2018-07-07 22:00:49+00:00'),
+       ('Checking Account ****0008', 'Checking', '2020-01-13 10:22:24+00:00', '2020-01-13 10:22:24+00:00'),
+       ('Checking Account ****0009', 'Checking', '2022-07-26 07:32:29+00:00', '2022-07-26 07:32:29+00:00'),
+       ('Checking Account ****0010', 'Checking', '2022-08-25 23:55:42+00:00', '2022-08-25 23:55:42+00:00'),
+       ('Checking Account ****0011', 'Checking', '2020-12-29 16:50:13+00:00', '2023-09-25 16:50:13+00:00'),
+       ('Checking Account ****0012', 'Checking', '2021-07-27 03:32:13+00:00', '2021-07-27 03:32:13+00:00'),
+       ('Checking Account ****0013', 'Checking', '2018-03-16 11:49:07+00:00', '2018-03-16 11:49:07+00:00'),
+       ('Checking Account ****0014', 'Checking', '2020-04-10 09:39:21+00:00', '2022-02-04 09:39:21+00:00'),
+       ('Checking Account ****0015', 'Checking', '2018-01-03 13:21:31+00:00', '2018-01-03 13:21:31+00:00'),
+       ('Checking Account ****0016', 'Checking', '2019-07-28 06:16:20+00:00', '2019-07-28 06:16:20+00:00'),
+       ('Checking Account ****0017', 'Checking', '2022-06-09 04:33:03+00:00', '2022-06-09 04:33:03+00:00'),
+       ('Checking Account ****0018', 'Checking', '2020-10-03 02:26:16+00:00', '2020-10-03 02:26:16+00:00'),
+       ('Checking Account ****0019', 'Checking', '2019-11-12 18:38:28+00:00', '2019-11-12 18:38:28+00:00'),
+       ('Checking Account ****0020', 'Checking', '2021-09-02 16:36:37+00:00', '2021-09-02 16:36:37+00:00'),
+       ('Checking Account ****0021', 'Checking', '2020-11-18 08:46:52+00:00', '2020-11-18 08:46:52+00:00'),
+       ('Checking Account ****0022', 'Checking', '2019-12-06 01:36:21+00:00', '2019-12-06 01:36:21+00:00'),
+       ('Checking Account ****0023', 'Checking', '2021-11-27 15:53:39+00:00', '2024-07-04 15:53:39+00:00'),
+       ('Checking Account ****0024', 'Checking', '2020-05-27 06:21:02+00:00', '2020-05-27 06:21:02+00:00'),
+       ('Checking Account ****0025', 'Checking', '2019-07-09 07:51:59+00:00', '2019-07-09 07:51:59+00:00'),
+       ('Checking Account ****0026', 'Checking', '2020-02-26 00:12:06+00:00', '2020-02-26 00:12:06+00:00'),
+       ('Checking Account ****0027', 'Checking', '2019-12-17 09:26:25+00:00', '2020-06-05 09:26:25+00:00'),
+       ('Checking Account ****0028', 'Checking', '2020-04-18 10:38:22+00:00', '2020-04-18 10:38:22+00:00'),
+       ('Checking Account ****0029', 'Checking', '2022-07-16 05:21:38+00:00', '2022-07-16 05:21:38+00:00'),
+       ('Checking Account ****0030', 'Checking', '2021-01-19 02:11:05+00:00', '2021-01-19 02:11:05+00:00'),
+       ('Checking Account ****0031', 'Checking', '2022-11-20 05:05:34+00:00', '2022-11-20 05:05:34+00:00'),
+       ('Checking Account ****0032', 'Checking', '2018-07-16 16:41:59+00:00', '2018-07-16 16:41:59+00:00'),
+       ('Checking Account ****0033', 'Checking', '2021-10-26 09:07:27+00:00', '2021-10-26 09:07:27+00:00'),
+       ('Checking Account ****0034', 'Checking', '2019-10-23 22:44:02+00:00', '2019-10-23 22:44:02+00:00'),
+       ('Checking Account ****0035', 'Checking', '2019-02-03 15:49:08+00:00', '2019-02-03 15:49:08+00:00'),
+       ('Checking Account ****0036', 'Checking', '2022-07-03 12:06:56+00:00', '2024-02-28 12:06:56+00:00'),
+       ('Checking Account ****0037', 'Checking', '2021-08-17 08:20:51+00:00', '2024-04-13 08:20:51+00:00'),
+       ('Checking Account ****0038', 'Checking', '2019-06-20 18:59:54+00:00', '2019-06-20 18:59:54+00:00'),
+       ('Checking Account ****0039', 'Checking', '2022-05-23 06:14:25+00:00', '2022-05-23 06:14:25+00:00'),
+       ('Checking Account ****0040', 'Checking', '2018-05-17 09:06:32+00:00', '2021-02-12 09:06:32+00:00'),
+       ('Savings Account ****0001', 'Savings', '2021-05-23 02:08:38+00:00', '2021-05-23 02:08:38+00:00'),
+       ('Savings Account ****0002', 'Savings', '2019-08-20 22:35:26+00:00', '2022-06-15 22:35:26+00:00'),
+       ('Savings Account ****0003', 'Savings', '2018-03-07 19:26:47+00:00', '2018-03-07 19:26:47+00:00'),
+       ('Savings Account ****0004', 'Savings', '2023-08-21 05:11:35+00:00', '2023-08-21 05:11:35+00:00'),
+       ('Savings Account ****0005', 'Savings', '2021-06-19 08:25:55+00:00', '2021-06-19 08:25:55+00:00'),
+       ('Savings Account ****0006', 'Savings', '2019-12-31 00:52:27+00:00', '2019-12-31 00:52:27+00:00'),
+       ('Savings Account ****0007', 'Savings', '2018-10-17 09:43:38+00:00', '2018-10-17 09:43:38+00:00'),
+       ('Savings Account ****0008', 'Savings', '2021-02-02 05:15:17+00:00', '2023-08-30 05:15:17+00:00'),
+       ('Savings Account ****0009', 'Savings', '2018-12-14 19:38:43+00:00', '2019-08-10 19:38:43+00:00'),
+       ('Savings Account ****0010', 'Savings', '2019-08-17 01:42:33+00:00', '2019-08-17 01:42:33+00:00'),
+       ('Savings Account ****0011', 'Savings', '2023-03-07 23:30:54+00:00', '2023-03-07 23:30:54+00:00'),
+       ('Savings Account ****0012', 'Savings', '2018-05-28 13:57:06+00:00', '2018-05-28 13:57:06+00:00'),
+       ('Savings Account ****0013', 'Savings', '2018-05-16 17:20:16+00:00', '2020-11-06 17:20:16+00:00'),
+       ('Savings Account ****0014', 'Savings', '2018-10-05 16:47:16+00:00', '2018-10-05 16:47:16+00:00'),
+       ('Savings Account ****0015', 'Savings', '2019-09-02 10:23:09+00:00', '2019-09-02 10:23:09+00:00'),
+       ('Savings Account ****0016', 'Savings', '2019-07-08 00:15:26+00:00', '2019-07-08 00:15:26+00:00'),
+       ('Savings Account ****0017', 'Savings', '2018-08-12 10:02:53+00:00', '2018-08-12 10:02:53+00:00'),
+       ('Savings Account ****0018', 'Savings', '2022-06-04 02:26:04+00:00', '2022-06-04 02:26:04+00:00'),
+       ('Savings Account ****0019', 'Savings', '2018-01-15 19:21:18+00:00', '2018-01-15 19:21:18+00:00'),
+       ('Savings Account ****0020', 'Savings', '2019-10-25 16:30:00+00:00', '2019-10-25 16:30:00+00:00'),
+       ('Savings Account ****0021', 'Savings', '2020-02-15 15:51:59+00:00', '2020-02-15 15:51:59+00:00'),
+       ('Savings Account ****0022', 'Savings', '2019-01-18 04:00:37+00:00', '2019-01-18 04:00:37+00:00'),
+       ('Savings Account ****0023', 'Savings', '2018-08-15 02:22:24+00:00', '2018-08-15 02:22:24+00:00'),
+       ('Savings Account ****0024', 'Savings', '2021-10-16 20:47:52+00:00', '2021-10-16 20:47:52+00:00'),
+       ('Savings Account ****0025', 'Savings', '2018-09-11 11:31:41+00:00', '2019-12-08 11:31:41+00:00'),
+       ('Savings Account ****0026', 'Savings', '2021-12-03 12:09:01+00:00', '2021-12-03 12:09:01+00:00'),
+       ('Savings Account ****0027', 'Savings', '2018-07-08 15:57:34+00:00', '2018-07-08 15:57:34+00:00'),
+       ('Savings Account ****0028', 'Savings', '2019-04-19 11:04:46+00:00', '2019-04-19 11:04:46+00:00'),
+       ('Savings Account ****0029', 'Savings', '2021-05-13 00:25:57+00:00', '2021-05-13 00:25:57+00:00'),
+       ('Savings Account ****0030', 'Savings', '2018-05-16 23:19:55+00:00', '2018-05-16 23:19:55+00:00'),
+       ('Savings Account ****0031', 'Savings', '2019-05-11 00:56:03+00:00', '2019-05-11 00:56:03+00:00'),
+       ('Savings Account ****0032', 'Savings', '2019-10-23 14:08:59+00:00', '2019-10-23 14:08:59+00:00'),
+       ('Savings Account ****0033', 'Savings', '2018-08-20 04:33:49+00:00', '2018-08-20 04:33:49+00:00'),
+       ('Savings Account ****0034', 'Savings', '2020-09-01 18:33:01+00:00', '2020-09-01 18:33:01+00:00'),
+       ('Savings Account ****0035', 'Savings', '2018-07-04 03:53:21+00:00', '2018-07-04 03:53:21+00:00'),
+       ('Savings Account ****0036', 'Savings', '2022-08-08 09:47:41+00:00', '2022-08-08 09:47:41+00:00'),
+       ('Savings Account ****0037', 'Savings', '2019-01-26 18:13:16+00:00', '2019-01-26 18:13:16+00:00'),
+       ('Savings Account ****0038', 'Savings', '2018-11-29 19:39:57+00:00', '2018-11-29 19:39:57+00:00'),
+       ('Savings Account ****0039', 'Savings', '2020-06-25 05:19:33+00:00', '2020-06-25 05:19:33+00:00'),
+       ('Savings Account ****0040', 'Savings', '2019-05-18 15:52:29+00:00', '2019-05-18 15:52:29+00:00'),
+       ('Credit Card ****3001', 'Credit Card', '2018-02-28 18:55:46+00:00', '2018-02-28 18:55:46+00:00'),
+       ('Credit Card ****3002', 'Credit Card', '2020-01-27 18:01:11+00:00', '2022-11-23 18:01:11+00:00'),
+       ('Credit Card ****3003', 'Credit Card', '2021-03-21 17:01:32+00:00', '2023-12-15 17:01:32+00:00'),
+       ('Credit Card ****3004', 'Credit Card', '2018-02-17 07:07:35+00:00', '2018-02-17 07:07:35+00:00'),
+       ('Credit Card ****3005', 'Credit Card', '2022-03-12 20:56:33+00:00', '2022-03-12 20:56:33+00:00'),
+       ('Credit Card ****3006', 'Credit Card', '2020-05-22 11:49:25+00:00', '2020-05-22 11:49:25+00:00'),
+       ('Credit Card ****3007', 'Credit Card', '2020-07-30 16:32:16+00:00', '2020-07-30 16:32:16+00:00'),
+       ('Credit Card ****3008', 'Credit Card', '2019-02-03 02:39:08+00:00', '2019-02-03 02:39:08+00:00'),
+       ('Credit Card ****3009', 'Credit Card', '2021-02-26 02:15:47+00:00', '2023-07-13 02:15:47+00:00'),
+       ('Credit Card ****3010', 'Credit Card', '2020-01-05 07:34:25+00:00', '2020-01-05 07:34:25+00:00'),
+       ('Credit Card ****3011', 'Credit Card', '2021-10-06 05:15:23+00:00', '2021-10-06 05:15:23+00:00'),
+       ('Credit Card ****3012', 'Credit Card', '2018-08-26 01:50:48+00:00', '2018-08-26 01:50:48+00:00'),
+       ('Credit Card ****3013', 'Credit Card', '2021-10-20 14:39:08+00:00', '2024-09-15 14:39:08+00:00'),
+       ('Credit Card ****3014', 'Credit Card', '2019-01-10 15:25:00+00:00', '2019-01-10 15:25:00+00:00'),
+       ('Credit Card ****3015', 'Credit Card', '2018-11-29 07:31:53+00:00', '2021-06-16 07:31:53+00:00'),
+       ('Credit Card ****3016', 'Credit Card', '2022-04-06 06:37:27+00:00', '2022-04-06 06:37:27+00:00'),
+       ('Credit Card ****3017', 'Credit Card', '2022-03-08 22:09:15+00:00', '2022-03-08 22:09:15+00:00'),
+       ('Credit Card ****3018', 'Credit Card', '2023-07-30 09:42:00+00:00', '2023-07-30 09:42:00+00:00'),
+       ('Credit Card ****3019', 'Credit Card', '2022-04-12 23:58:07+00:00', '2022-04-12 23:58:07+00:00'),
+       ('Credit Card ****3020', 'Credit Card', '2021-05-16 21:26:06+00:00', '2021-05-16 21:26:06+00:00'),
+       ('Credit Card ****3021', 'Credit Card', '2020-09-15 16:13:41+00:00', '2023-05-31 16:13:41+00:00'),
+       ('Credit Card ****3022', 'Credit Card', '2019-06-08 18:16:24+00:00', '2022-03-04 18:16:24+00:00'),
+       ('Credit Card ****3023', 'Credit Card', '2022-01-27 20:00:56+00:00', '2022-01-27 20:00:56+00:00'),
+       ('Credit Card ****3024', 'Credit Card', '2022-04-04 22:26:17+00:00', '2022-04-04 22:26:17+00:00'),
+       ('Credit Card ****3025', 'Credit Card', '2022-11-09 18:14:49+00:00', '2022-11-09 18:14:49+00:00'),
+       ('Credit Card ****3026', 'Credit Card', '2018-09-28 01:07:22+00:00', '2018-09-28 01:07:22+00:00'),
+       ('Credit Card ****3027', 'Credit Card', '2022-12-18 22:56:37+00:00', '2022-12-18 22:56:37+00:00'),
+       ('Credit Card ****3028', 'Credit Card', '2018-06-20 04:52:33+00:00', '2018-06-20 04:52:33+00:00'),
+       ('Credit Card ****3029', 'Credit Card', '2021-04-14 20:29:29+00:00', '2021-04-14 20:29:29+00:00'),
+       ('Credit Card ****3030', 'Credit Card', '2019-03-25 01:54:50+00:00', '2019-03-25 01:54:50+00:00'),
+       ('Mortgage Loan #001', 'Loan', '2019-08-13 13:50:15+00:00', '2019-08-13 13:50:15+00:00'),
+       ('Mortgage Loan #002', 'Loan', '2019-09-04 00:49:03+00:00', '2019-09-04 00:49:03+00:00'),
+       ('Mortgage Loan #003', 'Loan', '2018-02-26 21:17:26+00:00', '2018-02-26 21:17:26+00:00'),
+       ('Mortgage Loan #004', 'Loan', '2019-08-02 20:14:42+00:00', '2019-08-02 20:14:42+00:00'),
+       ('Mortgage Loan #005', 'Loan', '2018-07-29 21:33:41+00:00', '2018-07-29 21:33:41+00:00'),
+       ('Mortgage Loan #006', 'Loan', '2019-05-15 19:04:12+00:00', '2019-05-15 19:04:12+00:00'),
+       ('Mortgage Loan #007', 'Loan', '2020-01-30 21:24:59+00:00', '2020-01-30 21:24:59+00:00'),
+       ('Mortgage Loan #008', 'Loan', '2019-06-30 22:46:00+00:00', '2019-06-30 22:46:00+00:00'),
+       ('Mortgage Loan #009', 'Loan', '2022-02-27 00:13:26+00:00', '2022-02-27 00:13:26+00:00'),
+       ('Mortgage Loan #010', 'Loan', '2019-01-08 13:26:10+00:00', '2019-01-08 13:26:10+00:00'),
+       ('Auto Loan #001', 'Loan', '2022-12-23 12:58:04+00:00', '2022-12-23 12:58:04+00:00'),
+       ('Auto Loan #002', 'Loan', '2020-06-09 22:31:07+00:00', '2020-06-09 22:31:07+00:00'),
+       ('Auto Loan #003', 'Loan', '2018-02-12 18:46:25+00:00', '2018-02-12 18:46:25+00:00'),
+       ('Auto Loan #004', 'Loan', '2019-12-26 17:11:29+00:00', '2019-12-26 17:11:29+00:00'),
+       ('Auto Loan #005', 'Loan', '2019-03-18 21:08:18+00:00', '2019-03-18 21:08:18+00:00'),
+       ('Auto Loan #006', 'Loan', '2019-04-22 05:22:45+00:00', '2019-04-22 05:22:45+00:00'),
+       ('Auto Loan #007', 'Loan', '2020-03-05 04:55:49+00:00', '2020-03-05 04:55:49+00:00'),
+       ('Auto Loan #008', 'Loan', '2020-11-27 06:39:17+00:00', '2020-11-27 06:39:17+00:00'),
+       ('Auto Loan #009', 'Loan', '2020-09-06 02:46:01+00:00', '2020-09-06 02:46:01+00:00'),
+       ('Auto Loan #010', 'Loan', '2018-01-31 15:04:18+00:00', '2018-01-31 15:04:18+00:00'),
+       ('Personal Loan #001', 'Loan', '2018-05-09 03:37:04+00:00', '2018-05-09 03:37:04+00:00'),
+       ('Personal Loan #002', 'Loan', '2018-10-05 03:53:04+00:00', '2018-10-05 03:53:04+00:00'),
+       ('Personal Loan #003', 'Loan', '2018-06-30 15:46:57+00:00', '2018-06-30 15:46:57+00:00'),
+       ('Personal Loan #004', 'Loan', '2019-06-14 23:46:21+00:00', '2019-06-14 23:46:21+00:00'),
+       ('Personal Loan #005', 'Loan', '2019-02-13 10:25:22+00:00', '2019-02-13 10:25:22+00:00'),
+       ('Personal Loan #006', 'Loan', '2021-09-11 16:13:44+00:00', '2021-09-11 16:13:44+00:00'),
+       ('Personal Loan #007', 'Loan', '2020-05-06 10:39:01+00:00', '2020-05-06 10:39:01+00:00'),
+       ('Personal Loan #008', 'Loan', '2020-01-21 04:05:25+00:00', '2020-01-21 04:05:25+00:00'),
+       ('Personal Loan #009', 'Loan', '2021-03-12 03:30:57+00:00', '2024-02-06 03:30:57+00:00'),
+       ('Personal Loan #010', 'Loan', '2021-01-06 09:53:44+00:00', '2021-01-06 09:53:44+00:00'),
+       ('Brokerage Account 1', 'Investment', '2019-03-14 11:52:20+00:00', '2021-12-08 11:52:20+00:00'),
+       ('Brokerage Account 2', 'Investment', '2020-11-23 09:22:10+00:00', '2020-11-23 09:22:10+00:00'),
+       ('Brokerage Account 3', 'Investment', '2019-02-09 10:05:11+00:00', '2019-02-09 10:05:11+00:00'),
+       ('Brokerage Account 4', 'Investment', '2018-12-24 03:59:54+00:00', '2018-12-24 03:59:54+00:00'),
+       ('Brokerage Account 5', 'Investment', '2018-12-18 13:57:25+00:00', '2018-12-18 13:57:25+00:00'),
+       ('Brokerage Account 6', 'Investment', '2019-10-02 19:27:21+00:00', '2019-10-02 19:27:21+00:00'),
+       ('Brokerage Account 7', 'Investment', '2023-10-06 05:09:25+00:00', '2023-10-06 05:09:25+00:00'),
+       ('Brokerage Account 8', 'Investment', '2019-08-12 00:57:24+00:00', '2019-08-12 00:57:24+00:00'),
+       ('Brokerage Account 9', 'Investment', '2018-09-15 05:42:22+00:00', '2021-07-12 05:42:22+00:00'),
+       ('Brokerage Account 10', 'Investment', '2023-02-11 06:25:43+00:00', '2023-02-11 06:25:43+00:00'),
+       ('Retirement Account 1', 'Investment', '2020-05-14 08:20:31+00:00', '2020-05-14 08:20:31+00:00'),
+       ('Retirement Account 2', 'Investment', '2023-08-03 01:39:58+00:00', '2023-08-03 01:39:58+00:00'),
+       ('Retirement Account 3', 'Investment', '2018-06-18 09:13:54+00:00', '2018-06-18 09:13:54+00:00'),
+       ('Retirement Account 4', 'Investment', '2019-12-06 06:26:04+00:00', '2019-12-06 06:26:04+00:00'),
+       ('Retirement Account 5', 'Investment', '2021-06-17 22:52:35+00:00', '2021-06-17 22:52:35+00:00'),
+       ('Retirement Account 6', 'Investment', '2019-07-14 11:52:35+00:00', '2019-07-14 11:52:35+00:00'),
+       ('Retirement Account 7', 'Investment', '2018-10-30 20:10:31+00:00', '2018-10-30 20:10:31+00:00'),
+       ('Retirement Account 8', 'Investment', '2018-05-08 12:37:08+00:00', '2018-05-08 12:37:08+00:00'),
+       ('Retirement Account 9', 'Investment', '2020-12-28 17:03:55+00:00', '2020-12-28 17:03:55+00:00'),
+       ('Retirement Account 10', 'Investment', '2020-04-15 17:07:49+00:00', '2020-04-15 17:07:49+00:00'),
+       ('Crypto Wallet 1', 'Investment', '2022-07-04 03:10:24+00:00', '2022-07-04 03:10:24+00:00'),
+       ('Crypto Wallet 2', 'Investment', '2018-11-02 01:04:02+00:00', '2018-11-02 01:04:02+00:00'),
+       ('Crypto Wallet 3', 'Investment', '2023-05-15 16:17:50+00:00', '2023-05-15 16:17:50+00:00'),
+       ('Crypto Wallet 4', 'Investment', '2021-06-08 05:49:26+00:00', '2021-06-08 05:49:26+00:00'),
+       ('Crypto Wallet 5', 'Investment', '2020-06-20 19:51:12+00:00', '2020-06-20 19:51:12+00:00'),
+       ('Investment Account 1', 'Investment', '2020-02-06 12:07:36+00:00', '2020-02-06 12:07:36+00:00'),
+       ('Investment Account 2', 'Investment', '2018-06-07 06:51:31+00:00', '2018-06-07 06:51:31+00:00'),
+       ('Investment Account 3', 'Investment', '2022-04-25 09:31:14+00:00', '2022-04-25 09:31:14+00:00'),
+       ('Investment Account 4', 'Investment', '2018-12-08 22:47:07+00:00', '2018-12-08 22:47:07+00:00'),
+       ('Investment Account 5', 'Investment', '2023-07-18 23:14:30+00:00', '2023-07-18 23:14:30+00:00'),
+       ('Cash Wallet 1', 'Cash', '2021-08-30 14:57:17+00:00', '2021-08-30 14:57:17+00:00'),
+       ('Cash Wallet 2', 'Cash', '2018-03-20 20:31:08+00:00', '2018-03-20 20:31:08+00:00'),
+       ('Cash Wallet 3', 'Cash', '2018-10-07 12:24:23+00:00', '2018-10-07 12:24:23+00:00'),
+       ('Cash Wallet 4', 'Cash', '2018-06-23 05:40:42+00:00', '2018-06-23 05:40:42+00:00'),
+       ('Cash Wallet 5', 'Cash', '2020-06-28 10:40:39+00:00', '2020-06-28 10:40:39+00:00'),
+       ('Cash Wallet 6', 'Cash', '2018-02-18 03:21:39+00:00', '2020-05-14 03:21:39+00:00'),
+       ('Cash Wallet 7', 'Cash', '2018-02-27 06:45:18+00:00', '2018-02-27 06:45:18+00:00'),
+       ('Cash Wallet 8', 'Cash', '2018-09-24 11:34:29+00:00', '2018-09-24 11:34:29+00:00'),
+       ('Cash Wallet 9', 'Cash', '2020-06-02 02:30:54+00:00', '2020-06-02 02:30:54+00:00'),
+       ('Cash Wallet 10', 'Cash', '2023-06-10 07:06:03+00:00', '2023-06-10 07:06:03+00:00'),
+       ('Cash Box 1', 'Cash', '2019-06-09 10:19:17+00:00', '2019-06-09 10:19:17+00:00'),
+       ('Cash Box 2', 'Cash', '2020-06-03 06:02:23+00:00', '2020-06-03 06:02:23+00:00'),
+       ('Cash Box 3', 'Cash', '2020-09-05 08:36:44+00:00', '2020-09-05 08:36:44+00:00'),
+       ('Cash Box 4', 'Cash', '2020-05-20 01:49:53+00:00', '2020-05-20 01:49:53+00:00'),
+       ('Cash Box 5', 'Cash', '2021-12-04 00:38:05+00:00', '2024-07-25 00:38:05+00:00'),
+       ('Cash Box 6', 'Cash', '2019-02-04 12:43:46+00:00', '2019-02-04 12:43:46+00:00'),
+       ('Cash Box 7', 'Cash', '2021-11-16 23:43:14+00:00', '2024-09-04 23:43:14+00:00'),
+       ('Cash Box 8', 'Cash', '2018-08-28 07:34:03+00:00', '2018-08-28 07:34:03+00:00'),
+       ('Cash Box 9', 'Cash', '2020-12-18 03:04:55+00:00', '2020-12-18 03:04:55+00:00'),
+       ('Cash Box 10', 'Cash', '2020-06-20 23:07:39+00:00', '2020-06-20 23:07:39+00:00'),
+       ('Petty Cash 1', 'Cash', '2019-01-17 17:39:18+00:00', '2019-01-17 17:39:18+00:00'),
+       ('Petty Cash 2', 'Cash', '2021-09-15 22:38:11+00:00', '2021-09-15 22:38:11+00:00'),
+       ('Petty Cash 3', 'Cash', '2018-02-04 00:32:25+00:00', '2018-02-04 00:32:25+00:00'),
+       ('Petty Cash 4', 'Cash', '2018-09-22 19:46:59+00:00', '2018-09-22 19:46:59+00:00'),
+       ('Petty Cash 5', 'Cash', '2020-10-29 04:25:30+00:00', '2020-10-29 04:25:30+00:00'),
+       ('Petty Cash 6', 'Cash', '2018-11-26 00:02:34+00:00', '2018-11-26 00:02:34+00:00'),
+       ('Petty Cash 7', 'Cash', '2022-07-13 00:17:56+00:00', '2022-07-13 00:17:56+00:00'),
+       ('Petty Cash 8', 'Cash', '2020-11-06 06:05:52+00:00', '2020-11-06 06:05:52+00:00'),
+       ('Petty Cash 9', 'Cash', '2021-03-25 06:56:40+00:00', '2021-03-25 06:56:40+00:00'),
+       ('Petty Cash 10', 'Cash', '2019-05-28 22:54:08+00:00', '2019-05-28 22:54:08+00:00');
+
+INSERT INTO categories (name, category_type, created_at, updated_at)
+VALUES ('Mortgage', 'Expense', '2019-12-28 13:07:17+00:00', '2022-06-01 13:07:17+00:00'),
+       ('Rent', 'Expense', '2021-01-15 14:25:31+00:00', '2021-01-15 14:25:31+00:00'),
+       ('Home Maintenance', 'Expense', '2022-02-16 12:45:31+00:00', '2022-02-16 12:45:31+00:00'),
+       ('Property Taxes', 'Expense', '2020-11-27 08:46:14+00:00', '2022-07-24 08:46:14+00:00'),
+       ('HOA Dues', 'Expense', '2021-07-30 12:20:32+00:00', '2021-07-30 12:20:32+00:00'),
+       ('Home Warranty', 'Expense', '2020-11-07 11:33:11+00:00', '2020-11-07 11:33:11+00:00'),
+       ('Large Appliances', 'Expense', '2019-04-11 11:58:15+00:00', '2019-04-11 11:58:15+00:00'),
+       ('Lawn Care', 'Expense', '2022-08-29 02:40:00+00:00', '2022-08-29 02:40:00+00:00'),
+       ('Electricity', 'Expense', '2021-03-18 17:53:21+00:00', '2021-03-18 17:53:21+00:00'),
+       ('Gas (Utility)', 'Expense', '2020-07-02 01:35:40+00:00', '2020-07-02 01:35:40+00:00'),
+       ('Heating', 'Expense', '2019-02-10 18:48:58+00:00', '2019-02-10 18:48:58+00:00'),
+       ('Water', 'Expense', '2018-07-09 12:35:41+00:00', '2018-07-09 12:35:41+00:00'),
+       ('Internet', 'Expense', '2019-10-25 17:52:56+00:00', '2019-10-25 17:52:56+00:00'),
+       ('Cable', 'Expense', '2019-12-18 22:24:09+00:00', '2019-12-18 22:24:09+00:00'),
+       ('Phone', 'Expense', '2021-02-24 01:23:56+00:00', '2023-07-18 01:23:56+00:00'),
+       ('Cellphone', 'Expense', '2019-10-11 12:42:47+00:00', '2019-10-11 12:42:47+00:00'),
+       ('Trash', 'Expense', '2018-07-09 12:31:22+00:00', '2018-07-09 12:31:22+00:00'),
+       ('Recycling', 'Expense', '2021-04-17 08:02:37+00:00', '2021-04-17 08:02:37+00:00'),
+       ('Sewer', 'Expense', '2021-05-21 02:22:40+00:00', '2021-05-21 02:22:40+00:00'),
+       ('Groceries', 'Expense', '2018-09-16 23:17:22+00:00', '2018-09-16 23:17:22+00:00'),
+       ('Restaurants', 'Expense', '2020-03-06 15:29:04+00:00', '2020-03-06 15:29:04+00:00'),
+       ('Takeout', 'Expense', '2022-01-06 13:09:09+00:00', '2022-01-06 13:09:09+00:00'),
+       ('Fast Food', 'Expense', '2019-09-17 19:57:01+00:00', '2019-09-17 19:57:01+00:00'),
+       ('Coffee Shops', 'Expense', '2022-08-03 23:22:30+00:00', '2022-08-03 23:22:30+00:00'),
+       ('Alcohol & Bars', 'Expense', '2018-01-17 06:52:14+00:00', '2018-01-17 06:52:14+00:00'),
+       ('Gas/Fuel', 'Expense', '2021-05-09 20:29:15+00:00', '2024-01-05 20:29:15+00:00'),
+       ('Car Maintenance', 'Expense', '2018-11-25 03:07:36+00:00', '2018-11-25 03:07:36+00:00'),
+       ('Auto Insurance', 'Expense', '2018-09-07 09:17:32+00:00', '2018-09-07 09:17:32+00:00'),
+       ('Parking Fees', 'Expense', '2021-01-21 08:25:31+00:00', '2021-01-21 08:25:31+00:00'),
+       ('Public Transportation', 'Expense', '2021-07-07 03:54:03+00:00', '2021-07-07 03:54:03+00:00'),
+       ('Airplane Tickets', 'Expense', '2018-08-15 06:18:17+00:00', '2023-04-08 06:18:17+00:00'),
+       ('Taxis', 'Expense', '2019-12-15 09:41:40+00:00', '2019-12-15 09:41:40+00:00'),
+       ('Ride Sharing (Uber/Lyft)', 'Expense', '2019-10-06 20:54:13+00:00', '2019-10-06 20:54:13+00:00'),
+       ('Tolls (EZ Pass)', 'Expense', '2022-07-16 12:18:13+00:00', '2022-07-16 12:18:13+00:00'),
+       ('DMV Fees', 'Expense', '2018-11-25 09:21:16+00:00', '2018-11-25 09:21:16+00:00'),
+       ('AAA Membership', 'Expense', '2020-01-23 10:41:19+00:00', '2020-01-23 10:41:19+00:00'),
+       ('Credit Card Payment', 'Expense', '2019-01-13 18:52:30+00:00', '2021-12-01 18:52:30+00:00'),
+       ('Student Loan Payment', 'Expense', '2019-08-14 07:27:38+00:00', '2019-08-14 07:27:38+00:00'),
+       ('Personal Loan Payment', 'Expense', '2022-08-08 10:32:45+00:00', '2022-08-08 10:32:45+00:00'),
+       ('Auto Loan Payment', 'Expense', '2019-01-14 22:46:11+00:00', '2019-01-14 22:46:11+00:00'),
+       ('Alimony (Paid)', 'Expense', '2020-01-14 21:03:25+00:00', '2020-01-14 21:03:25+00:00'),
+       ('Medical Insurance', 'Expense', '2019-07-21 04:45:39+00:00', '2019-07-21 04:45:39+00:00'),
+       ('Dental Insurance', 'Expense', '2020-04-13 20:01:25+00:00', '2020-04-13 20:01:25+00:00'),
+       ('Mortgage Insurance', 'Expense', '2019-07-24 02:59:28+00:00', '2019-07-24 02:59:28+00:00'),
+       ('Renters Insurance', 'Expense', '2020-02-20 11:17:28+00:00', '2020-02-20 11:17:28+00:00'),
+       ('Life Insurance', 'Expense', '2020-08-11 19:14:22+00:00', '2020-08-11 19:14:22+00:00'),
+       ('Property Insurance', 'Expense', '2020-07-03 06:45:09+00:00', '2020-07-03 06:45:09+00:00'),
+       ('Pet Insurance', 'Expense', '2018-11-22 14:39:05+00:00', '2018-11-22 14:39:05+00:00'),
+       ('Primary Care', 'Expense', '2020-04-13 22:42:46+00:00', '2020-04-13 22:42:46+00:00'),
+       ('Specialty Care', 'Expense', '2022-11-10 16:11:09+00:00', '2022-11-10 16:11:09+00:00'),
+       ('Dental Care', 'Expense', '2018-07-13 17:00:01+00:00', '2021-06-06 17:00:01+00:00'),
+       ('Urgent Care', 'Expense', '2020-11-24 07:11:21+00:00', '2020-11-24 07:11:21+00:00'),
+       ('Prescriptions', 'Expense', '2018-09-01 10:25:56+00:00', '2018-09-01 10:25:56+00:00'),
+       ('Medical Devices', 'Expense', '2018-03-17 17:23:07+00:00', '2018-03-17 17:23:07+00:00'),
+       ('Senior Care', 'Expense', '2019-03-02 21:40:58+00:00', '2019-03-02 21:40:58+00:00'),
+       ('Health Supplements', 'Expense', '2022-06-10 16:44:12+00:00', '2022-06-10 16:44:12+00:00'),
+       ('Child Support (Paid)', 'Expense', '2019-05-31 16:30:58+00:00', '2019-05-31 16:30:58+00:00'),
+       ('Baby Supplies', 'Expense', '2020-06-26 17:03:17+00:00', '2020-06-26 17:03:17+00:00'),
+       ('Daycare', 'Expense', '2018-11-12 00:19:21+00:00', '2018-11-12 00:19:21+00:00'),
+       ('Tuition', 'Expense', '2020-05-14 01:04:19+00:00', '2020-05-14 01:04:19+00:00'),
+       ('School Supplies', 'Expense', '2019-05-22 08:44:55+00:00', '2021-09-08 08:44:55+00:00'),
+       ('School Lunch', 'Expense', '2022-04-03 09:14:08+00:00', '2022-04-03 09:14:08+00:00'),
+       ('Extracurricular Activities', 'Expense', '2018-07-03 02:37:06+00:00', '2018-07-03 02:37:06+00:00'),
+       ('Tutoring', 'Expense', '2020-08-17 22:38:34+00:00', '2020-08-17 22:38:34+00:00'),
+       ('Allowance (Paid)', 'Expense', '2018-11-14 14:07:13+00:00', '2018-11-14 14:07:13+00:00'),
+       ('Babysitter', 'Expense', '2020-08-17 05:11:27+00:00', '2020-08-17 05:11:27+00:00'),
+       ('Clothing', 'Expense', '2019-04-09 23:33:19+00:00', '2019-04-09 23:33:19+00:00'),
+       ('Haircuts', 'Expense', '2020-07-18 11:51:03+00:00', '2020-07-18 11:51:03+00:00'),
+       ('Barber', 'Expense', '2018-06-25 16:16:57+00:00', '2018-06-25 16:16:57+00:00'),
+       ('Cosmetics', 'Expense', '2020-09-27 06:44:39+00:00', '2020-09-27 06:44:39+00:00'),
+       ('Spa', 'Expense', '2022-08-18 17:37:53+00:00', '2022-08-18 17:37:53+00:00'),
+       ('Salon Visits', 'Expense', '2018-07-12 00:03:18+00:00', '2020-02-08 00:03:18+00:00'),
+       ('Cleaning Supplies', 'Expense', '2019-11-06 00:00:37+00:00', '2019-11-06 00:00:37+00:00'),
+       ('Paper Products', 'Expense', '2019-01-08 13:36:17+00:00', '2019-01-08 13:36:17+00:00'),
+       ('Furniture', 'Expense', '2019-10-06 18:28:31+00:00', '2019-10-06 18:28:31+00:00'),
+       ('Home Decorations', 'Expense', '2021-08-20 19:32:26+00:00', '2021-08-20 19:32:26+00:00'),
+       ('Small Appliances', 'Expense', '2021-02-18 01:27:50+00:00', '2021-02-18 01:27:50+00:00'),
+       ('Pool Supplies', 'Expense', '2018-09-19 18:04:24+00:00', '2018-09-19 18:04:24+00:00'),
+       ('Pet Food', 'Expense', '2018-03-25 21:25:21+00:00', '2018-03-25 21:25:21+00:00'),
+       ('Pet Supplies', 'Expense', '2020-02-27 19:30:23+00:00', '2020-02-27 19:30:23+00:00'),
+       ('Pet Grooming', 'Expense', '2020-02-19 13:09:09+00:00', '2020-02-19 13:09:09+00:00'),
+       ('Vet Visits', 'Expense', '2022-11-30 19:08:17+00:00', '2022-11-30 19:08:17+00:00'),
+       ('Pet Medication', 'Expense', '2020-08-15 16:12:01+00:00', '2020-08-15 16:12:01+00:00'),
+       ('Gym Membership', 'Expense', '2021-06-08 20:58:47+00:00', '2021-06-08 20:58:47+00:00'),
+       ('Video Streaming Subscription', 'Expense', '2020-03-04 01:06:13+00:00', '2020-03-04 01:06:13+00:00'),
+       ('Music Streaming Subscription', 'Expense', '2020-02-12 14:53:17+00:00', '2020-02-12 14:53:17+00:00'),
+       ('Magazines', 'Expense', '2020-07-13 07:42:19+00:00', '2020-07-13 07:42:19+00:00'),
+       ('Software Subscriptions', 'Expense', '2019-12-02 07:50:24+00:00', '2019-12-02 07:50:24+00:00'),
+       ('Books', 'Expense', '2020-04-24 04:43:24+00:00', '2020-04-24 04:43:24+00:00'),
+       ('Hobbies', 'Expense', '2019-12-10 00:47:56+00:00', '2019-12-10 00:47:56+00:00'),
+       ('Small Electronics', 'Expense', '2019-03-28 01:48:01+00:00', '2019-03-28 01:48:01+00:00'),
+       ('Sporting Events', 'Expense', '2019-08-25 14:18:08+00:00', '2019-08-25 14:18:08+00:00'),
+       ('Concerts', 'Expense', '2019-04-30 03:20:10+00:00', '2019-04-30 03:20:10+00:00'),
+       ('Movies', 'Expense', '2022-04-27 13:42:06+00:00', '2022-04-27 13:42:06+00:00'),
+       ('Coaching', 'Expense', '2020-09-19 00:13:18+00:00', '2020-09-19 00:13:18+00:00'),
+       ('Conferences', 'Expense', '2018-08-05 20:14:02+00:00', '2018-08-05 20:14:02+00:00'),
+       ('Webinars', 'Expense', '2019-09-12 00:04:41+00:00', '2019-09-12 00:04:41+00:00'),
+       ('Courses', 'Expense', '2020-02-14 02:28:15+00:00', '2020-02-14 02:28:15+00:00'),
+       ('Church Donations', 'Expense', '2018-09-26 22:14:40+00:00', '2018-09-26 22:14:40+00:00'),
+       ('Nonprofit Donations', 'Expense', '2018-12-02 09:31:10+00:00', '2018-12-02 09:31:10+00:00'),
+       ('Online Donations', 'Expense', '2019-09-19 18:30:16+00:00', '2019-09-19 18:30:16+00:00'),
+       ('Political Donations', 'Expense', '2018-11-04 03:10:49+00:00', '2018-11-04 03:10:49+00:00'),
+       ('Emergency Fund', 'Expense', '2021-12-09 04:42:13+00:00', '2021-12-09 04:42:13+00:00'),
+       ('Retirement Contributions', 'Expense', '2019-03-02 13:53:33+00:00', '2019-03-02 13:53:33+00:00'),
+       ('Investment Contributions', 'Expense', '2020-08-28 09:36:59+00:00', '2020-08-28 09:36:59+00:00'),
+       ('Vacation Fund', 'Expense', '2021-06-23 14:05:17+00:00', '2021-06-23 14:05:17+00:00'),
+       ('New Car Fund', 'Expense', '2019-09-25 23:24:08+00:00', '2019-09-25 23:24:08+00:00'),
+       ('College Fund', 'Expense', '2018-06-07 17:27:11+00:00', '2018-06-07 17:27:11+00:00'),
+       ('Vacation (General)', 'Expense', '2019-07-24 02:04:37+00:00', '2019-07-24 02:04:37+00:00'),
+       ('Bank Fees', 'Expense', '2020-07-04 09:45:53+00:00', '2020-07-04 09:45:53+00:00'),
+       ('Credit Card Fees', 'Expense', '2022-03-31 06:30:58+00:00', '2022-03-31 06:30:58+00:00'),
+       ('Miscellaneous', 'Expense', '2018-07-08 01:45:19+00:00', '2018-07-08 01:45:19+00:00'),
+       ('Income Tax', 'Expense', '2019-01-04 04:56:48+00:00', '2019-01-04 04:56:48+00:00'),
+       ('Fines & Penalties', 'Expense', '2021-08-28 06:28:40+00:00', '2021-08-28 06:28:40+00:00'),
+       ('Legal Fees', 'Expense', '2020-11-10 10:41:15+00:00', '2023-11-10 10:41:15+00:00'),
+       ('Hotel', 'Expense', '2018-06-24 21:33:37+00:00', '2018-06-24 21:33:37+00:00'),
+       ('Gifts', 'Expense', '2021-08-10 04:26:01+00:00', '2021-08-10 04:26:01+00:00'),
+       ('Moving Expenses', 'Expense', '2018-11-28 13:14:37+00:00', '2018-11-28 13:14:37+00:00'),
+       ('Wedding Expenses', 'Expense', '2020-12-14 01:40:16+00:00', '2020-12-14 01:40:16+00:00'),
+       ('Electronics', 'Expense', '2019-03-21 17:27:44+00:00', '2019-03-21 17:27:44+00:00'),
+       ('Home Improvement', 'Expense', '2018-02-23 18:22:53+00:00', '2018-02-23 18:22:53+00:00'),
+       ('Tobacco & Smoking', 'Expense', '2018-01-27 20:44:15+00:00', '2018-01-27 20:44:15+00:00'),
+       ('Video Games', 'Expense', '2019-03-06 11:53:06+00:00', '2019-03-06 11:53:06+00:00'),
+       ('Lottery Tickets', 'Expense', '2020-11-06 19:08:22+00:00', '2020-11-06 19:08:22+00:00'),
+       ('Gambling Expenses', 'Expense', '2020-07-29 05:34:17+00:00', '2020-07-29 05:34:17+00:00'),
+       ('Union Dues', 'Expense', '2018-01-02 03:39:32+00:00', '2020-12-28 03:39:32+00:00'),
+       ('Pet Adoption', 'Expense', '2019-04-02 16:39:59+00:00', '2019-04-02 16:39:59+00:00'),
+       ('Home Security', 'Expense', '2018-03-30 11:18:06+00:00', '2018-03-30 11:18:06+00:00'),
+       ('House Cleaning Service', 'Expense', '2021-08-06 07:56:55+00:00', '2021-08-06 07:56:55+00:00'),
+       ('Pool Service', 'Expense', '2021-11-13 17:22:31+00:00', '2021-11-13 17:22:31+00:00'),
+       ('Gardening Supplies', 'Expense', '2018-04-30 01:02:14+00:00', '2018-04-30 01:02:14+00:00'),
+       ('Pet Boarding', 'Expense', '2020-09-06 07:55:05+00:00', '2020-09-06 07:55:05+00:00'),
+       ('Pet Training', 'Expense', '2018-04-29 16:01:01+00:00', '2018-04-29 16:01:01+00:00'),
+       ('Amusement Park', 'Expense', '2018-08-18 18:23:37+00:00', '2018-08-18 18:23:37+00:00'),
+       ('Cloud Storage Subscription', 'Expense', '2021-04-06 14:59:57+00:00', '2021-04-06 14:59:57+00:00'),
+       ('Dry Cleaning', 'Expense', '2019-01-29 05:31:35+00:00', '2019-01-29 05:31:35+00:00'),
+       ('Holiday Gifts', 'Expense', '2021-03-05 16:17:51+00:00', '2021-03-05 16:17:51+00:00'),
+       ('Birthday Party', 'Expense', '2019-02-12 07:00:24+00:00', '2019-02-12 07:00:24+00:00'),
+       ('Handyman Services', 'Expense', '2019-04-13 23:56:15+00:00', '2019-04-13 23:56:15+00:00'),
+       ('Car Rental', 'Expense', '2018-12-29 12:11:43+00:00', '2018-12-29 12:11:43+00:00'),
+       ('Sports Equipment', 'Expense', '2020-08-11 17:15:08+00:00', '2020-08-11 17:15:08+00:00'),
+       ('Tools & Equipment', 'Expense', '2021-01-23 09:22:35+00:00', '2021-01-23 09:22:35+00:00'),
+       ('Holiday Decorations', 'Expense', '2018-06-14 17:42:31+00:00', '2018-06-14 17:42:31+00:00'),
+       ('Tax Preparation', 'Expense', '2020-09-11 04:26:27+00:00', '2020-09-11 04:26:27+00:00'),
+       ('Nanny', 'Expense', '2019-05-31 03:19:09+00:00', '2019-05-31 03:19:09+00:00'),
+       ('Professional Memberships', 'Expense', '2019-07-22 00:24:19+00:00', '2019-07-22 00:24:19+00:00'),
+       ('Toys', 'Expense', '2019-12-21 15:34:37+00:00', '2019-12-21 15:34:37+00:00'),
+       ('Vision Insurance', 'Expense', '2021-12-19 06:21:06+00:00', '2021-12-19 06:21:06+00:00'),
+       ('Salary', 'Income', '2021-09-09 12:21:43+00:00', '2021-09-09 12:21:43+00:00'),
+       ('Bonus', 'Income', '2018-02-18 11:42:39+00:00', '2018-02-18 11:42:39+00:00'),
+       ('Overtime Pay', 'Income', '2020-12-12 05:17:17+00:00', '2020-12-12 05:17:17+00:00'),
+       ('Commission', 'Income', '2021-05-16 09:31:45+00:00', '2021-05-16 09:31:45+00:00'),
+       ('Tips', 'Income', '2019-10-19 06:29:10+00:00', '2019-10-19 06:29:10+00:00'),
+       ('Freelance Income', 'Income', '2018-10-14 20:17:59+00:00', '2018-10-14 20:17:59+00:00'),
+       ('Consulting Income', 'Income', '2019-08-09 17:11:43+00:00', '2019-08-09 17:11:43+00:00'),
+       ('Business Income', 'Income', '2020-03-08 23:44:26+00:00', '2020-03-08 23:44:26+00:00'),
+       ('Rental Income', 'Income', '2020-01-24 17:49:42+00:00', '2020-01-24 17:49:42+00:00'),
+       ('Dividend Income', 'Income', '2019-11-21 22:29:42+00:00', '2019-11-21 22:29:42+00:00'),
+       ('Interest Income', 'Income', '2021-03-14 03:52:28+00:00', '2021-03-14 03:52:28+00:00'),
+       ('Capital Gains', 'Income', '2020-06-15 16:14:35+00:00', '2020-06-15 16:14:35+00:00'),
+       ('Tax Refund', 'Income', '2020-08-08 11:40:01+00:00', '2020-08-08 11:40:01+00:00'),
+       ('Insurance Payout', 'Income', '2018-09-14 01:48:29+00:00', '2018-09-14 01:48:29+00:00'),
+       ('Gift Received', 'Income', '2019-08-14 01:33:08+00:00', '2019-08-14 01:33:08+00:00'),
+       ('Lottery Winnings', 'Income', '2021-03-25 17:09:00+00:00', '2021-03-25 17:09:00+00:00'),
+       ('Inheritance', 'Income', '2018-01-20 12:39:53+00:00', '2018-01-20 12:39:53+00:00'),
+       ('Sale of Stock', 'Income', '2019-06-12 19:11:37+00:00', '2019-06-12 19:11:37+00:00'),
+       ('Sale of Property', 'Income', '2021-06-19 20:32:15+00:00', '2021-06-19 20:32:15+00:00'),
+       ('Sale of Vehicle', 'Income', '2018-12-29 09:03:36+00:00', '2018-12-29 09:03:36+00:00'),
+       ('Cashback Rewards', 'Income', '2020-10-11 00:03:01+00:00', '2020-10-11 00:03:01+00:00'),
+       ('Crypto Cashout', 'Income', '2019-04-23 08:06:01+00:00', '2019-04-23 08:06:01+00:00'),
+       ('Royalties', 'Income', '2021-07-18 23:19:52+00:00', '2021-07-18 23:19:52+00:00'),
+       ('Scholarship', 'Income', '2020-03-04 13:29:00+00:00', '2020-03-04 13:29:00+00:00'),
+       ('Grant', 'Income', '2018-04-16 18:00:39+00:00', '2018-04-16 18:00:39+00:00'),
+       ('Child Support Received', 'Income', '2019-10-20 07:34:14+00:00', '2019-10-20 07:34:14+00:00'),
+       ('Alimony Received', 'Income', '2020-02-16 16:37:46+00:00', '2020-02-16 16:37:46+00:00'),
+       ('Legal Settlement', 'Income', '2018-06-13 20:01:14+00:00', '2018-06-13 20:01:14+00:00'),
+       ('Prize Money', 'Income', '2022-02-16 01:22:29+00:00', '2022-02-16 01:22:29+00:00'),
+       ('Loan Disbursement', 'Income', '2019-11-22 22:44:04+00:00', '2019-11-22 22:44:04+00:00'),
+       ('Crypto Mining Income', 'Income', '2020-04-07 09:30:14+00:00', '2020-04-07 09:30:14+00:00'),
+       ('Staking Rewards', 'Income', '2020-04-12 17:00:56+00:00', '2020-04-12 17:00:56+00:00'),
+       ('Uber Income', 'Income', '2021-05-26 00:43:16+00:00', '2021-05-26 00:43:16+00:00'),
+       ('Other Income', 'Income', '2019-07-27 05:59:52+00:00', '2019-07-27 05:59:52+00:00'),
+       ('Found Money', 'Income', '2018-02-19 10:13:42+00:00', '2018-02-19 10:13:42+00:00'),
+       ('Reimbursement', 'Income', '2022-07-02 08:03:00+00:00', '2022-07-02 08:03:00+00:00'),
+       ('Security Deposit Refund', 'Income', '2020-12-20 03:19:06+00:00', '2020-12-20 03:19:06+00:00'),
+       ('Affiliate Income', 'Income', '2018-02-06 04:13:56+00:00', '2018-02-06 04:13:56+00:00'),
+       ('Ad Revenue', 'Income', '2020-02-26 14:37:56+00:00', '2020-02-26 14:37:56+00:00'),
+       ('Sponsorship Income', 'Income', '2018-06-04 22:50:50+00:00', '2018-06-04 22:50:50+00:00'),
+       ('Severance Pay', 'Income', '2019-12-05 06:20:53+00:00', '2019-12-05 06:20:53+00:00'),
+       ('Signing Bonus', 'Income', '2020-06-11 12:57:59+00:00', '2020-06-11 12:57:59+00:00'),
+       ('Pension Income', 'Income', '2019-06-06 05:13:05+00:00', '2019-06-06 05:13:05+00:00'),
+       ('Social Security', 'Income', '2022-07-12 00:40:07+00:00', '2022-07-12 00:40:07+00:00'),
+       ('Disability Income', 'Income', '2021-09-25 00:20:13+00:00', '2021-09-25 00:20:13+00:00'),
+       ('Unemployment Benefits', 'Income', '2019-01-08 23:03:47+00:00', '2019-01-08 23:03:47+00:00'),
+       ('Retirement Withdrawal', 'Income', '2020-03-21 05:53:41+00:00', '2020-03-21 05:53:41+00:00'),
+       ('Stimulus Check', 'Income', '2019-01-12 01:50:37+00:00', '2019-01-12 01:50:37+00:00'),
+       ('Product Refund', 'Income', '2020-03-22 22:09:04+00:00', '2020-03-22 22:09:04+00:00'),
+       ('Casino Winnings', 'Income', '2020-05-25 02:44:30+00:00', '2020-05-25 02:44:30+00:00');
+
+INSERT INTO transactions (account_id, category_id, amount, transaction_date, description, created_at, updated_at)
+VALUES (67, 3, -103.70, '2020-01-01', 'Home Maintenance', '2020-01-01 18:56:31+00:00', '2020-01-01 18:56:31+00:00'),
+       (55, 55, -33.75, '2020-01-02', 'Dental Insurance Premium', '2020-01-02 07:24:11+00:00', '2020-01-02 07:24:11+00:00'),
+       (73, 73, -117.56, '2020-01-02', 'Pet Store Purchase', '2020-01-02 19:09:30+00:00', '2020-01-02 19:09:30+00:00'),
+       (4, 4, -932.69, '2020-01-02', 'Property Tax Payment', '2020-01-02 19:52:48+00:00', '2020-01-02 19:52:48+00:00'),
+       (172, 172, -49.91, '2020-01-03', 'Misc Expense', '2020-01-03 10:34:54+00:00', '2020-01-03 10:34:54+00:00'),
+       (101, 101, -16.44, '2020-01-03', 'Misc Expense', '2020-01-03 11:40:03+00:00', '2020-01-03 11:40:03+00:00'),
+       (193, 193, -70.90, '2020-01-03', 'Petty Cash 1', '2020-01-03 21:46:53+00:00', '2020-01-03 21:46:53+00:00'),
+       (1, 1, -1208.93, '2020-01-04', 'Mortgage Payment', '2020-01-04 03:29:22+00:00', '2020-01-04 03:29:22+00:00'),
+       (116, 116, -130.45, '2020-01-04', 'Hair Salon', '2020-01-04 17:38:50+00:00', '2020-01-04 17:38:50+00:00'),
+       (158, 158, -42.41, '2020-01-04', 'Music Streaming Subscription', '2020-01-04 21:01:53+00:00',
+        '2020-01-04 21:01:53+00:00'),
+       (146, 146, -168.80, '2020-01-05', 'Brokerage Account 6', '2020-01-05 09:40:05+00:00', '2020-01-05 09:40:05+00:00'),
+       (107, 107, -120.95, '2020-01-05', 'Allowance to Child', '2020-01-05 11:20:19+00:00', '2020-01-05 11:20:19+00:00'),
+       (26, 26, -41.73, '2020-01-05', 'Auto Insurance Payment', '2020-01-05 12:11:03+00:00', '2020-01-05 12:11:03+00:00'),
+       (8, 8, -43.52, '2020-01-05', 'Lawn Care Service', '2020-01-05 19:12:21+00:00', '2020-01-05 19:12:21+00:00'),
+       (77, 77, -41.88, '2020-01-06', 'Pet Supplies Purchase', '2020-01-06 06:56:54+00:00', '2020-01-06 06:56:54+00:00'),
+       (81, 81, -31.46, '2020-01-06', 'Movie Ticket Purchase', '2020-01-06 23:30:08+00:00', '2020-01-06 23:30:08+00:00'),
+       (144, 144, -351.40, '2020-01-07', 'Retirement Account 4', '2020-01-07 03:57:37+00:00', '2020-01-07 03:57:37+00:00'),
+       (52, 52, -60.28, '2020-01-07', 'Vet Clinic Payment', '2020-01-07 11:04:01+00:00', '2020-01-07 11:04:01+00:00'),
+       (114, 114, -105.96, '2020-01-07', 'Income Tax Payment', '2020-01-07 13:12:33+00:00', '2020-01-07 13:12:33+00:00'),
+       (28, 28, -15.36, '2020-01-07', 'Fast Food Purchase', '2020-01-07 15:39:24+00:00', '2020-01-07 15:39:24+00:00'),
+       (21, 21, -53.73, '2020-01-07', 'Groceries', '2020-01-07 22:40:35+00:00', '2020-01-07 22:40:35+00:00'),
+       (142, 142, -8.30, '2020-01-08', 'Brokerage Account 2', '2020-01-08 09:06:42+00:00', '2020-01-08 09:06:42+00:00'),
+       (129, 129, -174.80, '2020-01-08', 'Bar & Nightlife', '2020-01-08 23:53:23+00:00', '2020-01-08 23:53:23+00:00'),
+       (62, 62, -62.62, '2020-01-09', 'Medical Insurance Premium', '2020-01-09 14:54:58+00:00', '2020-01-09 14:54:58+00:00'),
+       (189, 189, -419.22, '2020-01-09', 'Cash Box 9', '2020-01-09 18:49:37+00:00', '2020-01-09 18:49:37+00:00'),
+       (70, 70, -59.27, '2020-01-10', 'Gym Membership Fee', '2020-01-10 07:12:13+00:00', '2020-01-10 07:12:13+00:00'),
+       (198, 198, -203.97, '2020-01-10', 'Sponsorship Income', '2020-01-10 22:47:27+00:00', '2020-01-10 22:47:27+00:00'),
+       (18, 18, -244.56, '2020-01-11', 'Checking Account ****0018', '2020-01-11 01:13:47+00:00', '2020-01-11 01:13:47+00:00'),
+       (120, 120, -159.45, '2020-01-11', 'Spa Service', '2020-01-11 02:13:29+00:00', '2020-01-11 02:13:29+00:00'),
+       (48, 48, -35.59, '2020-01-11', 'Prescription Pharmacy Purchase', '2020-01-11 21:56:55+00:00',
+        '2020-01-11 21:56:55+00:00'),
+       (22, 22, -803.07, '2020-01-12', 'Public Transport Fare', '2020-01-12 07:03:59+00:00', '2020-01-12 07:03:59+00:00'),
+       (128, 128, -454.52, '2020-01-12', 'Fast Food Purchase', '2020-01-12 09:54:31+00:00', '2020-01-12 09:54:31+00:00'),
+       (176, 176, -58.69, '2020-01-12', 'Found Cash', '2020-01-12 14:05:14+00:00', '2020-01-12 14:05:14+00:00'),
+       (167, 167, -589.37, '2020-01-13', 'Investment Account 2', '2020-01-13 00:28:35+00:00', '2020-01-13 00:28:35+00:00'),
+       (180, 180, 3517.72, '2020-01-13', 'Loan Disbursement', '2020-01-13 05:54:19+00:00', '2020-01-13 05:54:19+00:00'),
+       (118, 118, -80.90, '2020-01-13', 'Barber Shop', '2020-01-13 21:52:04+00:00', '2020-01-13 21:52:04+00:00'),
+       (177, 177, -47.78, '2020-01-14', 'Miscellaneous Expense', '2020-01-14 08:56:27+00:00', '2020-01-14 08:56:27+00:00'),
+       (57, 57, -100.40, '2020-01-14', 'Urgent Care Visit', '2020-01-14 14:44:34+00:00', '2020-01-14 14:44:34+00:00'),
+       (95, 95, -303.69, '2020-01-14', 'Home Depot', '2020-01-14 15:10:16+00:00', '2020-01-14 15:10:16+00:00'),
+       (14, 14, -208.78, '2020-01-15', 'Cable TV Bill', '2020-01-15 03:20:18+00:00', '2020-01-15 03:20:18+00:00'),
+       (132, 132, -82.29, '2020-01-15', 'Conferences', '2020-01-15 12:03:13+00:00', '2020-01-15 12:03:13+00:00'),
+       (88, 88, -83.84, '2020-01-15', 'Savings Account ****0028', '2020-01-15 18:01:15+00:00', '2020-01-15 18:01:15+00:00'),
+       (112, 112, -170.08, '2020-01-15', 'Credit Card Annual Fee', '2020-01-15 18:29:25+00:00', '2020-01-15 18:29:25+00:00'),
+       (191, 191, -784.39, '2020-01-16', 'Cash Box 1', '2020-01-16 06:13:59+00:00', '2020-01-16 06:13:59+00:00'),
+       (160, 160, -25.01, '2020-01-16', 'Netflix Subscription', '2020-01-16 19:59:57+00:00', '2020-01-16 19:59:57+00:00'),
+       (82, 82, -301.26, '2020-01-16', 'Gym Membership Fee', '2020-01-16 22:05:46+00:00', '2020-01-16 22:05:46+00:00'),
+       (74, 74, -72.57, '2020-01-17', 'Pet Grooming Service', '2020-01-17 10:52:56+00:00', '2020-01-17 10:52:56+00:00'),
+       (151, 151, 11061.85, '2020-01-17', 'Payroll Deposit', '2020-01-17 15:03:32+00:00', '2020-01-17 15:03:32+00:00'),
+       (97, 97, -130.59, '2020-01-17', 'Charity Donation', '2020-01-17 22:32:45+00:00', '2020-01-17 22:32:45+00:00'),
+       (130, 130, -248.07, '2020-01-18', 'Hobby Supplies', '2020-01-18 08:59:39+00:00', '2020-01-18 08:59:39+00:00'),
+       (182, 182, -62.06, '2020-01-18', 'Cash Box 2', '2020-01-18 16:35:14+00:00', '2020-01-18 16:35:14+00:00'),
+       (93, 93, -16.60, '2020-01-18', 'School Lunch Expense', '2020-01-18 22:43:34+00:00', '2020-01-18 22:43:34+00:00'),
+       (148, 148, -564.09, '2020-01-19', 'Retirement Account 8', '2020-01-19 07:35:30+00:00', '2020-01-19 07:35:30+00:00'),
+       (126, 126, -19.92, '2020-01-19', 'Cosmetics Purchase', '2020-01-19 09:46:10+00:00', '2020-01-19 09:46:10+00:00'),
+       (10, 10, -93.56, '2020-01-19', 'Checking Account ****0010', '2020-01-19 16:46:00+00:00', '2020-01-19 16:46:00+00:00'),
+       (163, 163, -46.66, '2020-01-19', 'Crypto Wallet 3', '2020-01-19 23:32:56+00:00', '2020-01-19 23:32:56+00:00'),
+       (187, 187, -48.73, '2020-01-20', 'Cash Box 7', '2020-01-20 07:35:21+00:00', '2020-01-20 07:35:21+00:00'),
+       (23, 23, -974.44, '2020-01-20', 'Extracurricular Activity Fee', '2020-01-20 08:49:58+00:00',
+        '2020-01-20 08:49:58+00:00'),
+       (80, 80, -161.48, '2020-01-20', 'Ride Sharing Fare', '2020-01-20 16:47:31+00:00', '2020-01-20 16:47:31+00:00'),
+       (25, 25, -44.73, '2020-01-20', 'Renters Insurance Premium', '2020-01-20 20:44:04+00:00', '2020-01-20 20:44:04+00:00'),
+       (90, 90, -39.98, '2020-01-21', 'School Supplies Purchase', '2020-01-21 02:00:31+00:00', '2020-01-21 02:00:31+00:00'),
+       (166, 166, -76.61, '2020-01-21', 'Investment Account 1', '2020-01-21 09:04:42+00:00', '2020-01-21 09:04:42+00:00'),
+       (125, 125, -211.15, '2020-01-21', 'Clothing Store Purchase', '2020-01-21 12:14:46+00:00', '2020-01-21 12:14:46+00:00'),
+       (36, 36, -64.70, '2020-01-21', 'Trash Service Bill', '2020-01-21 19:07:40+00:00', '2020-01-21 19:07:40+00:00'),
+       (86, 86, -190.30, '2020-01-22', 'Checking Account ****0026', '2020-01-22 05:54:15+00:00', '2020-01-22 05:54:15+00:00'),
+       (34, 34, -60.96, '2020-01-22', 'Public Transport Fare', '2020-01-22 18:47:30+00:00', '2020-01-22 18:47:30+00:00'),
+       (193, 97, -49.68, '2020-01-23', 'Church Donation', '2020-01-23 01:43:58+00:00', '2020-01-23 01:43:58+00:00'),
+       (183, 183, -177.77, '2020-01-23', 'Cash Box 3', '2020-01-23 12:50:55+00:00', '2020-01-23 12:50:55+00:00'),
+       (43, 43, -56.21, '2020-01-23', 'AAA Membership Dues', '2020-01-23 15:47:18+00:00', '2020-01-23 15:47:18+00:00'),
+       (166, 95, -74.82, '2020-01-24', 'Home Depot', '2020-01-24 01:06:49+00:00', '2020-01-24 01:06:49+00:00'),
+       (22, 7, -15.16, '2020-01-24', 'Mortgage Loan #002', '2020-01-24 12:37:43+00:00', '2020-01-24 12:37:43+00:00'),
+       (138, 138, 10338.15, '2020-01-24', 'Lottery Winnings', '2020-01-24 22:55:50+00:00', '2020-01-24 22:55:50+00:00'),
+       (130, 80, -35.03, '2020-01-24', 'Movie Ticket Purchase', '2020-01-24 23:38:54+00:00', '2020-01-24 23:38:54+00:00'),
+       (25, 36, -94.42, '2020-01-25', 'Gym Membership Fee', '2020-01-25 09:11:21+00:00', '2020-01-25 09:11:21+00:00'),
+       (53, 125, -128.08, '2020-01-25', 'Clothing Store Purchase', '2020-01-25 14:15:24+00:00', '2020-01-25 14:15:24+00:00'),
+       (52, 138, 6375.56, '2020-01-25', 'Lottery Winnings', '2020-01-25 16:35:12+00:00', '2020-01-25 16:35:12+00:00'),
+       (144, 87, -177.17, '2020-01-26', 'Hair Salon', '2020-01-26 11:25:04+00:00', '2020-01-26 11:25:04+00:00'),
+       (128, 110, -88.56, '2020-01-26', 'Miscellaneous Expense', '2020-01-26 21:15:56+00:00', '2020-01-26 21:15:56+00:00'),
+       (133, 83, -50.74, '2020-01-27', 'Online Donation', '2020-01-27 01:39:32+00:00', '2020-01-27 01:39:32+00:00'),
+       (74, 36, -36.87, '2020-01-27', 'Gym Membership Fee', '2020-01-27 06:07:08+00:00', '2020-01-27 06:07:08+00:00'),
+       (39, 39, -6.09, '2020-01-27', 'Recycling Service Bill', '2020-01-27 08:01:10+00:00', '2020-01-27 08:01:10+00:00'),
+       (31, 31, -58.49, '2020-01-27', 'Gas Station Purchase', '2020-01-27 16:24:50+00:00', '2020-01-27 16:24:50+00:00'),
+       (14, 31, -119.83, '2020-01-27', 'Gas Station Purchase', '2020-01-27 17:46:40+00:00', '2020-01-27 17:46:40+00:00'),
+       (116, 133, -52.93, '2020-01-28', 'Online Course', '2020-01-28 00:46:49+00:00', '2020-01-28 00:46:49+00:00'),
+       (29, 29, -173.65, '2020-01-28', 'Lawn Care Service', '2020-01-28 07:52:56+00:00', '2020-01-28 07:52:56+00:00'),
+       (121, 94, -168.12, '2020-01-28', 'Extracurricular Activity Fee', '2020-01-28 14:26:21+00:00',
+        '2020-01-28 14:26:21+00:00'),
+       (88, 8, -144.44, '2020-01-28', 'Lawn Care Service', '2020-01-28 18:47:54+00:00', '2020-01-28 18:47:54+00:00'),
+       (35, 113, -140.37, '2020-01-29', 'Income Tax Payment', '2020-01-29 07:27:52+00:00', '2020-01-29 07:27:52+00:00'),
+       (108, 108, -91.68, '2020-01-29', 'Babysitting Payment', '2020-01-29 16:30:38+00:00', '2020-01-29 16:30:38+00:00'),
+       (33, 147, -65.94, '2020-01-29', 'Peer-to-peer Loans', '2020-01-29 20:10:54+00:00', '2020-01-29 20:10:54+00:00'),
+       (159, 159, -132.38, '2020-01-29', 'Software Subscription', '2020-01-29 22:56:33+00:00', '2020-01-29 22:56:33+00:00'),
+       (177, 160, -106.53, '2020-01-30', 'Savings Account ****0036', '2020-01-30 08:21:48+00:00',
+        '2020-01-30 08:21:48+00:00'),
+       (64, 64, -177.84, '2020-01-30', 'Auto Insurance Payment', '2020-01-30 21:51:19+00:00', '2020-01-30 21:51:19+00:00'),
+       (192, 52, -54.34, '2020-01-30', 'Vision Insurance Premium', '2020-01-30 22:08:09+00:00', '2020-01-30 22:08:09+00:00'),
+       (165, 105, -52.06, '2020-01-31', 'Mortgage Loan #005', '2020-01-31 02:11:40+00:00', '2020-01-31 02:11:40+00:00'),
+       (116, 75, -160.80, '2020-01-31', 'Vet Clinic Payment', '2020-01-31 09:27:38+00:00', '2020-01-31 09:27:38+00:00'),
+       (90, 80, -286.70, '2020-01-31', 'Ride Sharing Fare', '2020-01-31 19:45:55+00:00', '2020-01-31 19:45:55+00:00'),
+       (70, 16, -18.72, '2020-02-01', 'Rent Payment', '2020-02-01 03:07:11+00:00', '2020-02-01 03:07:11+00:00'),
+       (10, 177, -30.64, '2020-02-01', 'Cash Box 7', '2020-02-01 03:28:32+00:00', '2020-02-01 03:28:32+00:00'),
+       (16, 50, -109.56, '2020-02-01', 'Gas Utility Bill', '2020-02-01 16:12:51+00:00', '2020-02-01 16:12:51+00:00'),
+       (123, 121, -100.77, '2020-02-01', 'Pool Supplies Store', '2020-02-01 16:44:26+00:00', '2020-02-01 16:44:26+00:00'),
+       (31, 62, -71.53, '2020-02-02', 'Medical Insurance Premium', '2020-02-02 00:29:08+00:00', '2020-02-02 00:29:08+00:00'),
+       (106, 76, -48.72, '2020-02-02', 'Vet Pharmacy Purchase', '2020-02-02 09:00:07+00:00', '2020-02-02 09:00:07+00:00'),
+       (97, 155, -256.37, '2020-02-02', 'Freelance Income', '2020-02-02 16:55:01+00:00', '2020-02-02 16:55:01+00:00'),
+       (134, 80, -388.14, '2020-02-03', 'Ride Sharing Fare', '2020-02-03 02:26:29+00:00', '2020-02-03 02:26:29+00:00'),
+       (137, 1, -143.88, '2020-02-03', 'Mortgage Payment', '2020-02-03 11:17:49+00:00', '2020-02-03 11:17:49+00:00'),
+       (121, 95, -68.97, '2020-02-03', 'Home Depot', '2020-02-03 15:04:08+00:00', '2020-02-03 15:04:08+00:00'),
+       (173, 182, -141.03, '2020-02-03', 'Cash Box 3', '2020-02-03 23:57:13+00:00', '2020-02-03 23:57:13+00:00'),
+       (178, 34, -121.43, '2020-02-04', 'Video Streaming Subscription', '2020-02-04 00:27:03+00:00',
+        '2020-02-04 00:27:03+00:00'),
+       (79, 14, -88.39, '2020-02-04', 'Cable TV Bill', '2020-02-04 08:45:16+00:00', '2020-02-04 08:45:16+00:00'),
+       (7, 173, -29.90, '2020-02-04', 'Loan Disbursement', '2020-02-04 17:19:47+00:00', '2020-02-04 17:19:47+00:00'),
+       (100, 30, -15.67, '2020-02-04', 'AAA Membership Dues', '2020-02-04 23:45:51+00:00', '2020-02-04 23:45:51+00:00'),
+       (198, 191, -607.02, '2020-02-05', 'Cash Box 1', '2020-02-05 03:56:50+00:00', '2020-02-05 03:56:50+00:00'),
+       (132, 17, -61.62, '2020-02-05', 'Fast Food Purchase', '2020-02-05 07:28:26+00:00', '2020-02-05 07:28:26+00:00'),
+       (102, 178, -401.74, '2020-02-05', 'Miscellaneous Expense', '2020-02-05 13:41:57+00:00', '2020-02-05 13:41:57+00:00'),
+       (19, 125, -29.59, '2020-02-05', 'Clothing Store Purchase', '2020-02-05 20:25:33+00:00', '2020-02-05 20:25:33+00:00'),
+       (53, 135, -6.47, '2020-02-06', 'Conference Fee', '2020-02-06 06:01:59+00:00', '2020-02-06 06:01:59+00:00'),
+       (87, 178, -74.16, '2020-02-06', 'Miscellaneous Expense', '2020-02-06 06:26:00+00:00', '2020-02-06 06:26:00+00:00'),
+       (190, 30, -59.04, '2020-02-06', 'AAA Membership Dues', '2020-02-06 09:53:39+00:00', '2020-02-06 09:53:39+00:00'),
+       (35, 11, -25.65, '2020-02-06', 'Water Bill', '2020-02-06 11:24:31+00:00', '2020-02-06 11:24:31+00:00'),
+       (158, 75, -68.40, '2020-02-06', 'Vet Clinic Payment', '2020-02-06 13:03:59+00:00', '2020-02-06 13:03:59+00:00'),
+       (166, 66, -36.30, '2020-02-06', 'Mortgage Insurance Premium', '2020-02-06 16:39:53+00:00',
+        '2020-02-06 16:39:53+00:00'),
+       (108, 142, -13.27, '2020-02-07', 'Brokerage Account 2', '2020-02-07 04:02:01+00:00', '2020-02-07 04:02:01+00:00'),
+       (139, 188, -57.02, '2020-02-07', 'Cash Box 8', '2020-02-07 07:44:19+00:00', '2020-02-07 07:44:19+00:00'),
+       (147, 96, -24.87, '2020-02-07', 'Nonprofit Donation', '2020-02-07 18:45:45+00:00', '2020-02-07 18:45:45+00:00'),
+       (96, 101, -85.17, '2020-02-07', 'Video Game Purchase', '2020-02-07 22:46:56+00:00', '2020-02-07 22:46:56+00:00'),
+       (50, 166, -179.62, '2020-02-08', 'Investment Account 1', '2020-02-08 10:56:17+00:00', '2020-02-08 10:56:17+00:00'),
+       (177, 138, -44.79, '2020-02-08', 'Lottery Winnings', '2020-02-08 17:55:00+00:00', '2020-02-08 17:55:00+00:00'),
+       (32, 177, -445.79, '2020-02-08', 'Cash Box 7', '2020-02-08 22:35:28+00:00', '2020-02-08 22:35:28+00:00'),
+       (110, 55, -53.05, '2020-02-09', 'Dental Insurance Premium', '2020-02-09 03:47:08+00:00', '2020-02-09 03:47:08+00:00'),
+       (82, 116, -45.52, '2020-02-09', 'Barber Shop', '2020-02-09 06:00:33+00:00', '2020-02-09 06:00:33+00:00'),
+       (71, 13, -139.44, '2020-02-09', 'Electric Bill Payment', '2020-02-09 07:36:41+00:00', '2020-02-09 07:36:41+00:00'),
+       (34, 111, -149.44, '2020-02-09', 'Property Tax Payment', '2020-02-09 18:10:39+00:00', '2020-02-09 18:10:39+00:00'),
+       (98, 9, -71.46, '2020-02-09', 'Electric Bill Payment', '2020-02-09 23:07:10+00:00', '2020-02-09 23:07:10+00:00'),
+       (27, 62, -154.73, '2020-02-10', 'Medical Insurance Premium', '2020-02-10 09:11:55+00:00', '2020-02-10 09:11:55+00:00'),
+       (139, 110, -22.75, '2020-02-10', 'Miscellaneous Expense', '2020-02-10 09:55:57+00:00', '2020-02-10 09:55:57+00:00'),
+       (133, 8, -37.41, '2020-02-10', 'Lawn Care Service', '2020-02-10 13:33:26+00:00', '2020-02-10 13:33:26+00:00'),
+       (59, 91, -68.86, '2020-02-10', 'School Supplies Purchase', '2020-02-10 20:19:29+00:00', '2020-02-10 20:19:29+00:00'),
+       (68, 48, -67.36, '2020-02-11', 'Small Appliances Purchase', '2020-02-11 00:07:08+00:00', '2020-02-11 00:07:08+00:00'),
+       (13, 107, -31.06, '2020-02-11', 'Daycare Fee', '2020-02-11 03:27:07+00:00', '2020-02-11 03:27:07+00:00'),
+       (146, 133, -82.28, '2020-02-11', 'Conference Fee', '2020-02-11 05:47:13+00:00', '2020-02-11 05:47:13+00:00'),
+       (119, 171, -101.91, '2020-02-11', 'Credit Card Cashback', '2020-02-11 15:13:31+00:00', '2020-02-11 15:13:31+00:00'),
+       (7, 97, -310.27, '2020-02-11', 'Church Donation', '2020-02-11 23:19:07+00:00', '2020-02-11 23:19:07+00:00'),
+       (117, 168, -99.65, '2020-02-12', 'Crypto Cashout', '2020-02-12 10:06:24+00:00', '2020-02-12 10:06:24+00:00'),
+       (136, 58, -74.11, '2020-02-12', 'Gym Membership Fee', '2020-02-12 23:58:32+00:00', '2020-02-12 23:58:32+00:00'),
+       (113, 126, -117.94, '2020-02-13', 'Bar & Nightlife', '2020-02-13 06:22:41+00:00', '2020-02-13 06:22:41+00:00'),
+       (72, 102, -71.97, '2020-02-13', 'Political Campaign Donation', '2020-02-13 09:02:10+00:00',
+        '2020-02-13 09:02:10+00:00'),
+       (114, 138, -26.94, '2020-02-13', 'Lottery Winnings', '2020-02-13 12:01:52+00:00', '2020-02-13 12:01:52+00:00'),
+       (177, 117, -50.23, '2020-02-13', 'Brokerage Account 7', '2020-02-13 23:31:28+00:00', '2020-02-13 23:31:28+00:00'),
+       (68, 5, -12.69, '2020-02-14', 'HOA Fee', '2020-02-14 10:17:15+00:00', '2020-02-14 10:17:15+00:00'),
+       (87, 72, -26.93, '2020-02-14', 'Pet Grooming Service', '2020-02-14 21:35:05+00:00', '2020-02-14 21:35:05+00:00'),
+       (31, 95, -197.60, '2020-02-14', 'Home Depot', '2020-02-14 22:06:29+00:00', '2020-02-14 22:06:29+00:00'),
+       (83, 159, -56.74, '2020-02-15', 'Software Subscription', '2020-02-15 02:53:06+00:00', '2020-02-15 02:53:06+00:00'),
+       (105, 153, -60.05, '2020-02-15', 'Interest Income', '2020-02-15 07:19:00+00:00', '2020-02-15 07:19:00+00:00'),
+       (152, 26, -183.73, '2020-02-15', 'DMV Fee', '2020-02-15 15:04:36+00:00', '2020-02-15 15:04:36+00:00'),
+       (176, 78, -64.64, '2020-02-15', 'Pet Store Purchase', '2020-02-15 19:55:12+00:00', '2020-02-15 19:55:12+00:00'),
+       (32, 161, 2801.57, '2020-02-16', 'Social Security Benefits', '2020-02-16 03:07:27+00:00', '2020-02-16 03:07:27+00:00'),
+       (144, 145, -451.46, '2020-02-16', 'Retirement Account 5', '2020-02-16 04:12:04+00:00', '2020-02-16 04:12:04+00:00'),
+       (90, 26, -36.44, '2020-02-16', 'DMV Fee', '2020-02-16 12:53:50+00:00', '2020-02-16 12:53:50+00:00'),
+       (167, 128, -117.50, '2020-02-16', 'Concert Tickets', '2020-02-16 19:20:59+00:00', '2020-02-16 19:20:59+00:00'),
+       (143, 62, -137.37, '2020-02-17', 'Medical Insurance Premium', '2020-02-17 06:04:12+00:00',
+        '2020-02-17 06:04:12+00:00'),
+       (3, 70, -17.47, '2020-02-17', 'Gym Membership Fee', '2020-02-17 06:55:36+00:00', '2020-02-17 06:55:36+00:00'),
+       (140, 76, -62.74, '2020-02-17', 'Vet Pharmacy Purchase', '2020-02-17 15:53:56+00:00', '2020-02-17 15:53:56+00:00'),
+       (66, 171, -93.27, '2020-02-18', 'Credit Card Cashback', '2020-02-18 00:00:32+00:00', '2020-02-18 00:00:32+00:00'),
+       (160, 108, -69.42, '2020-02-18', 'Babysitting Payment', '2020-02-18 08:04:20+00:00', '2020-02-18 08:04:20+00:00'),
+       (109, 125, -134.03, '2020-02-18', 'Clothing Store Purchase', '2020-02-18 12:07:47+00:00', '2020-02-18 12:07:47+00:00'),
+       (98, 110, -16.77, '2020-02-18', 'Miscellaneous Expense', '2020-02-18 19:37:32+00:00', '2020-02-18 19:37:32+00:00'),
+       (14, 32, -102.53, '2020-02-19', 'Coffee Shop Purchase', '2020-02-19 00:03:53+00:00', '2020-02-19 00:03:53+00:00'),
+       (148, 163, 4118.73, '2020-02-19', 'Casino Winnings', '2020-02-19 08:36:07+00:00', '2020-02-19 08:36:07+00:00'),
+       (139, 112, -96.10, '2020-02-19', 'Credit Card Annual Fee', '2020-02-19 12:44:56+00:00', '2020-02-19 12:44:56+00:00'),
+       (131, 47, -51.46, '2020-02-19', 'Specialist Visit', '2020-02-19 23:18:25+00:00', '2020-02-19 23:18:25+00:00'),
+       (144, 40, -104.16, '2020-02-20', 'Trash Service Bill', '2020-02-20 05:57:08+00:00', '2020-02-20 05:57:08+00:00'),
+       (25, 62, -79.51, '2020-02-20', 'Medical Insurance Premium', '2020-02-20 07:08:53+00:00', '2020-02-20 07:08:53+00:00'),
+       (127, 105, -52.97, '2020-02-20', 'Mortgage Loan #005', '2020-02-20 09:25:46+00:00', '2020-02-20 09:25:46+00:00'),
+       (124, 73, -96.90, '2020-02-20', 'Pet Store Purchase', '2020-02-20 15:12:04+00:00', '2020-02-20 15:12:04+00:00'),
+       (183, 159, -7.52, '2020-02-20', 'Software Subscription', '2020-02-20 19:55:02+00:00', '2020-02-20 19:55:02+00:00'),
+       (100, 110, -41.92, '2020-02-21', 'Miscellaneous Expense', '2020-02-21 08:03:58+00:00', '2020-02-21 08:03:58+00:00'),
+       (178, 4, -250.51, '2020-02-21', 'Property Tax Payment', '2020-02-21 10:46:25+00:00', '2020-02-21 10:46:25+00:00'),
+       (198, 61, -99.53, '2020-02-21', 'Life Insurance Premium', '2020-02-21 17:33:19+00:00', '2020-02-21 17:33:19+00:00'),
+       (2, 152, 2898.01, '2020-02-21', 'Uber Income', '2020-02-21 21:04:03+00:00', '2020-02-21 21:04:03+00:00'),
+       (137, 101, -67.28, '2020-02-22', 'Misc Expense', '2020-02-22 05:49:21+00:00', '2020-02-22 05:49:21+00:00'),
+       (77, 108, -43.77, '2020-02-22', 'Babysitting Payment', '2020-02-22 06:21:01+00:00', '2020-02-22 06:21:01+00:00'),
+       (125, 50, -254.53, '2020-02-22', 'DMV Fee', '2020-02-22 13:45:58+00:00', '2020-02-22 13:45:58+00:00'),
+       (41, 3, -471.20, '2020-02-22', 'Home Maintenance', '2020-02-22 17:47:13+00:00', '2020-02-22 17:47:13+00:00'),
+       (154, 154, 16315.90, '2020-02-22', 'Rental Income', '2020-02-22 19:39:27+00:00', '2020-02-22 19:39:27+00:00'),
+       (182, 53, -76.56, '2020-02-23', 'Babysitting Payment', '2020-02-23 04:34:00+00:00', '2020-02-23 04:34:00+00:00'),
+       (3, 130, -252.52, '2020-02-23', 'Electronics Store Purchase', '2020-02-23 06:29:19+00:00',
+        '2020-02-23 06:29:19+00:00'),
+       (67, 159, -48.42, '2020-02-23', 'Software Subscription', '2020-02-23 19:28:40+00:00', '2020-02-23 19:28:40+00:00'),
+       (74, 20, -38.48, '2020-02-23', 'Internet Bill', '2020-02-23 22:25:59+00:00', '2020-02-23 22:25:59+00:00'),
+       (136, 21, -378.37, '2020-02-24', 'Restaurant Dining', '2020-02-24 04:28:32+00:00', '2020-02-24 04:28:32+00:00'),
+       (15, 15, -115.15, '2020-02-24', 'Phone Bill', '2020-02-24 09:53:31+00:00', '2020-02-24 09:53:31+00:00'),
+       (164, 12, -90.29, '2020-02-24', 'Water Bill', '2020-02-24 14:41:22+00:00', '2020-02-24 14:41:22+00:00'),
+       (147, 21, -69.88, '2020-02-24', 'Restaurant Dining', '2020-02-24 20:55:11+00:00', '2020-02-24 20:55:11+00:00'),
+       (173, 23, -86.28, '2020-02-25', 'Fast Food Purchase', '2020-02-25 03:56:15+00:00', '2020-02-25 03:56:15+00:00'),
+       (49, 78, -54.71, '2020-02-25', 'Pet Store Purchase', '2020-02-25 06:49:11+00:00', '2020-02-25 06:49:11+00:00'),
+       (88, 48, -68.92, '2020-02-25', 'Small Appliances Purchase', '2020-02-25 12:13:38+00:00', '2020-02-25 12:13:38+00:00'),
+       (1, 121, -23.71, '2020-02-25', 'Pool Supplies Store', '2020-02-25 22:30:04+00:00', '2020-02-25 22:30:04+00:00'),
+       (69, 51, -72.05, '2020-02-26', 'Out-of-pocket Medical', '2020-02-26 09:21:59+00:00', '2020-02-26 09:21:59+00:00'),
+       (48, 122, -74.39, '2020-02-26', 'Salon Visit', '2020-02-26 09:43:37+00:00', '2020-02-26 09:43:37+00:00'),
+       (37, 94, -120.47, '2020-02-26', 'Extracurricular Activity Fee', '2020-02-26 23:22:49+00:00',
+        '2020-02-26 23:22:49+00:00'),
+       (115, 56, -185.17, '2020-02-27', 'Urgent Care Visit', '2020-02-27 01:10:38+00:00', '2020-02-27 01:10:38+00:00'),
+       (4, 155, -151.38, '2020-02-27', 'Consulting Fee', '2020-02-27 08:45:02+00:00', '2020-02-27 08:45:02+00:00'),
+       (124, 140, 10879.99, '2020-02-27', 'Sale of Property', '2020-02-27 17:31:50+00:00', '2020-02-27 17:31:50+00:00'),
+       (6, 174, 798.44, '2020-02-27', 'Government Stimulus', '2020-02-27 20:07:14+00:00', '2020-02-27 20:07:14+00:00'),
+       (152, 83, -86.80, '2020-02-28', 'Church Donation', '2020-02-28 02:49:16+00:00', '2020-02-28 02:49:16+00:00'),
+       (167, 110, -59.88, '2020-02-28', 'Misc Expense', '2020-02-28 07:42:00+00:00', '2020-02-28 07:42:00+00:00'),
+       (43, 111, -175.59, '2020-02-28', 'Property Tax Payment', '2020-02-28 18:20:59+00:00', '2020-02-28 18:20:59+00:00'),
+       (74, 13, -74.54, '2020-02-28', 'Electric Bill Payment', '2020-02-28 18:48:15+00:00', '2020-02-28 18:48:15+00:00'),
+       (194, 67, -93.96, '2020-02-29', 'Checking Account ****0007', '2020-02-29 05:58:26+00:00', '2020-02-29 05:58:26+00:00'),
+       (121, 163, -21.13, '2020-02-29', 'Crypto Wallet 3', '2020-02-29 13:05:13+00:00', '2020-02-29 13:05:13+00:00'),
+       (51, 62, -198.74, '2020-02-29', 'Medical Insurance Premium', '2020-02-29 21:23:54+00:00', '2020-02-29 21:23:54+00:00'),
+       (177, 148, -29.84, '2020-03-01', 'Retirement Account 9', '2020-03-01 04:21:43+00:00', '2020-03-01 04:21:43+00:00'),
+       (15, 39, -84.89, '2020-03-01', 'Recycling Service Bill', '2020-03-01 04:25:37+00:00', '2020-03-01 04:25:37+00:00'),
+       (42, 66, -183.82, '2020-03-01', 'Mortgage Insurance Premium', '2020-03-01 13:34:47+00:00',
+        '2020-03-01 13:34:47+00:00'),
+       (88, 99, -83.19, '2020-03-01', 'Political Campaign Donation', '2020-03-01 13:45:22+00:00',
+        '2020-03-01 13:45:22+00:00'),
+       (65, 7, -146.68, '2020-03-02', 'Lawn Care Service', '2020-03-02 05:47:29+00:00', '2020-03-02 05:47:29+00:00'),
+       (133, 198, -102.09, '2020-03-02', 'Signing Bonus', '2020-03-02 10:18:47+00:00', '2020-03-02 10:18:47+00:00'),
+       (181, 130, -149.61, '2020-03-02', 'Electronics Store Purchase', '2020-03-02 15:17:41+00:00',
+        '2020-03-02 15:17:41+00:00'),
+       (140, 45, -41.21, '2020-03-02', 'Insurance Payout', '2020-03-02 16:09:04+00:00', '2020-03-02 16:09:04+00:00'),
+       (109, 15, -116.22, '2020-03-02', 'Phone Bill', '2020-03-02 21:43:37+00:00', '2020-03-02 21:43:37+00:00'),
+       (164, 176, -115.67, '2020-03-03', 'Found Cash', '2020-03-03 02:40:57+00:00', '2020-03-03 02:40:57+00:00'),
+       (95, 115, -62.78, '2020-03-03', 'Holiday Gifts', '2020-03-03 09:35:58+00:00', '2020-03-03 09:35:58+00:00'),
+       (30, 12, -8.49, '2020-03-03', 'Water Bill', '2020-03-03 12:51:46+00:00', '2020-03-03 12:51:46+00:00'),
+       (14, 149, -159.24, '2020-03-03', 'Retirement Account 10', '2020-03-03 18:39:08+00:00', '2020-03-03 18:39:08+00:00'),
+       (127, 95, -130.35, '2020-03-04', 'Home Depot', '2020-03-04 09:11:27+00:00', '2020-03-04 09:11:27+00:00'),
+       (13, 34, -69.74, '2020-03-04', 'Video Streaming Subscription', '2020-03-04 10:04:21+00:00',
+        '2020-03-04 10:04:21+00:00'),
+       (142, 118, -173.97, '2020-03-04', 'Freelance Income', '2020-03-04 10:19:43+00:00', '2020-03-04 10:19:43+00:00'),
+       (9, 9, -39.69, '2020-03-04', 'Electric Bill Payment', '2020-03-04 18:08:55+00:00', '2020-03-04 18:08:55+00:00'),
+       (71, 69, -81.85, '2020-03-04', 'Church Donation', '2020-03-04 22:37:39+00:00', '2020-03-04 22:37:39+00:00'),
+       (183, 62, -11.30, '2020-03-05', 'Medical Insurance Premium', '2020-03-05 01:29:49+00:00', '2020-03-05 01:29:49+00:00'),
+       (103, 121, -43.05, '2020-03-05', 'Pool Supplies Store', '2020-03-05 02:56:36+00:00', '2020-03-05 02:56:36+00:00'),
+       (146, 8, -102.03, '2020-03-05', 'Lawn Care Service', '2020-03-05 05:38:09+00:00', '2020-03-05 05:38:09+00:00'),
+       (100, 25, -142.22, '2020-03-05', 'Renters Insurance Premium', '2020-03-05 15:55:04+00:00',
+        '2020-03-05 15:55:04+00:00'),
+       (19, 135, -18.88, '2020-03-05', 'Webinar Fee', '2020-03-05 17:10:07+00:00', '2020-03-05 17:10:07+00:00'),
+       (66, 80, -137.19, '2020-03-06', 'Ride Sharing Fare', '2020-03-06 00:58:25+00:00', '2020-03-06 00:58:25+00:00'),
+       (32, 74, -70.89, '2020-03-06', 'Pet Grooming Service', '2020-03-06 13:09:26+00:00', '2020-03-06 13:09:26+00:00'),
+       (111, 79, -53.12, '2020-03-06', 'Child Support Payment', '2020-03-06 21:54:59+00:00', '2020-03-06 21:54:59+00:00'),
+       (181, 147, -18.50, '2020-03-07', 'Retirement Account 8', '2020-03-07 02:48:13+00:00', '2020-03-07 02:48:13+00:00'),
+       (149, 74, -49.60, '2020-03-07', 'Pet Grooming Service', '2020-03-07 02:53:40+00:00', '2020-03-07 02:53:40+00:00'),
+       (4, 51, -14.79, '2020-03-07', 'Senior Care Expenses', '2020-03-07 21:34:20+00:00', '2020-03-07 21:34:20+00:00'),
+       (52, 25, -132.43, '2020-03-07', 'Renters Insurance Premium', '2020-03-07 21:46:37+00:00', '2020-03-07 21:46:37+00:00'),
+       (117, 49, -51.10, '2020-03-08', 'Streaming Service Fee', '2020-03-08 06:28:03+00:00', '2020-03-08 06:28:03+00:00'),
+       (197, 2, -380.97, '2020-03-08', 'Rent Payment', '2020-03-08 09:47:10+00:00', '2020-03-08 09:47:10+00:00'),
+       (163, 19, -97.45, '2020-03-08', 'Groceries', '2020-03-08 11:42:37+00:00', '2020-03-08 11:42:37+00:00'),
+       (52, 2, -1334.21, '2020-03-08', 'Rent Payment', '2020-03-08 16:18:46+00:00', '2020-03-08 16:18:46+00:00'),
+       (34, 108, -164.07, '2020-03-08', 'Babysitting Payment', '2020-03-08 21:20:59+00:00', '2020-03-08 21:20:59+00:00'),
+       (118, 6, -137.38, '2020-03-09', 'Home Warranty Payment', '2020-03-09 10:28:52+00:00', '2020-03-09 10:28:52+00:00'),
+       (157, 93, -40.98, '2020-03-09', 'Allowance to Child', '2020-03-09 18:55:56+00:00', '2020-03-09 18:55:56+00:00'),
+       (103, 95, -79.89, '2020-03-09', 'Home Depot', '2020-03-09 21:31:44+00:00', '2020-03-09 21:31:44+00:00'),
+       (156, 52, -17.60, '2020-03-10', 'Vision Insurance Premium', '2020-03-10 06:28:47+00:00', '2020-03-10 06:28:47+00:00'),
+       (140, 43, -5.86, '2020-03-10', 'AAA Membership Dues', '2020-03-10 15:40:56+00:00', '2020-03-10 15:40:56+00:00'),
+       (81, 159, -22.42, '2020-03-10', 'Software Subscription', '2020-03-10 17:45:36+00:00', '2020-03-10 17:45:36+00:00'),
+       (107, 9, -78.33, '2020-03-10', 'Electric Bill Payment', '2020-03-10 20:31:59+00:00', '2020-03-10 20:31:59+00:00'),
+       (52, 171, -47.49, '2020-03-11', 'Credit Card Cashback', '2020-03-11 04:45:49+00:00', '2020-03-11 04:45:49+00:00'),
+       (116, 39, -23.53, '2020-03-11', 'Recycling Service Bill', '2020-03-11 16:42:47+00:00', '2020-03-11 16:42:47+00:00'),
+       (81, 156, -78.58, '2020-03-11', 'Grant Funds', '2020-03-11 16:57:11+00:00', '2020-03-11 16:57:11+00:00'),
+       (200, 84, -103.00, '2020-03-11', 'Phone Bill', '2020-03-11 21:16:13+00:00', '2020-03-11 21:16:13+00:00'),
+       (16, 77, -58.31, '2020-03-12', 'Pet Store Purchase', '2020-03-12 12:35:23+00:00', '2020-03-12 12:35:23+00:00'),
+       (35, 154, 2622.88, '2020-03-12', 'Rental Income', '2020-03-12 18:19:44+00:00', '2020-03-12 18:19:44+00:00'),
+       (70, 100, -289.45, '2020-03-12', 'Union Membership Dues', '2020-03-12 20:31:57+00:00', '2020-03-12 20:31:57+00:00'),
+       (108, 73, -95.50, '2020-03-13', 'Pet Store Purchase', '2020-03-13 06:21:58+00:00', '2020-03-13 06:21:58+00:00'),
+       (141, 131, -101.64, '2020-03-13', 'Hobbies', '2020-03-13 10:26:46+00:00', '2020-03-13 10:26:46+00:00'),
+       (187, 141, -70.19, '2020-03-13', 'Retirement Account 2', '2020-03-13 17:29:06+00:00', '2020-03-13 17:29:06+00:00'),
+       (153, 122, -33.90, '2020-03-13', 'Salon Visit', '2020-03-13 23:37:34+00:00', '2020-03-13 23:37:34+00:00'),
+       (199, 188, -134.65, '2020-03-14', 'Cash Box 8', '2020-03-14 02:06:52+00:00', '2020-03-14 02:06:52+00:00'),
+       (101, 133, -43.01, '2020-03-14', 'Online Course', '2020-03-14 08:36:35+00:00', '2020-03-14 08:36:35+00:00'),
+       (127, 165, -54.32, '2020-03-14', 'Investment Account 5', '2020-03-14 09:35:05+00:00', '2020-03-14 09:35:05+00:00'),
+       (39, 72, -75.86, '2020-03-14', 'Pet Grooming Service', '2020-03-14 18:52:02+00:00', '2020-03-14 18:52:02+00:00'),
+       (110, 153, -25.76, '2020-03-15', 'Interest Income', '2020-03-15 02:49:40+00:00', '2020-03-15 02:49:40+00:00'),
+       (177, 21, -59.56, '2020-03-15', 'Restaurant Dining', '2020-03-15 10:19:41+00:00', '2020-03-15 10:19:41+00:00'),
+       (62, 116, -19.42, '2020-03-15', 'Barber Shop', '2020-03-15 15:55:55+00:00', '2020-03-15 15:55:55+00:00'),
+       (109, 31, -116.19, '2020-03-15', 'Gas Station Purchase', '2020-03-15 23:56:37+00:00', '2020-03-15 23:56:37+00:00'),
+       (92, 137, 17888.72, '2020-03-16', 'Sale of Stock Proceeds', '2020-03-16 02:16:27+00:00', '2020-03-16 02:16:27+00:00'),
+       (99, 24, -426.88, '2020-03-16', 'Internet Bill', '2020-03-16 04:17:38+00:00', '2020-03-16 04:17:38+00:00'),
+       (45, 14, -77.72, '2020-03-16', 'Cable TV Bill', '2020-03-16 06:57:13+00:00', '2020-03-16 06:57:13+00:00'),
+       (168, 56, -70.51, '2020-03-16', 'Urgent Care Visit', '2020-03-16 13:01:16+00:00', '2020-03-16 13:01:16+00:00'),
+       (26, 104, -64.99, '2020-03-16', 'Child Support Payment', '2020-03-16 14:09:50+00:00', '2020-03-16 14:09:50+00:00'),
+       (186, 175, -55.89, '2020-03-16', 'Reimbursement', '2020-03-16 17:42:09+00:00', '2020-03-16 17:42:09+00:00'),
+       (90, 102, -57.69, '2020-03-17', 'Political Campaign Donation', '2020-03-17 01:28:15+00:00',
+        '2020-03-17 01:28:15+00:00'),
+       (107, 42, -58.58, '2020-03-17', 'DMV Fee', '2020-03-17 02:22:47+00:00', '2020-03-17 02:22:47+00:00'),
+       (43, 83, -22.80, '2020-03-17', 'Church Donation', '2020-03-17 03:54:20+00:00', '2020-03-17 03:54:20+00:00'),
+       (102, 13, -116.89, '2020-03-17', 'Electric Bill Payment', '2020-03-17 09:39:30+00:00', '2020-03-17 09:39:30+00:00'),
+       (7, 108, -107.56, '2020-03-17', 'Babysitting Payment', '2020-03-17 13:41:01+00:00', '2020-03-17 13:41:01+00:00'),
+       (170, 147, -86.62, '2020-03-17', 'Retirement Account 8', '2020-03-17 16:41:05+00:00', '2020-03-17 16:41:05+00:00'),
+       (87, 169, -282.65, '2020-03-18', 'Child Support Received', '2020-03-18 04:09:36+00:00', '2020-03-18 04:09:36+00:00'),
+       (121, 170, -148.33, '2020-03-18', 'Alimony Received', '2020-03-18 07:22:24+00:00', '2020-03-18 07:22:24+00:00'),
+       (35, 19, -93.40, '2020-03-18', 'Groceries', '2020-03-18 14:58:59+00:00', '2020-03-18 14:58:59+00:00'),
+       (119, 163, -35.44, '2020-03-18', 'Crypto Wallet 3', '2020-03-18 18:24:32+00:00', '2020-03-18 18:24:32+00:00'),
+       (104, 1, -1564.99, '2020-03-18', 'Mortgage Payment', '2020-03-18 21:47:44+00:00', '2020-03-18 21:47:44+00:00'),
+       (145, 118, -23.36, '2020-03-19', 'Freelance Income', '2020-03-19 01:55:15+00:00', '2020-03-19 01:55:15+00:00'),
+       (171, 96, -25.00, '2020-03-19', 'Nonprofit Donation', '2020-03-19 11:36:34+00:00', '2020-03-19 11:36:34+00:00'),
+       (133, 103, -69.53, '2020-03-19', 'Political Campaign Donation', '2020-03-19 14:39:07+00:00',
+        '2020-03-19 14:39:07+00:00'),
+       (1, 108, -63.47, '2020-03-19', 'Babysitting Payment', '2020-03-19 15:20:29+00:00', '2020-03-19 15:20:29+00:00'),
+       (15, 106, -115.21, '2020-03-19', 'Daycare Fee', '2020-03-19 18:40:31+00:00', '2020-03-19 18:40:31+00:00'),
+       (62, 109, -78.30, '2020-03-20', 'Clothing Store Purchase', '2020-03-20 00:07:07+00:00', '2020-03-20 00:07:07+00:00'),
+       (161, 86, -12.25, '2020-03-20', 'Savings Account ****0035', '2020-03-20 09:20:26+00:00', '2020-03-20 09:20:26+00:00'),
+       (91, 122, -197.68, '2020-03-20', 'Salon Visit', '2020-03-20 18:17:45+00:00', '2020-03-20 18:17:45+00:00'),
+       (43, 91, -71.34, '2020-03-20', 'School Supplies Purchase', '2020-03-20 21:22:09+00:00', '2020-03-20 21:22:09+00:00'),
+       (5, 5, -44.65, '2020-03-21', 'HOA Fee', '2020-03-21 03:58:11+00:00', '2020-03-21 03:58:11+00:00'),
+       (16, 100, -83.12, '2020-03-21', 'Union Membership Dues', '2020-03-21 05:44:09+00:00', '2020-03-21 05:44:09+00:00'),
+       (126, 123, -36.90, '2020-03-21', 'Conference Fee', '2020-03-21 15:37:42+00:00', '2020-03-21 15:37:42+00:00'),
+       (184, 147, -46.91, '2020-03-21', 'Retirement Account 8', '2020-03-21 16:53:15+00:00', '2020-03-21 16:53:15+00:00'),
+       (80, 5, -12.51, '2020-03-21', 'HOA Fee', '2020-03-21 23:46:09+00:00', '2020-03-21 23:46:09+00:00'),
+       (182, 25, -136.70, '2020-03-22', 'Renters Insurance Premium', '2020-03-22 02:29:59+00:00',
+        '2020-03-22 02:29:59+00:00'),
+       (10, 132, -76.59, '2020-03-22', 'Course Fee', '2020-03-22 05:46:57+00:00', '2020-03-22 05:46:57+00:00'),
+       (141, 137, -23.57, '2020-03-22', 'Sale of Stock Proceeds', '2020-03-22 11:10:37+00:00', '2020-03-22 11:10:37+00:00'),
+       (61, 56, -161.97, '2020-03-22', 'Urgent Care Visit', '2020-03-22 15:07:55+00:00', '2020-03-22 15:07:55+00:00'),
+       (84, 35, -56.15, '2020-03-22', 'Cellphone Bill', '2020-03-22 15:58:45+00:00', '2020-03-22 15:58:45+00:00'),
+       (149, 65, -14.87, '2020-03-23', 'Doctor Visit', '2020-03-23 03:07:35+00:00', '2020-03-23 03:07:35+00:00'),
+       (21, 15, -75.82, '2020-03-23', 'Phone Bill', '2020-03-23 13:14:50+00:00', '2020-03-23 13:14:50+00:00'),
+       (136, 133, -125.38, '2020-03-23', 'Online Course', '2020-03-23 14:07:44+00:00', '2020-03-23 14:07:44+00:00'),
+       (120, 13, -17.09, '2020-03-23', 'Electric Bill Payment', '2020-03-23 15:02:24+00:00', '2020-03-23 15:02:24+00:00'),
+       (194, 160, -56.92, '2020-03-23', 'Savings Account ****0036', '2020-03-23 20:46:01+00:00', '2020-03-23 20:46:01+00:00'),
+       (45, 53, -102.05, '2020-03-24', 'Babysitting Payment', '2020-03-24 04:09:50+00:00', '2020-03-24 04:09:50+00:00'),
+       (158, 125, -166.55, '2020-03-24', 'Clothing Store Purchase', '2020-03-24 11:42:15+00:00', '2020-03-24 11:42:15+00:00'),
+       (87, 41, -13.15, '2020-03-24', 'DMV Fee', '2020-03-24 19:05:30+00:00', '2020-03-24 19:05:30+00:00'),
+       (131, 65, -40.93, '2020-03-25', 'Doctor Visit', '2020-03-25 02:05:31+00:00', '2020-03-25 02:05:31+00:00'),
+       (37, 77, -66.47, '2020-03-25', 'Pet Store Purchase', '2020-03-25 02:13:54+00:00', '2020-03-25 02:13:54+00:00'),
+       (69, 129, -6.89, '2020-03-25', 'Movies', '2020-03-25 02:36:39+00:00', '2020-03-25 02:36:39+00:00'),
+       (9, 83, -91.80, '2020-03-25', 'Church Donation', '2020-03-25 04:18:18+00:00', '2020-03-25 04:18:18+00:00'),
+       (192, 37, -153.80, '2020-03-25', 'Internet Bill', '2020-03-25 08:17:53+00:00', '2020-03-25 08:17:53+00:00'),
+       (106, 67, -64.26, '2020-03-25', 'Checking Account ****0007', '2020-03-25 14:52:55+00:00', '2020-03-25 14:52:55+00:00'),
+       (193, 178, -134.68, '2020-03-25', 'Misc Expense', '2020-03-25 20:49:30+00:00', '2020-03-25 20:49:30+00:00'),
+       (9, 92, -56.91, '2020-03-26', 'Salary Payment', '2020-03-26 03:08:56+00:00', '2020-03-26 03:08:56+00:00'),
+       (175, 71, -25.52, '2020-03-26', 'Gym Membership Fee', '2020-03-26 04:28:08+00:00', '2020-03-26 04:28:08+00:00'),
+       (55, 96, -29.87, '2020-03-26', 'Nonprofit Donation', '2020-03-26 05:14:59+00:00', '2020-03-26 05:14:59+00:00'),
+       (193, 159, -44.58, '2020-03-26', 'Software Subscription', '2020-03-26 12:24:23+00:00', '2020-03-26 12:24:23+00:00'),
+       (87, 17, -41.77, '2020-03-26', 'Fast Food Purchase', '2020-03-26 18:29:02+00:00', '2020-03-26 18:29:02+00:00'),
+       (145, 23, -96.87, '2020-03-27', 'Fast Food Purchase', '2020-03-27 00:34:59+00:00', '2020-03-27 00:34:59+00:00'),
+       (98, 129, -41.39, '2020-03-27', 'Movies', '2020-03-27 01:18:52+00:00', '2020-03-27 01:18:52+00:00'),
+       (102, 176, -118.96, '2020-03-27', 'Found Cash', '2020-03-27 02:06:33+00:00', '2020-03-27 02:06:33+00:00'),
+       (52, 92, 10118.21, '2020-03-27', 'Salary Payment', '2020-03-27 05:02:54+00:00', '2020-03-27 05:02:54+00:00'),
+       (39, 179, -20.00, '2020-03-27', 'Affiliate Income', '2020-03-27 05:07:20+00:00', '2020-03-27 05:07:20+00:00'),
+       (179, 153, -13.93, '2020-03-27', 'Interest Income', '2020-03-27 06:18:58+00:00', '2020-03-27 06:18:58+00:00'),
+       (147, 166, -58.02, '2020-03-27', 'Investment Account 1', '2020-03-27 07:45:52+00:00', '2020-03-27 07:45:52+00:00'),
+       (33, 95, -62.44, '2020-03-27', 'Home Depot', '2020-03-27 12:27:07+00:00', '2020-03-27 12:27:07+00:00'),
+       (121, 122, -54.03, '2020-03-27', 'Salon Visit', '2020-03-27 12:50:11+00:00', '2020-03-27 12:50:11+00:00'),
+       (17, 142, -119.92, '2020-03-27', 'Brokerage Account 2', '2020-03-27 16:33:10+00:00', '2020-03-27 16:33:10+00:00'),
+       (86, 173, -9.82, '2020-03-28', 'Loan Disbursement', '2020-03-28 03:19:47+00:00', '2020-03-28 03:19:47+00:00'),
+       (158, 129, -105.05, '2020-03-28', 'Movies', '2020-03-28 09:05:57+00:00', '2020-03-28 09:05:57+00:00'),
+       (60, 41, -86.77, '2020-03-28', 'DMV Fee', '2020-03-28 13:09:14+00:00', '2020-03-28 13:09:14+00:00'),
+       (200, 24, -25.09, '2020-03-28', 'Internet Bill', '2020-03-28 18:48:38+00:00', '2020-03-28 18:48:38+00:00'),
+       (80, 19, -213.17, '2020-03-28', 'Groceries', '2020-03-28 23:12:37+00:00', '2020-03-28 23:12:37+00:00'),
+       (55, 7, -49.21, '2020-03-29', 'Lawn Care Service', '2020-03-29 10:25:40+00:00', '2020-03-29 10:25:40+00:00'),
+       (95, 53, -99.14, '2020-03-29', 'Babysitting Payment', '2020-03-29 13:04:29+00:00', '2020-03-29 13:04:29+00:00'),
+       (131, 61, -23.62, '2020-03-29', 'Life Insurance Premium', '2020-03-29 15:10:51+00:00', '2020-03-29 15:10:51+00:00'),
+       (56, 3, -200.79, '2020-03-29', 'Home Maintenance', '2020-03-29 17:23:17+00:00', '2020-03-29 17:23:17+00:00'),
+       (38, 63, -139.94, '2020-03-29', 'Student Loan Payment', '2020-03-29 23:39:20+00:00', '2020-03-29 23:39:20+00:00'),
+       (5, 34, -49.91, '2020-03-30', 'Video Streaming Subscription', '2020-03-30 05:09:07+00:00',
+        '2020-03-30 05:09:07+00:00'),
+       (28, 62, -26.67, '2020-03-30', 'Medical Insurance Premium', '2020-03-30 14:53:52+00:00', '2020-03-30 14:53:52+00:00'),
+       (12, 145, -491.81, '2020-03-30', 'Retirement Account 5', '2020-03-30 15:26:55+00:00', '2020-03-30 15:26:55+00:00'),
+       (185, 119, -70.58, '2020-03-30', 'Freelance Income', '2020-03-30 20:57:08+00:00', '2020-03-30 20:57:08+00:00'),
+       (49, 6, -13.72, '2020-03-31', 'Home Warranty Payment', '2020-03-31 05:28:07+00:00', '2020-03-31 05:28:07+00:00'),
+       (157, 173, -56.70, '2020-03-31', 'Loan Disbursement', '2020-03-31 11:26:55+00:00', '2020-03-31 11:26:55+00:00'),
+       (130, 96, -168.70, '2020-03-31', 'Nonprofit Donation', '2020-03-31 19:05:04+00:00', '2020-03-31 19:05:04+00:00'),
+       (157, 119, -78.93, '2020-03-31', 'Freelance Income', '2020-03-31 23:26:57+00:00', '2020-03-31 23:26:57+00:00'),
+       (185, 15, -138.89, '2020-04-01', 'Phone Bill', '2020-04-01 01:30:30+00:00', '2020-04-01 01:30:30+00:00'),
+       (100, 198, -65.04, '2020-04-01', 'Signing Bonus', '2020-04-01 04:19:18+00:00', '2020-04-01 04:19:18+00:00'),
+       (29, 9, -90.04, '2020-04-01', 'Electric Bill Payment', '2020-04-01 09:13:26+00:00', '2020-04-01 09:13:26+00:00'),
+       (184, 198, -92.31, '2020-04-01', 'Signing Bonus', '2020-04-01 09:50:17+00:00', '2020-04-01 09:50:17+00:00'),
+       (21, 31, -36.71, '2020-04-01', 'Gas Station Purchase', '2020-04-01 11:24:21+00:00', '2020-04-01 11:24:21+00:00'),
+       (142, 85, -73.67, '2020-04-01', 'Church Donation', '2020-04-01 22:49:30+00:00', '2020-04-01 22:49:30+00:00'),
+       (159, 58, -82.67, '2020-04-02', 'Gym Membership Fee', '2020-04-02 09:57:19+00:00', '2020-04-02 09:57:19+00:00'),
+       (92, 102, -129.83, '2020-04-02', 'Political Campaign Donation', '2020-04-02 14:11:58+00:00',
+        '2020-04-02 14:11:58+00:00'),
+       (160, 146, -32.93, '2020-04-02', 'Retirement Account 4', '2020-04-02 21:57:43+00:00', '2020-04-02 21:57:43+00:00'),
+       (93, 149, -105.28, '2020-04-03', 'Retirement Account 10', '2020-04-03 03:30:54+00:00', '2020-04-03 03:30:54+00:00'),
+       (43, 185, -55.31, '2020-04-03', 'Social Security Benefits', '2020-04-03 07:02:15+00:00', '2020-04-03 07:02:15+00:00'),
+       (123, 114, -63.79, '2020-04-03', 'Income Tax Payment', '2020-04-03 12:03:30+00:00', '2020-04-03 12:03:30+00:00'),
+       (81, 178, -50.51, '2020-04-03', 'Misc Expense', '2020-04-03 14:41:32+00:00', '2020-04-03 14:41:32+00:00'),
+       (21, 167, -171.33, '2020-04-03', 'Investment Account 3', '2020-04-03 21:16:48+00:00', '2020-04-03 21:16:48+00:00'),
+       (146, 90, -143.56, '2020-04-04', 'School Supplies Purchase', '2020-04-04 04:19:25+00:00', '2020-04-04 04:19:25+00:00'),
+       (187, 178, -12.30, '2020-04-04', 'Misc Expense', '2020-04-04 05:59:20+00:00', '2020-04-04 05:59:20+00:00'),
+       (13, 117, -12.40, '2020-04-04', 'Brokerage Account 8', '2020-04-04 13:11:42+00:00', '2020-04-04 13:11:42+00:00'),
+       (60, 36, -159.53, '2020-04-04', 'Trash Service Bill', '2020-04-04 16:23:23+00:00', '2020-04-04 16:23:23+00:00'),
+       (127, 22, -139.33, '2020-04-04', 'Public Transport Fare', '2020-04-04 18:01:11+00:00', '2020-04-04 18:01:11+00:00'),
+       (16, 75, -92.73, '2020-04-04', 'Vet Clinic Payment', '2020-04-04 23:17:51+00:00', '2020-04-04 23:17:51+00:00'),
+       (105, 96, -79.17, '2020-04-05', 'Nonprofit Donation', '2020-04-05 07:59:29+00:00', '2020-04-05 07:59:29+00:00'),
+       (121, 144, -67.90, '2020-04-05', 'Retirement Account 4', '2020-04-05 12:13:04+00:00', '2020-04-05 12:13:04+00:00'),
+       (195, 45, -44.05, '2020-04-05', 'Insurance Premium', '2020-04-05 22:49:39+00:00', '2020-04-05 22:49:39+00:00'),
+       (152, 15, -49.42, '2020-04-06', 'Phone Bill', '2020-04-06 07:31:19+00:00', '2020-04-06 07:31:19+00:00'),
+       (96, 52, -102.55, '2020-04-06', 'Vision Insurance Premium', '2020-04-06 20:48:01+00:00', '2020-04-06 20:48:01+00:00'),
+       (4, 4, -363.95, '2020-04-06', 'Property Tax Payment', '2020-04-06 23:07:41+00:00', '2020-04-06 23:07:41+00:00'),
+       (17, 29, -88.63, '2020-04-07', 'Fast Food Purchase', '2020-04-07 02:27:24+00:00', '2020-04-07 02:27:24+00:00'),
+       (83, 47, -57.34, '2020-04-07', 'Specialist Visit', '2020-04-07 09:44:37+00:00', '2020-04-07 09:44:37+00:00'),
+       (90, 148, -274.02, '2020-04-07', 'Retirement Account 9', '2020-04-07 18:37:53+00:00', '2020-04-07 18:37:53+00:00'),
+       (193, 94, -168.11, '2020-04-07', 'Extracurricular Activity Fee', '2020-04-07 21:51:05+00:00',
+        '2020-04-07 21:51:05+00:00'),
+       (161, 127, -110.98, '2020-04-08', 'Magazines Subscription', '2020-04-08 03:39:56+00:00', '2020-04-08 03:39:56+00:00'),
+       (5, 94, -48.02, '2020-04-08', 'Extracurricular Activity Fee', '2020-04-08 09:25:54+00:00',
+        '2020-04-08 09:25:54+00:00'),
+       (71, 96, -192.88, '2020-04-08', 'Nonprofit Donation', '2020-04-08 18:38:06+00:00', '2020-04-08 18:38:06+00:00'),
+       (130, 70, -108.76, '2020-04-08', 'Gym Membership Fee', '2020-04-08 21:07:12+00:00', '2020-04-08 21:07:12+00:00'),
+       (176, 63, -112.35, '2020-04-09', 'Student Loan Payment', '2020-04-09 08:57:07+00:00', '2020-04-09 08:57:07+00:00'),
+       (78, 150, -70.93, '2020-04-09', 'Gym Membership Fee', '2020-04-09 14:25:18+00:00', '2020-04-09 14:25:18+00:00'),
+       (87, 4, -999.83, '2020-04-09', 'Home Maintenance', '2020-04-09 15:39:03+00:00', '2020-04-09 15:39:03+00:00'),
+       (32, 178, -101.79, '2020-04-09', 'Misc Expense', '2020-04-09 19:26:43+00:00', '2020-04-09 19:26:43+00:00'),
+       (64, 140, 10434.83, '2020-04-10', 'Sale of Property Proceeds', '2020-04-10 04:35:29+00:00',
+        '2020-04-10 04:35:29+00:00'),
+       (16, 162, -15.98, '2020-04-10', 'Grant Funds', '2020-04-10 07:10:06+00:00', '2020-04-10 07:10:06+00:00'),
+       (37, 142, -53.90, '2020-04-10', 'Brokerage Account 2', '2020-04-10 12:23:12+00:00', '2020-04-10 12:23:12+00:00'),
+       (104, 108, -160.53, '2020-04-10', 'Babysitting Payment', '2020-04-10 15:45:19+00:00', '2020-04-10 15:45:19+00:00'),
+       (190, 176, -198.81, '2020-04-10', 'Found Cash', '2020-04-10 17:39:25+00:00', '2020-04-10 17:39:25+00:00'),
+       (67, 107, -175.71, '2020-04-10', 'Daycare Fee', '2020-04-10 20:27:32+00:00', '2020-04-10 20:27:32+00:00'),
+       (87, 70, -81.93, '2020-04-11', 'Gym Membership Fee', '2020-04-11 03:33:52+00:00', '2020-04-11 03:33:52+00:00'),
+       (106, 87, -49.98, '2020-04-11', 'Auto Loan Payment', '2020-04-11 05:07:54+00:00', '2020-04-11 05:07:54+00:00'),
+       (43, 149, -81.92, '2020-04-11', 'Retirement Account 10', '2020-04-11 12:31:07+00:00', '2020-04-11 12:31:07+00:00'),
+       (64, 40, -61.75, '2020-04-11', 'Trash Service Bill', '2020-04-11 12:46:33+00:00', '2020-04-11 12:46:33+00:00'),
+       (199, 51, -90.86, '2020-04-11', 'Senior Care Expenses', '2020-04-11 19:56:03+00:00', '2020-04-11 19:56:03+00:00'),
+       (93, 40, -59.74, '2020-04-12', 'Trash Service Bill', '2020-04-12 01:26:33+00:00', '2020-04-12 01:26:33+00:00'),
+       (139, 87, -59.88, '2020-04-12', 'Auto Loan Payment', '2020-04-12 06:27:40+00:00', '2020-04-12 06:27:40+00:00'),
+       (14, 171, -47.26, '2020-04-12', 'Credit Card Cashback', '2020-04-12 07:16:43+00:00', '2020-04-12 07:16:43+00:00'),
+       (75, 90, -79.62, '2020-04-12', 'School Supplies Purchase', '2020-04-12 11:57:49+00:00', '2020-04-12 11:57:49+00:00'),
+       (111, 126, -189.86, '2020-04-12', 'Spa Service', '2020-04-12 20:07:03+00:00', '2020-04-12 20:07:03+00:00'),
+       (149, 149, -176.43, '2020-04-13', 'Retirement Account 10', '2020-04-13 03:39:53+00:00', '2020-04-13 03:39:53+00:00'),
+       (157, 58, -9.92, '2020-04-13', 'Gym Membership Fee', '2020-04-13 04:18:36+00:00', '2020-04-13 04:18:36+00:00'),
+       (96, 73, -59.59, '2020-04-13', 'Pet Store Purchase', '2020-04-13 13:15:03+00:00', '2020-04-13 13:15:03+00:00'),
+       (125, 108, -63.31, '2020-04-13', 'Babysitting Payment', '2020-04-13 22:25:09+00:00', '2020-04-13 22:25:09+00:00'),
+       (61, 125, -60.44, '2020-04-14', 'Clothing Store Purchase', '2020-04-14 03:24:41+00:00', '2020-04-14 03:24:41+00:00'),
+       (136, 171, -77.81, '2020-04-14', 'Credit Card Cashback', '2020-04-14 03:58:16+00:00', '2020-04-14 03:58:16+00:00'),
+       (171, 24, -25.90, '2020-04-14', 'Internet Bill', '2020-04-14 10:25:05+00:00', '2020-04-14 10:25:05+00:00'),
+       (8, 19, -9.91, '2020-04-14', 'Groceries', '2020-04-14 14:05:51+00:00', '2020-04-14 14:05:51+00:00'),
+       (21, 72, -27.19, '2020-04-14', 'Pet Grooming Service', '2020-04-14 14:58:57+00:00', '2020-04-14 14:58:57+00:00'),
+       (147, 58, -140.50, '2020-04-14', 'Gym Membership Fee', '2020-04-14 17:54:44+00:00', '2020-04-14 17:54:44+00:00'),
+       (69, 186, -21.61, '2020-04-15', 'Pension Payment', '2020-04-15 07:36:19+00:00', '2020-04-15 07:36:19+00:00'),
+       (81, 63, -80.72, '2020-04-15', 'Student Loan Payment', '2020-04-15 16:34:36+00:00', '2020-04-15 16:34:36+00:00'),
+       (108, 157, -170.70, '2020-04-15', 'Other Income', '2020-04-15 18:46:10+00:00', '2020-04-15 18:46:10+00:00'),
+       (164, 87, -77.78, '2020-04-15', 'Auto Loan Payment', '2020-04-15 18:59:27+00:00', '2020-04-15 18:59:27+00:00'),
+       (79, 75, -58.93, '2020-04-16', 'Vet Clinic Payment', '2020-04-16 04:28:12+00:00', '2020-04-16 04:28:12+00:00'),
+       (49, 144, -6.81, '2020-04-16', 'Retirement Account 4', '2020-04-16 14:58:47+00:00', '2020-04-16 14:58:47+00:00'),
+       (148, 27, -19.93, '2020-04-16', 'Airline Ticket Purchase', '2020-04-16 16:00:06+00:00', '2020-04-16 16:00:06+00:00'),
+       (105, 9, -85.62, '2020-04-16', 'Electric Bill Payment', '2020-04-16 22:27:40+00:00', '2020-04-16 22:27:40+00:00'),
+       (164, 80, -36.95, '2020-04-17', 'Ride Sharing Fare', '2020-04-17 06:47:05+00:00', '2020-04-17 06:47:05+00:00'),
+       (47, 185, -15.93, '2020-04-17', 'Social Security Benefits', '2020-04-17 15:33:33+00:00', '2020-04-17 15:33:33+00:00'),
+       (82, 78, -74.81, '2020-04-17', 'Pet Store Purchase', '2020-04-17 18:37:06+00:00', '2020-04-17 18:37:06+00:00'),
+       (73, 166, -174.81, '2020-04-17', 'Investment Account 1', '2020-04-17 19:18:22+00:00', '2020-04-17 19:18:22+00:00'),
+       (30, 124, 3384.23, '2020-04-18', 'Affiliate Income', '2020-04-18 03:36:31+00:00', '2020-04-18 03:36:31+00:00'),
+       (68, 85, -174.80, '2020-04-18', 'Church Donation', '2020-04-18 07:04:46+00:00', '2020-04-18 07:04:46+00:00'),
+       (185, 141, -38.77, '2020-04-18', 'Retirement Account 2', '2020-04-18 12:27:21+00:00', '2020-04-18 12:27:21+00:00'),
+       (78, 132, -74.71, '2020-04-18', 'Course Fee', '2020-04-18 17:15:00+00:00', '2020-04-18 17:15:00+00:00'),
+       (120, 66, -63.86, '2020-04-18', 'Mortgage Insurance Premium', '2020-04-18 23:33:29+00:00',
+        '2020-04-18 23:33:29+00:00'),
+       (122, 7, -94.03, '2020-04-19', 'Lawn Care Service', '2020-04-19 03:47:57+00:00', '2020-04-19 03:47:57+00:00'),
+       (192, 42, -47.42, '2020-04-19', 'DMV Fee', '2020-04-19 09:51:07+00:00', '2020-04-19 09:51:07+00:00'),
+       (92, 57, -114.93, '2020-04-19', 'Health Supplements', '2020-04-19 15:27:49+00:00', '2020-04-19 15:27:49+00:00'),
+       (4, 126, -28.94, '2020-04-19', 'Spa Service', '2020-04-19 16:36:38+00:00', '2020-04-19 16:36:38+00:00'),
+       (163, 128, -248.96, '2020-04-19', 'Concert Tickets', '2020-04-19 22:57:53+00:00', '2020-04-19 22:57:53+00:00'),
+       (65, 123, -158.96, '2020-04-20', 'Conference Fee', '2020-04-20 06:37:22+00:00', '2020-04-20 06:37:22+00:00'),
+       (139, 98, -10.62, '2020-04-20', 'Freelance Income', '2020-04-20 17:37:58+00:00', '2020-04-20 17:37:58+00:00'),
+       (41, 6, -118.47, '2020-04-20', 'Home Warranty Payment', '2020-04-20 20:15:18+00:00', '2020-04-20 20:15:18+00:00'),
+       (107, 152, -99.73, '2020-04-21', 'Uber Income', '2020-04-21 00:05:15+00:00', '2020-04-21 00:05:15+00:00'),
+       (86, 7, -47.65, '2020-04-21', 'Lawn Care Service', '2020-04-21 02:31:50+00:00', '2020-04-21 02:31:50+00:00'),
+       (172, 96, -118.66, '2020-04-21', 'Nonprofit Donation', '2020-04-21 06:18:29+00:00', '2020-04-21 06:18:29+00:00'),
+       (3, 87, -46.75, '2020-04-21', 'Auto Loan Payment', '2020-04-21 14:07:47+00:00', '2020-04-21 14:07:47+00:00'),
+       (67, 76, -90.62, '2020-04-21', 'Vet Pharmacy Purchase', '2020-04-21 17:40:22+00:00', '2020-04-21 17:40:22+00:00'),
+       (17, 173, -46.88, '2020-04-22', 'Loan Disbursement', '2020-04-22 03:32:43+00:00', '2020-04-22 03:32:43+00:00'),
+       (132, 67, -98.59, '2020-04-22', 'Checking Account ****0007', '2020-04-22 11:13:55+00:00', '2020-04-22 11:13:55+00:00'),
+       (120, 122, -163.09, '2020-04-22', 'Salon Visit', '2020-04-22 17:33:51+00:00', '2020-04-22 17:33:51+00:00'),
+       (81, 185, -31.84, '2020-04-22', 'Social Security Benefits', '2020-04-22 20:05:42+00:00', '2020-04-22 20:05:42+00:00'),
+       (135, 107, -74.85, '2020-04-23', 'Daycare Fee', '2020-04-23 07:19:18+00:00', '2020-04-23 07:19:18+00:00'),
+       (58, 155, -37.32, '2020-04-23', 'Consulting Fee', '2020-04-23 07:45:38+00:00', '2020-04-23 07:45:38+00:00'),
+       (123, 167, -127.22, '2020-04-23', 'Investment Account 3', '2020-04-23 18:46:31+00:00', '2020-04-23 18:46:31+00:00'),
+       (163, 42, -26.34, '2020-04-23', 'DMV Fee', '2020-04-23 21:02:07+00:00', '2020-04-23 21:02:07+00:00'),
+       (39, 95, -57.68, '2020-04-24', 'Home Depot', '2020-04-24 00:43:28+00:00', '2020-04-24 00:43:28+00:00'),
+       (15, 198, -9.66, '2020-04-24', 'Signing Bonus', '2020-04-24 05:47:18+00:00', '2020-04-24 05:47:18+00:00'),
+       (62, 72, -36.39, '2020-04-24', 'Pet Grooming Service', '2020-04-24 07:25:44+00:00', '2020-04-24 07:25:44+00:00'),
+       (94, 146, -35.36, '2020-04-24', 'Retirement Account 4', '2020-04-24 08:32:13+00:00', '2020-04-24 08:32:13+00:00'),
+       (100, 172, -53.46, '2020-04-24', 'Misc Expense', '2020-04-24 15:35:55+00:00', '2020-04-24 15:35:55+00:00'),
+       (161, 53, -49.03, '2020-04-24', 'Babysitting Payment', '2020-04-24 21:59:08+00:00', '2020-04-24 21:59:08+00:00'),
+       (139, 48, -34.63, '2020-04-25', 'Small Appliances Purchase', '2020-04-25 00:57:43+00:00', '2020-04-25 00:57:43+00:00'),
+       (9, 165, -7.81, '2020-04-25', 'Investment Account 5', '2020-04-25 04:32:18+00:00', '2020-04-25 04:32:18+00:00'),
+       (184, 164, -87.11, '2020-04-25', 'Social Security Benefits', '2020-04-25 10:32:40+00:00', '2020-04-25 10:32:40+00:00'),
+       (109, 166, -144.72, '2020-04-25', 'Investment Account 1', '2020-04-25 19:08:36+00:00', '2020-04-25 19:08:36+00:00'),
+       (67, 86, -54.87, '2020-04-25', 'Savings Account ****0035', '2020-04-25 22:41:40+00:00', '2020-04-25 22:41:40+00:00'),
+       (185, 132, -51.81, '2020-04-26', 'Course Fee', '2020-04-26 03:23:14+00:00', '2020-04-26 03:23:14+00:00'),
+       (14, 166, -62.68, '2020-04-26', 'Investment Account 1', '2020-04-26 09:10:13+00:00', '2020-04-26 09:10:13+00:00'),
+       (131, 78, -79.03, '2020-04-26', 'Pet Store Purchase', '2020-04-26 14:25:46+00:00', '2020-04-26 14:25:46+00:00'),
+       (87, 58, -89.86, '2020-04-26', 'Gym Membership Fee', '2020-04-26 16:10:34+00:00', '2020-04-26 16:10:34+00:00'),
+       (153, 26, -110.15, '2020-04-26', 'DMV Fee', '2020-04-26 20:07:24+00:00', '2020-04-26 20:07:24+00:00'),
+       (32, 161, -117.08, '2020-04-27', 'Social Security Benefits', '2020-04-27 06:01:10+00:00', '2020-04-27 06:01:10+00:00'),
+       (136, 32, -17.24, '2020-04-27', 'Coffee Shop Purchase', '2020-04-27 07:29:49+00:00', '2020-04-27 07:29:49+00:00'),
+       (57, 123, -114.16, '2020-04-27', 'Conference Fee', '2020-04-27 09:46:37+00:00', '2020-04-27 09:46:37+00:00'),
+       (45, 72, -39.97, '2020-04-27', 'Pet Grooming Service', '2020-04-27 18:06:11+00:00', '2020-04-27 18:06:11+00:00'),
+       (99, 58, -92.08, '2020-04-27', 'Gym Membership Fee', '2020-04-27 21:45:53+00:00', '2020-04-27 21:45:53+00:00'),
+       (120, 103, -107.27, '2020-04-28', 'Political Campaign Donation', '2020-04-28 01:55:17+00:00',
+        '2020-04-28 01:55:17+00:00'),
+       (195, 119, -146.11, '2020-04-28', 'Freelance Income', '2020-04-28 02:53:17+00:00', '2020-04-28 02:53:17+00:00'),
+       (71, 71, -34.85, '2020-04-28', 'Gym Membership Fee', '2020-04-28 07:01:15+00:00', '2020-04-28 07:01:15+00:00'),
+       (44, 64, -135.67, '2020-04-28', 'Mortgage Loan #007', '2020-04-28 12:06:19+00:00', '2020-04-28 12:06:19+00:00'),
+       (173, 84, -53.40, '2020-04-28', 'Insurance Payout', '2020-04-28 19:50:17+00:00', '2020-04-28 19:50:17+00:00'),
+       (3, 149, -106.02, '2020-04-29', 'Retirement Account 10', '2020-04-29 04:11:59+00:00', '2020-04-29 04:11:59+00:00'),
+       (26, 92, -284.57, '2020-04-29', 'Salary Payment', '2020-04-29 07:55:45+00:00', '2020-04-29 07:55:45+00:00'),
+       (53, 150, -27.53, '2020-04-29', 'Gym Membership Fee', '2020-04-29 14:22:58+00:00', '2020-04-29 14:22:58+00:00'),
+       (143, 105, -1.54, '2020-04-29', 'Retirement Contribution', '2020-04-29 20:47:19+00:00', '2020-04-29 20:47:19+00:00'),
+       (153, 56, -232.85, '2020-04-30', 'Supplement Store Purchase', '2020-04-30 13:25:01+00:00',
+        '2020-04-30 13:25:01+00:00'),
+       (81, 138, -981.28, '2020-05-01', 'Holiday Gifts', '2020-05-01 05:47:16+00:00', '2020-05-01 05:47:16+00:00'),
+       (163, 151, 9307.02, '2020-05-01', 'Payroll Deposit', '2020-05-01 23:41:47+00:00', '2020-05-01 23:41:47+00:00');
+
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE accounts DROP CONSTRAINT df_accounts_currency;
+ALTER TABLE accounts DROP COLUMN currency;
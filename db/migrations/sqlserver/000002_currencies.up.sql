@@ -0,0 +1,15 @@
+-- +migrate Up
+
+-- Existing accounts predate currencies and are all in US dollars.
+-- The default constraint is named so that the down migration can drop it before the column.
+ALTER TABLE accounts ADD currency NCHAR(3) NOT NULL CONSTRAINT df_accounts_currency DEFAULT 'USD';
+
+-- One unit of base_currency is worth rate units of quote_currency from rate_date until the next rate of the pair
+CREATE TABLE exchange_rates
+(
+    rate_date      DATE           NOT NULL,
+    base_currency  NCHAR(3)       NOT NULL,
+    quote_currency NCHAR(3)       NOT NULL,
+    rate           DECIMAL(18, 8) NOT NULL,
+    PRIMARY KEY (rate_date, base_currency, quote_currency)
+);
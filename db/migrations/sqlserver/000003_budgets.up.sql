@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- A spending limit of a category per period, from the period containing start_date.
+-- With rollover the unspent amount of each period, or the overspending, carries over to the next one.
+CREATE TABLE budgets
+(
+    budget_id   INT IDENTITY (1, 1) PRIMARY KEY,
+    category_id INT            NOT NULL,
+    period      NVARCHAR(10)   NOT NULL CHECK (period IN ('week', 'month', 'quarter', 'year')),
+    amount      DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    rollover    BIT            NOT NULL DEFAULT 0,
+    start_date  DATE           NOT NULL,
+    created_at  DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    updated_at  DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    UNIQUE (category_id, period)
+);
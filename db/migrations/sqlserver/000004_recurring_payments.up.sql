@@ -0,0 +1,24 @@
+-- +migrate Up
+
+-- Payments detected as recurring among the transactions of an account in a category whose descriptions normalize to
+-- description_key. They are refreshed by each analysis and kept when they stop, so that missed payments show up.
+CREATE TABLE recurring_payments
+(
+    recurring_payment_id INT IDENTITY (1, 1) PRIMARY KEY,
+    account_id           INT            NOT NULL,
+    category_id          INT            NOT NULL,
+    description_key      NVARCHAR(255)  NOT NULL,
+    description          NVARCHAR(MAX)  NOT NULL,
+    frequency            NVARCHAR(10)   NOT NULL CHECK (frequency IN ('weekly', 'monthly', 'annual')),
+    typical_amount       DECIMAL(10, 2) NOT NULL,
+    occurrences          INT            NOT NULL,
+    first_date           DATE           NOT NULL,
+    last_date            DATE           NOT NULL,
+    last_amount          DECIMAL(10, 2) NOT NULL,
+    next_due_date        DATE           NOT NULL,
+    created_at           DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    updated_at           DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+    FOREIGN KEY (category_id) REFERENCES categories (category_id),
+    UNIQUE (account_id, category_id, description_key)
+);
@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- The bank's ID of an imported transaction, such as the FITID of an OFX statement, so that re-imports skip it.
+-- SQL Server counts NULLs as equal in unique indexes, so the index leaves out the transactions without one.
+ALTER TABLE transactions ADD external_id NVARCHAR(255);
+CREATE UNIQUE INDEX ux_transactions_external_id ON transactions (account_id, external_id) WHERE external_id IS NOT NULL;
+
+-- The ledger balance of an account as of a day, as stated by an imported bank statement
+CREATE TABLE statement_balances
+(
+    account_id   INT            NOT NULL,
+    balance_date DATE           NOT NULL,
+    balance      DECIMAL(12, 2) NOT NULL,
+    created_at   DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    updated_at   DATETIMEOFFSET NOT NULL DEFAULT SYSDATETIMEOFFSET(),
+    PRIMARY KEY (account_id, balance_date),
+    FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
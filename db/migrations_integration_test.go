@@ -34,7 +34,7 @@ func TestRunMigrations(t *testing.T) {
 	}
 	defer sqlDb.Close()
 
-	err = RunMigrations(db)
+	err = RunMigrations(db, config.DbType)
 	if err != nil {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -0,0 +1,47 @@
+//go:build integration && sqlite
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	pkgdb "sample-mcp/pkg/db"
+)
+
+func TestRunMigrations_Sqlite(t *testing.T) {
+	config := &pkgdb.ConnectionConfig{
+		DbType:       pkgdb.Sqlite,
+		DbName:       pkgdb.SqliteMemory,
+		Timeout:      10 * time.Second,
+		MaxIdleConns: 1,
+		MaxOpenConns: 1,
+	}
+
+	db, err := config.Pool()
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	if err := RunMigrations(db, config.DbType); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	// Running them again must be a no-op
+	if err := RunMigrations(db, config.DbType); err != nil {
+		t.Fatalf("Failed to rerun migrations: %v", err)
+	}
+
+	for _, table := range []string{"accounts", "categories", "transactions"} {
+		if !db.Migrator().HasTable(table) {
+			t.Errorf("Expected table %s to exist after migrations", table)
+		}
+	}
+
+	var count int64
+	if err := db.Table("transactions").Where("transaction_date >= ?", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count seeded transactions: %v", err)
+	}
+	if count == 0 {
+		t.Error("Expected seeded transactions to match a date filter")
+	}
+}
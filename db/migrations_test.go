@@ -0,0 +1,94 @@
+package db
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	pkgdb "sample-mcp/pkg/db"
+)
+
+func TestMigrationDir(t *testing.T) {
+	postgresOnly := []string{"SERIAL", "TIMESTAMPTZ", "now()"}
+
+	var postgresVersions []uint
+	for _, dbType := range []pkgdb.DatabaseType{pkgdb.Postgresql, pkgdb.Mysql, pkgdb.MSSQL, pkgdb.Sqlite} {
+		t.Run(string(dbType), func(t *testing.T) {
+			dir, err := migrationDir(dbType)
+			if err != nil {
+				t.Fatalf("Expected a migration directory, got %v", err)
+			}
+
+			source, err := iofs.New(migrationsFS, dir)
+			if err != nil {
+				t.Fatalf("Failed to open migrations in %s: %v", dir, err)
+			}
+			defer source.Close()
+
+			version, err := source.First()
+			if err != nil || version != 1 {
+				t.Fatalf("Expected first migration version 1, got %d (%v)", version, err)
+			}
+
+			var versions []uint
+			for {
+				versions = append(versions, version)
+				data := readMigration(t, source, version)
+
+				if _, _, err := source.ReadDown(version); err != nil {
+					t.Errorf("Expected a down migration for version %d, got %v", version, err)
+				}
+
+				if dbType != pkgdb.Postgresql {
+					for _, keyword := range postgresOnly {
+						if strings.Contains(data, keyword) {
+							t.Errorf("%s migration %d uses Postgres-only %s", dbType, version, keyword)
+						}
+					}
+				}
+
+				next, err := source.Next(version)
+				if err != nil {
+					break
+				}
+				version = next
+			}
+
+			if dbType == pkgdb.Postgresql {
+				postgresVersions = versions
+				return
+			}
+			if !slices.Equal(versions, postgresVersions) {
+				t.Errorf("Expected the same migration versions as Postgres %v, got %v", postgresVersions, versions)
+			}
+		})
+	}
+}
+
+// readMigration reads the up migration of a version
+func readMigration(t *testing.T, src source.Driver, version uint) string {
+	t.Helper()
+	up, _, err := src.ReadUp(version)
+	if err != nil {
+		t.Fatalf("Failed to read up migration %d: %v", version, err)
+	}
+	defer up.Close()
+	data, err := io.ReadAll(up)
+	if err != nil {
+		t.Fatalf("Failed to read up migration %d: %v", version, err)
+	}
+	return string(data)
+}
+
+func TestMigrationDir_Unsupported(t *testing.T) {
+	if _, err := migrationDir("ORACLE"); err == nil {
+		t.Error("Expected an error for an unsupported database type")
+	}
+
+	if err := RunMigrations(nil, "ORACLE"); err == nil {
+		t.Error("Expected RunMigrations to reject an unsupported database type")
+	}
+}
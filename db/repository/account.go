@@ -12,13 +12,13 @@ type AccountRepository struct {
 
 func NewAccountRepository(db *gorm.DB) *AccountRepository {
 	return &AccountRepository{
-		BaseRepository: &BaseRepository[entity.Account]{DB: db},
+		BaseRepository: &BaseRepository[entity.Account]{DB: db, Scope: accountScope{}},
 	}
 }
 
 func (r *AccountRepository) FindByName(ctx context.Context, name string) (*entity.Account, error) {
 	var account entity.Account
-	if err := r.DB.WithContext(ctx).Where("name = ?", name).First(&account).Error; err != nil {
+	if err := r.query(ctx).Where("name = ?", name).First(&account).Error; err != nil {
 		return nil, err
 	}
 	return &account, nil
@@ -26,10 +26,28 @@ func (r *AccountRepository) FindByName(ctx context.Context, name string) (*entit
 
 func (r *AccountRepository) FindByNameLike(ctx context.Context, keyword string) ([]entity.Account, error) {
 	var accounts []entity.Account
-	if err := r.DB.WithContext(ctx).
-		Where("name ILIKE ?", "%"+keyword+"%").
+	if err := r.query(ctx).
+		Where(containsCondition(r.DB, "name"), containsPattern(keyword)).
 		Find(&accounts).Error; err != nil {
 		return nil, err
 	}
 	return accounts, nil
 }
+
+// FindByNameLikePage retrieves a page of the accounts whose names contain the keyword, ordered by ID
+func (r *AccountRepository) FindByNameLikePage(ctx context.Context, keyword string, page PageRequest) (*Page[entity.Account], error) {
+	keys, err := primaryKeyset[entity.Account](r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(r.query(ctx).Where(containsCondition(r.DB, "name"), containsPattern(keyword)), page, keys)
+}
+
+// FindByIDs retrieves the accounts with the given IDs, ordered by ID; IDs outside the caller's scope are skipped
+func (r *AccountRepository) FindByIDs(ctx context.Context, ids []uint) ([]entity.Account, error) {
+	var accounts []entity.Account
+	if err := r.query(ctx).Where("account_id IN ?", ids).Order("account_id").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
@@ -55,10 +55,10 @@ func TestAccountRepository_Create(t *testing.T) {
 		UpdatedAt:   time.Now(),
 	}
 
-	// Expectations
+	// Expectations; accounts without a currency are created in the default USD
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "accounts" ("name","account_type","created_at","updated_at") VALUES ($1,$2,$3,$4) RETURNING "created_at","updated_at","account_id"`)).
-		WithArgs(account.Name, account.AccountType, sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "accounts" ("name","account_type","currency","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) RETURNING "created_at","updated_at","account_id"`)).
+		WithArgs(account.Name, account.AccountType, "USD", sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "account_id"}).AddRow(time.Now(), time.Now(), 1))
 	mock.ExpectCommit()
 
@@ -181,13 +181,14 @@ func TestAccountRepository_Update(t *testing.T) {
 		AccountID:   1,
 		Name:        "Updated Account",
 		AccountType: "Checking",
+		Currency:    "EUR",
 		UpdatedAt:   time.Now(),
 	}
 
 	// Expectations
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "accounts" SET "name"=$1,"account_type"=$2,"created_at"=$3,"updated_at"=$4 WHERE "account_id" = $5`)).
-		WithArgs(account.Name, account.AccountType, sqlmock.AnyArg(), sqlmock.AnyArg(), account.AccountID).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "accounts" SET "name"=$1,"account_type"=$2,"currency"=$3,"created_at"=$4,"updated_at"=$5 WHERE "account_id" = $6`)).
+		WithArgs(account.Name, account.AccountType, account.Currency, sqlmock.AnyArg(), sqlmock.AnyArg(), account.AccountID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -337,7 +338,7 @@ func TestAccountRepository_FindByNameLike(t *testing.T) {
 	keyword := "Test"
 
 	// Expectations
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1 ESCAPE '!'`)).
 		WithArgs("%" + keyword + "%").
 		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "created_at", "updated_at"}).
 			AddRow(1, "Test Account 1", "Savings", time.Now(), time.Now()).
@@ -369,7 +370,7 @@ func TestAccountRepository_FindByNameLike_NotFound(t *testing.T) {
 	keyword := "Nonexistent"
 
 	// Expectations
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1 ESCAPE '!'`)).
 		WithArgs("%" + keyword + "%").
 		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "created_at", "updated_at"}))
 
@@ -399,7 +400,7 @@ func TestAccountRepository_FindByNameLike_Error(t *testing.T) {
 	keyword := "Error"
 
 	// Expectations
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1 ESCAPE '!'`)).
 		WithArgs("%" + keyword + "%").
 		WillReturnError(errors.New("database error"))
 
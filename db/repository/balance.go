@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository/plain"
+	"sample-mcp/pkg/calendar"
+	"sample-mcp/pkg/money"
+)
+
+// MaxDailyBalanceDays caps the length of a daily balance series
+const MaxDailyBalanceDays = 1000
+
+// runningBalanceKeyset orders running balances like transactionKeyset, the order the balance accumulates in
+var runningBalanceKeyset = keyset[plain.TransactionBalance]{
+	columns: transactionKeyset.columns,
+	cursorOf: func(b *plain.TransactionBalance) cursor {
+		return transactionCursor(&b.Transaction)
+	},
+}
+
+// SumByAccountIDAsOf calculates the balance of an account at the end of a day,
+// the sum of its transactions dated on or before it
+func (r *TransactionRepository) SumByAccountIDAsOf(ctx context.Context, accountID uint, date time.Time) (money.Amount, error) {
+	var sum money.Amount
+	err := r.query(ctx).
+		Model(&entity.Transaction{}).
+		Where("account_id = ? AND transaction_date <= ?", accountID, date).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&sum).Error
+	return sum, err
+}
+
+// FindRunningBalancesPage retrieves a page of the transactions of an account, oldest first, each with the balance
+// of the account right after it. Start and end optionally restrict the listing; the balance still includes
+// every earlier transaction.
+// Postgres computes the balance with a window function, the other dialects add the page up from the balance before it.
+func (r *TransactionRepository) FindRunningBalancesPage(
+	ctx context.Context,
+	accountID uint,
+	start, end *time.Time,
+	page PageRequest,
+) (*Page[plain.TransactionBalance], error) {
+	if start != nil && end != nil && end.Before(*start) {
+		return nil, fmt.Errorf("%w: end date is before start date", ErrInvalidFilter)
+	}
+	if r.DB.Dialector.Name() == "postgres" {
+		return r.runningBalancesWindow(ctx, accountID, start, end, page)
+	}
+	return r.runningBalancesEmulated(ctx, accountID, start, end, page)
+}
+
+// runningBalancesWindow sums the balance over the account's whole history in a subquery,
+// so that the date range and the page only select which rows are returned
+func (r *TransactionRepository) runningBalancesWindow(
+	ctx context.Context,
+	accountID uint,
+	start, end *time.Time,
+	page PageRequest,
+) (*Page[plain.TransactionBalance], error) {
+	balances := r.query(ctx).
+		Model(&entity.Transaction{}).
+		Select("transactions.*, SUM(transactions.amount) OVER (ORDER BY transactions.transaction_date, transactions.transaction_id) AS running_balance").
+		Where("transactions.account_id = ?", accountID)
+
+	query := withinDates(r.DB.WithContext(ctx).Table("(?) AS transactions", balances), start, end)
+	return paginate(query, page, runningBalanceKeyset)
+}
+
+// runningBalancesEmulated fetches a page of transactions and adds it up from the balance before its first row
+func (r *TransactionRepository) runningBalancesEmulated(
+	ctx context.Context,
+	accountID uint,
+	start, end *time.Time,
+	page PageRequest,
+) (*Page[plain.TransactionBalance], error) {
+	transactions, err := paginate(withinDates(r.query(ctx).Where("account_id = ?", accountID), start, end), page, transactionKeyset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Page[plain.TransactionBalance]{
+		Items:      make([]plain.TransactionBalance, len(transactions.Items)),
+		NextCursor: transactions.NextCursor,
+	}
+	if len(transactions.Items) == 0 {
+		return result, nil
+	}
+
+	first := transactions.Items[0]
+	var balance money.Amount
+	err = r.query(ctx).
+		Model(&entity.Transaction{}).
+		Where("account_id = ?", accountID).
+		Where("transaction_date < ? OR (transaction_date = ? AND transaction_id < ?)",
+			first.TransactionDate, first.TransactionDate, first.TransactionID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&balance).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i, transaction := range transactions.Items {
+		balance += transaction.Amount
+		result.Items[i] = plain.TransactionBalance{Transaction: transaction, RunningBalance: balance}
+	}
+	return result, nil
+}
+
+// DailyBalances calculates the balance of an account at the end of every day from start to end, inclusive,
+// including the days without transactions
+func (r *TransactionRepository) DailyBalances(ctx context.Context, accountID uint, start, end time.Time) ([]plain.DailyBalance, error) {
+	start, end = calendar.Day(start), calendar.Day(end)
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end date is before start date", ErrInvalidFilter)
+	}
+	days := int(end.Sub(start).Hours()/24) + 1
+	if days > MaxDailyBalanceDays {
+		return nil, fmt.Errorf("%w: the series has more than %d days, narrow the date range", ErrInvalidFilter, MaxDailyBalanceDays)
+	}
+
+	var opening money.Amount
+	err := r.query(ctx).
+		Model(&entity.Transaction{}).
+		Where("account_id = ? AND transaction_date < ?", accountID, start).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&opening).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var sums []struct {
+		TransactionDate scannedDate
+		Total           money.Amount
+	}
+	err = r.query(ctx).
+		Model(&entity.Transaction{}).
+		Where("account_id = ? AND transaction_date BETWEEN ? AND ?", accountID, start, end).
+		Select("transaction_date, SUM(amount) AS total").
+		Group("transaction_date").
+		Scan(&sums).Error
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[time.Time]money.Amount, len(sums))
+	for _, sum := range sums {
+		changes[calendar.Day(sum.TransactionDate.Time)] += sum.Total
+	}
+
+	series := make([]plain.DailyBalance, days)
+	balance := opening
+	for i := range series {
+		day := start.AddDate(0, 0, i)
+		balance += changes[day]
+		series[i] = plain.DailyBalance{Date: day, Balance: balance}
+	}
+	return series, nil
+}
+
+// withinDates restricts a transactions query to an optional inclusive date range
+func withinDates(db *gorm.DB, start, end *time.Time) *gorm.DB {
+	if start != nil {
+		db = db.Where("transaction_date >= ?", *start)
+	}
+	if end != nil {
+		db = db.Where("transaction_date <= ?", *end)
+	}
+	return db
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"sample-mcp/pkg/money"
+)
+
+var transactionBalanceColumns = []string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "running_balance"}
+
+func TestTransactionRepository_SumByAccountIDAsOf(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	date := time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1 AND transaction_date <= $2`)).
+		WithArgs(uint(1), date).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("1523.07"))
+
+	// Test
+	balance, err := repo.SumByAccountIDAsOf(context.Background(), 1, date)
+	if err != nil {
+		t.Fatalf("Error calculating balance: %v", err)
+	}
+
+	if balance != money.MustParse("1523.07") {
+		t.Errorf("Expected 1523.07, got %s", balance)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindRunningBalancesPage_Window(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Expectations; the range applies outside the window so that earlier transactions count towards the balance
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM (SELECT transactions.*, `+
+		`SUM(transactions.amount) OVER (ORDER BY transactions.transaction_date, transactions.transaction_id) AS running_balance `+
+		`FROM "transactions" WHERE transactions.account_id = $1) AS transactions `+
+		`WHERE transaction_date >= $2 ORDER BY transaction_date ASC,transaction_id ASC LIMIT $3`)).
+		WithArgs(uint(1), start, 3).
+		WillReturnRows(sqlmock.NewRows(transactionBalanceColumns).
+			AddRow(10, 1, 2, "-20.00", start, "980.00").
+			AddRow(11, 1, 2, "-30.00", start, "950.00").
+			AddRow(12, 1, 3, "500.00", start.AddDate(0, 0, 1), "1450.00"))
+
+	// Test
+	page, err := repo.FindRunningBalancesPage(context.Background(), 1, &start, nil, PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("Error listing running balances: %v", err)
+	}
+
+	if len(page.Items) != 2 || page.NextCursor == "" {
+		t.Fatalf("Expected a full page with a next cursor, got %d items", len(page.Items))
+	}
+	if page.Items[1].TransactionID != 11 || page.Items[1].RunningBalance != money.MustParse("950") {
+		t.Errorf("Unexpected row %+v", page.Items[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindRunningBalancesPage_Emulated(t *testing.T) {
+	// Setup
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer mockDB.Close()
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: mockDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm connection: %v", err)
+	}
+
+	repo := NewTransactionRepository(gormDB)
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	after := cursor{Keys: "transaction_date ASC,transaction_id ASC", Date: &day, ID: 11}
+
+	// Expectations; the page is added up from the sum of every row before its first one
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `transactions` WHERE account_id = ? AND "+
+		"(transaction_date > ? OR (transaction_date = ? AND transaction_id > ?)) ORDER BY transaction_date ASC,transaction_id ASC LIMIT ?")).
+		WithArgs(uint(1), day, day, uint64(11), 3).
+		WillReturnRows(sqlmock.NewRows(transactionBalanceColumns[:5]).
+			AddRow(12, 1, 3, "500.00", day.AddDate(0, 0, 1)).
+			AddRow(14, 1, 2, "-0.10", day.AddDate(0, 0, 3)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(amount), 0) FROM `transactions` WHERE account_id = ? AND "+
+		"(transaction_date < ? OR (transaction_date = ? AND transaction_id < ?))")).
+		WithArgs(uint(1), day.AddDate(0, 0, 1), day.AddDate(0, 0, 1), uint(12)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("950.00"))
+
+	// Test
+	page, err := repo.FindRunningBalancesPage(context.Background(), 1, nil, nil, PageRequest{Limit: 2, Cursor: after.encode()})
+	if err != nil {
+		t.Fatalf("Error listing running balances: %v", err)
+	}
+
+	if len(page.Items) != 2 || page.NextCursor != "" {
+		t.Fatalf("Expected the last page with 2 items, got %d items", len(page.Items))
+	}
+	if page.Items[0].RunningBalance != money.MustParse("1450") || page.Items[1].RunningBalance != money.MustParse("1449.90") {
+		t.Errorf("Unexpected balances %s, %s", page.Items[0].RunningBalance, page.Items[1].RunningBalance)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_DailyBalances(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	start := time.Date(2021, 6, 28, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 7, 2, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1 AND transaction_date < $2`)).
+		WithArgs(uint(1), start).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("100.00"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transaction_date, SUM(amount) AS total FROM "transactions" `+
+		`WHERE account_id = $1 AND transaction_date BETWEEN $2 AND $3 GROUP BY "transaction_date"`)).
+		WithArgs(uint(1), start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_date", "total"}).
+			AddRow(time.Date(2021, 6, 29, 0, 0, 0, 0, time.UTC), "-40.00").
+			AddRow("2021-07-01", "250.50"))
+
+	// Test
+	series, err := repo.DailyBalances(context.Background(), 1, start, end)
+	if err != nil {
+		t.Fatalf("Error calculating daily balances: %v", err)
+	}
+
+	want := []string{"100.00", "60.00", "60.00", "310.50", "310.50"}
+	if len(series) != len(want) {
+		t.Fatalf("Expected %d days, got %d", len(want), len(series))
+	}
+	for i, balance := range want {
+		if !series[i].Date.Equal(start.AddDate(0, 0, i)) || series[i].Balance.String() != balance {
+			t.Errorf("Day %d: expected %s on %s, got %+v", i, balance, start.AddDate(0, 0, i).Format(time.DateOnly), series[i])
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_DailyBalances_InvalidRange(t *testing.T) {
+	// Setup
+	_, _, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := repo.DailyBalances(context.Background(), 1, start, start.AddDate(0, 0, -1)); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for a reversed range, got %v", err)
+	}
+	if _, err := repo.DailyBalances(context.Background(), 1, start, start.AddDate(0, 0, MaxDailyBalanceDays)); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for a range over %d days, got %v", MaxDailyBalanceDays, err)
+	}
+}
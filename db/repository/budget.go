@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+)
+
+// BudgetRepository stores budgets; like categories they are shared by every account scope
+type BudgetRepository struct {
+	*BaseRepository[entity.Budget]
+}
+
+func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
+	return &BudgetRepository{
+		BaseRepository: &BaseRepository[entity.Budget]{DB: db},
+	}
+}
+
+// FindByCategoryIDs retrieves the budgets of the categories with their category, ordered by ID.
+// Without category IDs every budget is returned.
+func (r *BudgetRepository) FindByCategoryIDs(ctx context.Context, categoryIDs []uint) ([]entity.Budget, error) {
+	query := r.query(ctx).Preload("Category").Order("budget_id")
+	if len(categoryIDs) > 0 {
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+	var budgets []entity.Budget
+	if err := query.Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+// FindByCategoryAndPeriod retrieves the budget of a category for a period, which is unique
+func (r *BudgetRepository) FindByCategoryAndPeriod(ctx context.Context, categoryID uint, period string) (*entity.Budget, error) {
+	var budget entity.Budget
+	if err := r.query(ctx).Where("category_id = ? AND period = ?", categoryID, period).First(&budget).Error; err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
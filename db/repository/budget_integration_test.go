@@ -0,0 +1,73 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// TestBudgetRepository_RoundTrip creates a budget for a fresh category, reads it back and sums the spending
+// of the category per period like the budget status does
+func TestBudgetRepository_RoundTrip(t *testing.T) {
+	budgets := repository.NewBudgetRepository(TestDB)
+	transactions := repository.NewTransactionRepository(TestDB)
+	ctx := context.Background()
+
+	category := createTestCategory(t, "Budgeted", "Expense")
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	budget := &entity.Budget{
+		CategoryID: category.CategoryID,
+		Period:     "month",
+		Amount:     money.MustParse("300"),
+		Rollover:   true,
+		StartDate:  start,
+	}
+	require.NoError(t, budgets.Create(ctx, budget))
+	t.Cleanup(func() { TestDB.Delete(budget) })
+
+	// A second budget of the same category and period violates the unique constraint
+	duplicate := *budget
+	duplicate.BudgetID = 0
+	assert.Error(t, budgets.Create(ctx, &duplicate))
+
+	for i, amount := range []string{"-120.50", "-80.25", "15.00"} {
+		transaction := &entity.Transaction{
+			AccountID:       1,
+			CategoryID:      category.CategoryID,
+			Amount:          money.MustParse(amount),
+			TransactionDate: start.AddDate(0, 0, i*10),
+		}
+		require.NoError(t, transactions.Create(ctx, transaction))
+		t.Cleanup(func() { TestDB.Delete(transaction) })
+	}
+
+	found, err := budgets.FindByCategoryIDs(ctx, []uint{category.CategoryID})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.True(t, found[0].Rollover)
+	assert.Equal(t, money.MustParse("300"), found[0].Amount)
+	assert.Equal(t, start, found[0].StartDate.UTC())
+	require.NotNil(t, found[0].Category)
+	assert.Equal(t, category.Name, found[0].Category.Name)
+
+	byPeriod, err := budgets.FindByCategoryAndPeriod(ctx, category.CategoryID, "month")
+	require.NoError(t, err)
+	assert.Equal(t, budget.BudgetID, byPeriod.BudgetID)
+
+	end := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	totals, err := transactions.NetByCategory(ctx, repository.TransactionFilter{
+		CategoryIDs: []uint{category.CategoryID},
+		StartDate:   &start,
+		EndDate:     &end,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]money.Amount{category.CategoryID: money.MustParse("-200.75")}, totals)
+}
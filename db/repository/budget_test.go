@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func TestBudgetRepository_Create(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewBudgetRepository(gormDB)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget := &entity.Budget{CategoryID: 3, Period: "month", Amount: money.MustParse("250"), StartDate: start}
+
+	// Expectations; rollover defaults to false in the table
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "budgets" ("category_id","period","amount","rollover","start_date") VALUES ($1,$2,$3,$4,$5) RETURNING "created_at","updated_at","budget_id"`)).
+		WithArgs(3, "month", "250.00", false, start).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "budget_id"}).AddRow(time.Now(), time.Now(), 1))
+	mock.ExpectCommit()
+
+	// Test
+	if err := repo.Create(context.Background(), budget); err != nil {
+		t.Errorf("Error creating budget: %v", err)
+	}
+	if budget.BudgetID != 1 {
+		t.Errorf("Expected budget ID 1, got %d", budget.BudgetID)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestBudgetRepository_FindByCategoryIDs(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewBudgetRepository(gormDB)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "budgets" WHERE category_id IN ($1,$2) ORDER BY budget_id`)).
+		WithArgs(3, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"budget_id", "category_id", "period", "amount", "rollover"}).
+			AddRow(1, 3, "month", "250.00", true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).AddRow(3, "Groceries", "Expense"))
+
+	// Test
+	budgets, err := repo.FindByCategoryIDs(context.Background(), []uint{3, 5})
+	if err != nil {
+		t.Fatalf("Error finding budgets: %v", err)
+	}
+
+	if len(budgets) != 1 || !budgets[0].Rollover || budgets[0].Category == nil || budgets[0].Category.Name != "Groceries" {
+		t.Errorf("Unexpected budgets %+v", budgets)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestBudgetRepository_FindByCategoryAndPeriod_NotFound(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewBudgetRepository(gormDB)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "budgets" WHERE category_id = $1 AND period = $2 ORDER BY "budgets"."budget_id" LIMIT $3`)).
+		WithArgs(3, "year", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"budget_id"}))
+
+	// Test
+	_, err := repo.FindByCategoryAndPeriod(context.Background(), 3, "year")
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected gorm.ErrRecordNotFound, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/repository/plain"
+	"sample-mcp/pkg/money"
+)
+
+// MaxCashFlowBuckets caps the rows of a cash flow report; longer reports need a narrower date range or a longer interval
+const MaxCashFlowBuckets = 1000
+
+// CashFlowInterval is the length of the periods of a cash flow report
+type CashFlowInterval string
+
+const (
+	IntervalDay CashFlowInterval = "day"
+	// IntervalWeek periods are ISO weeks, starting on Monday
+	IntervalWeek    CashFlowInterval = "week"
+	IntervalMonth   CashFlowInterval = "month"
+	IntervalQuarter CashFlowInterval = "quarter"
+	IntervalYear    CashFlowInterval = "year"
+)
+
+// CashFlowGrouping splits each period of a cash flow report
+type CashFlowGrouping string
+
+const (
+	GroupByNone     CashFlowGrouping = ""
+	GroupByCategory CashFlowGrouping = "category"
+	GroupByAccount  CashFlowGrouping = "account"
+)
+
+// CashFlow sums the income, expenses and net amount of the transactions matching the filter per period,
+// and per category or account when grouped, ordered by period. The filter's sort is ignored.
+func (r *TransactionRepository) CashFlow(
+	ctx context.Context,
+	filter TransactionFilter,
+	interval CashFlowInterval,
+	groupBy CashFlowGrouping,
+) ([]plain.CashFlow, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	period, err := truncateDate(r.DB, interval, "transactions.transaction_date")
+	if err != nil {
+		return nil, err
+	}
+
+	query := filter.apply(r.query(ctx).Table("transactions"))
+	columns := []string{period + " AS period_start"}
+	groups := []string{period}
+	switch groupBy {
+	case GroupByNone:
+	case GroupByCategory:
+		query = query.Joins("JOIN categories ON transactions.category_id = categories.category_id")
+		columns = append(columns, "categories.category_id AS group_id", "categories.name AS group_name")
+		groups = append(groups, "categories.category_id", "categories.name")
+	case GroupByAccount:
+		query = query.Joins("JOIN accounts ON transactions.account_id = accounts.account_id")
+		columns = append(columns, "accounts.account_id AS group_id", "accounts.name AS group_name")
+		groups = append(groups, "accounts.account_id", "accounts.name")
+	default:
+		return nil, fmt.Errorf("%w: cannot group by %q", ErrInvalidFilter, groupBy)
+	}
+	columns = append(columns,
+		"SUM(CASE WHEN transactions.amount > 0 THEN transactions.amount ELSE 0 END) AS income",
+		"SUM(CASE WHEN transactions.amount < 0 THEN transactions.amount ELSE 0 END) AS expenses",
+		"SUM(transactions.amount) AS net",
+		"COUNT(transactions.transaction_id) AS count")
+
+	// SQL Server cannot group by an alias, so the expressions are repeated
+	query = query.Select(strings.Join(columns, ", ")).Group(strings.Join(groups, ", ")).Order("period_start")
+	if groupBy != GroupByNone {
+		query = query.Order("group_id")
+	}
+
+	var rows []cashFlowRow
+	if err := query.Limit(MaxCashFlowBuckets + 1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) > MaxCashFlowBuckets {
+		return nil, fmt.Errorf("%w: the report has more than %d rows, narrow the date range or use a longer interval",
+			ErrInvalidFilter, MaxCashFlowBuckets)
+	}
+
+	result := make([]plain.CashFlow, len(rows))
+	for i, row := range rows {
+		result[i] = plain.CashFlow{
+			PeriodStart: row.PeriodStart.Time,
+			GroupID:     row.GroupID,
+			GroupName:   row.GroupName,
+			Income:      row.Income,
+			Expenses:    row.Expenses,
+			Net:         row.Net,
+			Count:       row.Count,
+		}
+	}
+	return result, nil
+}
+
+// cashFlowRow is a row of the cash flow query, whose computed period start needs a lenient scanner
+type cashFlowRow struct {
+	PeriodStart scannedDate
+	GroupID     uint
+	GroupName   string
+	Income      money.Amount
+	Expenses    money.Amount
+	Net         money.Amount
+	Count       int64
+}
+
+// scannedDate reads a date computed in SQL. Most drivers return a time, but SQLite has no declared type
+// for expressions and returns the YYYY-MM-DD text of its date functions.
+type scannedDate struct {
+	time.Time
+}
+
+func (d *scannedDate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		d.Time = v
+		return nil
+	case []byte:
+		return d.parse(string(v))
+	case string:
+		return d.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a date", src)
+	}
+}
+
+// Value lets gorm accept the type as a column; the date is only ever read
+func (d scannedDate) Value() (driver.Value, error) {
+	return d.Time, nil
+}
+
+func (d *scannedDate) parse(s string) error {
+	if len(s) < len(time.DateOnly) {
+		return fmt.Errorf("cannot scan %q into a date", s)
+	}
+	t, err := time.Parse(time.DateOnly, s[:len(time.DateOnly)])
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into a date", s)
+	}
+	d.Time = t
+	return nil
+}
+
+// truncateDate returns an expression truncating the date column to the first day of its period
+// in the dialect of db. Weeks start on Monday in every dialect, regardless of server settings.
+func truncateDate(db *gorm.DB, interval CashFlowInterval, column string) (string, error) {
+	switch interval {
+	case IntervalDay, IntervalWeek, IntervalMonth, IntervalQuarter, IntervalYear:
+	default:
+		return "", fmt.Errorf("%w: unknown interval %q", ErrInvalidFilter, interval)
+	}
+	if interval == IntervalDay {
+		return column, nil
+	}
+
+	switch name := db.Dialector.Name(); name {
+	case "postgres":
+		// DATE_TRUNC on a date would convert it to a timestamp in the session time zone
+		return fmt.Sprintf("CAST(DATE_TRUNC('%s', CAST(%s AS TIMESTAMP)) AS DATE)", interval, column), nil
+	case "mysql":
+		switch interval {
+		case IntervalWeek:
+			return fmt.Sprintf("DATE_SUB(%[1]s, INTERVAL WEEKDAY(%[1]s) DAY)", column), nil
+		case IntervalMonth:
+			return fmt.Sprintf("DATE_SUB(%[1]s, INTERVAL (DAYOFMONTH(%[1]s) - 1) DAY)", column), nil
+		case IntervalQuarter:
+			return fmt.Sprintf("MAKEDATE(YEAR(%[1]s), 1) + INTERVAL (QUARTER(%[1]s) - 1) QUARTER", column), nil
+		default:
+			return fmt.Sprintf("MAKEDATE(YEAR(%s), 1)", column), nil
+		}
+	case "sqlserver":
+		switch interval {
+		case IntervalWeek:
+			// (weekday + @@DATEFIRST - 2) % 7 is the number of days since Monday for any DATEFIRST setting
+			return fmt.Sprintf("DATEADD(DAY, -((DATEPART(WEEKDAY, %[1]s) + @@DATEFIRST - 2) %% 7), %[1]s)", column), nil
+		case IntervalMonth:
+			return fmt.Sprintf("DATEFROMPARTS(YEAR(%[1]s), MONTH(%[1]s), 1)", column), nil
+		case IntervalQuarter:
+			return fmt.Sprintf("DATEFROMPARTS(YEAR(%[1]s), (DATEPART(QUARTER, %[1]s) - 1) * 3 + 1, 1)", column), nil
+		default:
+			return fmt.Sprintf("DATEFROMPARTS(YEAR(%s), 1, 1)", column), nil
+		}
+	case "sqlite":
+		switch interval {
+		case IntervalWeek:
+			// Moving to the next Sunday, or staying on a Sunday, and back six days lands on Monday
+			return fmt.Sprintf("DATE(%s, 'weekday 0', '-6 days')", column), nil
+		case IntervalMonth:
+			return fmt.Sprintf("DATE(%s, 'start of month')", column), nil
+		case IntervalQuarter:
+			return fmt.Sprintf("DATE(%[1]s, 'start of month', '-' || ((CAST(STRFTIME('%%m', %[1]s) AS INTEGER) - 1) %% 3) || ' months')", column), nil
+		default:
+			return fmt.Sprintf("DATE(%s, 'start of year')", column), nil
+		}
+	default:
+		return "", fmt.Errorf("date truncation is not supported for %s", name)
+	}
+}
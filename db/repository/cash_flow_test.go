@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+	"sample-mcp/pkg/money"
+)
+
+var cashFlowColumns = []string{"period_start", "group_id", "group_name", "income", "expenses", "net", "count"}
+
+func TestTransactionRepository_CashFlow(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	period := `CAST(DATE_TRUNC('month', CAST(transactions.transaction_date AS TIMESTAMP)) AS DATE)`
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT `+period+` AS period_start, categories.category_id AS group_id, categories.name AS group_name, `+
+		`SUM(CASE WHEN transactions.amount > 0 THEN transactions.amount ELSE 0 END) AS income, `+
+		`SUM(CASE WHEN transactions.amount < 0 THEN transactions.amount ELSE 0 END) AS expenses, `+
+		`SUM(transactions.amount) AS net, COUNT(transactions.transaction_id) AS count `+
+		`FROM "transactions" JOIN categories ON transactions.category_id = categories.category_id `+
+		`WHERE transactions.account_id IN ($1) AND transactions.transaction_date >= $2 AND transactions.transaction_date <= $3 `+
+		`GROUP BY `+period+`, categories.category_id, categories.name ORDER BY period_start,group_id LIMIT $4`)).
+		WithArgs(uint(4), start, end, MaxCashFlowBuckets+1).
+		WillReturnRows(sqlmock.NewRows(cashFlowColumns).
+			AddRow(start, 3, "Groceries", "0", "-120.40", "-120.40", 4).
+			AddRow(start, 9, "Salary", "3000.00", "0", "3000.00", 1))
+
+	// Test
+	flows, err := repo.CashFlow(context.Background(),
+		TransactionFilter{AccountIDs: []uint{4}, StartDate: &start, EndDate: &end}, IntervalMonth, GroupByCategory)
+	if err != nil {
+		t.Fatalf("Error building cash flow: %v", err)
+	}
+
+	if len(flows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(flows))
+	}
+	if flows[0].GroupName != "Groceries" || flows[0].Expenses != money.MustParse("-120.40") || flows[0].Count != 4 {
+		t.Errorf("Unexpected row %+v", flows[0])
+	}
+	if !flows[1].PeriodStart.Equal(start) || flows[1].Net != money.MustParse("3000") {
+		t.Errorf("Unexpected row %+v", flows[1])
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_CashFlow_TextDates(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+
+	// Expectations; SQLite returns computed dates as text
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transactions.transaction_date AS period_start, SUM(`)).
+		WillReturnRows(sqlmock.NewRows([]string{"period_start", "income", "expenses", "net", "count"}).
+			AddRow("2023-02-06", "10.00", "-2.50", "7.50", 2).
+			AddRow([]byte("2023-02-07 00:00:00+00:00"), "0", "-1.00", "-1.00", 1))
+
+	// Test
+	flows, err := repo.CashFlow(context.Background(), TransactionFilter{}, IntervalDay, GroupByNone)
+	if err != nil {
+		t.Fatalf("Error building cash flow: %v", err)
+	}
+
+	if len(flows) != 2 || !flows[1].PeriodStart.Equal(time.Date(2023, 2, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected rows %+v", flows)
+	}
+}
+
+func TestTransactionRepository_CashFlow_TooManyBuckets(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	rows := sqlmock.NewRows([]string{"period_start", "net", "count"})
+	for i := 0; i <= MaxCashFlowBuckets; i++ {
+		rows.AddRow(time.Date(2020, 1, 1+i, 0, 0, 0, 0, time.UTC), "1.00", 1)
+	}
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transactions.transaction_date AS period_start`)).WillReturnRows(rows)
+
+	// Test
+	_, err := repo.CashFlow(context.Background(), TransactionFilter{}, IntervalDay, GroupByNone)
+	if !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", err)
+	}
+}
+
+func TestTransactionRepository_CashFlow_Invalid(t *testing.T) {
+	// Setup
+	_, _, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := context.Background()
+
+	if _, err := repo.CashFlow(ctx, TransactionFilter{}, "fortnight", GroupByNone); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for an unknown interval, got %v", err)
+	}
+	if _, err := repo.CashFlow(ctx, TransactionFilter{}, IntervalMonth, "merchant"); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for an unknown grouping, got %v", err)
+	}
+}
+
+func TestTruncateDate_Dialects(t *testing.T) {
+	dialectors := map[string]func(conn gorm.ConnPool) gorm.Dialector{
+		"postgres": func(conn gorm.ConnPool) gorm.Dialector {
+			return postgres.New(postgres.Config{Conn: conn})
+		},
+		"mysql": func(conn gorm.ConnPool) gorm.Dialector {
+			return mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+		},
+		"sqlserver": func(conn gorm.ConnPool) gorm.Dialector {
+			return sqlserver.New(sqlserver.Config{Conn: conn})
+		},
+	}
+	tests := []struct {
+		dialect  string
+		interval CashFlowInterval
+		want     string
+	}{
+		{dialect: "postgres", interval: IntervalDay, want: "d"},
+		{dialect: "postgres", interval: IntervalWeek, want: "CAST(DATE_TRUNC('week', CAST(d AS TIMESTAMP)) AS DATE)"},
+		{dialect: "postgres", interval: IntervalQuarter, want: "CAST(DATE_TRUNC('quarter', CAST(d AS TIMESTAMP)) AS DATE)"},
+		{dialect: "mysql", interval: IntervalWeek, want: "DATE_SUB(d, INTERVAL WEEKDAY(d) DAY)"},
+		{dialect: "mysql", interval: IntervalMonth, want: "DATE_SUB(d, INTERVAL (DAYOFMONTH(d) - 1) DAY)"},
+		{dialect: "mysql", interval: IntervalQuarter, want: "MAKEDATE(YEAR(d), 1) + INTERVAL (QUARTER(d) - 1) QUARTER"},
+		{dialect: "mysql", interval: IntervalYear, want: "MAKEDATE(YEAR(d), 1)"},
+		{dialect: "sqlserver", interval: IntervalWeek, want: "DATEADD(DAY, -((DATEPART(WEEKDAY, d) + @@DATEFIRST - 2) % 7), d)"},
+		{dialect: "sqlserver", interval: IntervalMonth, want: "DATEFROMPARTS(YEAR(d), MONTH(d), 1)"},
+		{dialect: "sqlserver", interval: IntervalQuarter, want: "DATEFROMPARTS(YEAR(d), (DATEPART(QUARTER, d) - 1) * 3 + 1, 1)"},
+		{dialect: "sqlserver", interval: IntervalYear, want: "DATEFROMPARTS(YEAR(d), 1, 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+"/"+string(tt.interval), func(t *testing.T) {
+			mockDB, _, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer mockDB.Close()
+			gormDB, err := gorm.Open(dialectors[tt.dialect](mockDB), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("Failed to open gorm connection: %v", err)
+			}
+
+			got, err := truncateDate(gormDB, tt.interval, "d")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
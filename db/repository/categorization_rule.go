@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+)
+
+// CategorizationRuleRepository stores categorization rules. Rules of an account are restricted like transactions
+// to the accounts of the caller's scope; rules of every account are visible to every scope.
+type CategorizationRuleRepository struct {
+	*BaseRepository[entity.CategorizationRule]
+}
+
+func NewCategorizationRuleRepository(db *gorm.DB) *CategorizationRuleRepository {
+	return &CategorizationRuleRepository{
+		BaseRepository: &BaseRepository[entity.CategorizationRule]{DB: db, Scope: categorizationRuleScope{}},
+	}
+}
+
+// FindAllByPriority retrieves the rules with their category in the order they are tried:
+// by descending priority, then by ID
+func (r *CategorizationRuleRepository) FindAllByPriority(ctx context.Context) ([]entity.CategorizationRule, error) {
+	var rules []entity.CategorizationRule
+	if err := r.query(ctx).Preload("Category").Order("priority DESC, rule_id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/db/entity"
+)
+
+func TestCategorizationRuleRepository_FindAllByPriority(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewCategorizationRuleRepository(gormDB)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules" ORDER BY priority DESC, rule_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "name", "priority", "category_id", "counterparty"}).
+			AddRow(2, "Large streaming", 5, 13, "Netflix").
+			AddRow(1, "Streaming", 0, 10, "Netflix"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" IN ($1,$2)`)).
+		WithArgs(13, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(10, "Streaming").AddRow(13, "Entertainment"))
+
+	// Test
+	rules, err := repo.FindAllByPriority(context.Background())
+	if err != nil {
+		t.Fatalf("Error finding categorization rules: %v", err)
+	}
+	if len(rules) != 2 || rules[0].RuleID != 2 || rules[0].Category == nil || rules[0].Category.Name != "Entertainment" {
+		t.Errorf("Unexpected rules %+v", rules)
+	}
+	if rules[1].Counterparty == nil || *rules[1].Counterparty != "Netflix" || rules[1].AccountID != nil {
+		t.Errorf("Unexpected rule %+v", rules[1])
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCategorizationRuleRepository_FindAllByPriority_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewCategorizationRuleRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}, AccountTypes: []string{"Savings"}})
+
+	// Expectations: the rules of every account and those of the granted accounts
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules" WHERE categorization_rules.account_id IS NULL OR `+
+		`(categorization_rules.account_id IN ($1) OR categorization_rules.account_id IN (SELECT "account_id" FROM "accounts" WHERE account_type IN ($2))) `+
+		`ORDER BY priority DESC, rule_id`)).
+		WithArgs(1, "Savings").
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id"}))
+
+	// Test
+	rules, err := repo.FindAllByPriority(ctx)
+	if err != nil {
+		t.Fatalf("Error finding categorization rules: %v", err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("Expected no rules, got %+v", rules)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCategorizationRuleRepository_FindByID_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewCategorizationRuleRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations: the scope's conditions stay apart from the query's own
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules" WHERE (categorization_rules.account_id IS NULL OR `+
+		`categorization_rules.account_id IN ($1)) AND "categorization_rules"."rule_id" = $2 ORDER BY "categorization_rules"."rule_id" LIMIT $3`)).
+		WithArgs(1, 7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "account_id"}).AddRow(7, 1))
+
+	// Test
+	rule, err := repo.FindByID(ctx, 7)
+	if err != nil {
+		t.Fatalf("Error finding categorization rule: %v", err)
+	}
+	if rule.AccountID == nil || *rule.AccountID != 1 {
+		t.Errorf("Unexpected rule %+v", rule)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestCategorizationRuleRepository_Create_OutOfScope verifies that a caller restricted to a scope cannot create
+// a rule of every account, which would categorize the transactions of accounts outside the scope
+func TestCategorizationRuleRepository_Create_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewCategorizationRuleRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+	counterparty := "Netflix"
+
+	// Test
+	err := repo.Create(ctx, &entity.CategorizationRule{Name: "Streaming", CategoryID: 10, Counterparty: &counterparty})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
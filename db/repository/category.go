@@ -16,6 +16,14 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 	}
 }
 
+func (r *CategoryRepository) FindByName(ctx context.Context, name string) (*entity.Category, error) {
+	var category entity.Category
+	if err := r.DB.WithContext(ctx).Where("name = ?", name).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
 func (r *CategoryRepository) FindByType(ctx context.Context, categoryType string) ([]entity.Category, error) {
 	var categories []entity.Category
 	if err := r.DB.WithContext(ctx).Where("category_type = ?", categoryType).Find(&categories).Error; err != nil {
@@ -26,9 +34,27 @@ func (r *CategoryRepository) FindByType(ctx context.Context, categoryType string
 func (r *CategoryRepository) FindByNameLike(ctx context.Context, keyword string) ([]entity.Category, error) {
 	var categories []entity.Category
 	if err := r.DB.WithContext(ctx).
-		Where("name ILIKE ?", "%"+keyword+"%").
+		Where(containsCondition(r.DB, "name"), containsPattern(keyword)).
 		Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
 }
+
+// FindByTypePage retrieves a page of the categories of a type, ordered by ID
+func (r *CategoryRepository) FindByTypePage(ctx context.Context, categoryType string, page PageRequest) (*Page[entity.Category], error) {
+	keys, err := primaryKeyset[entity.Category](r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(r.query(ctx).Where("category_type = ?", categoryType), page, keys)
+}
+
+// FindByNameLikePage retrieves a page of the categories whose names contain the keyword, ordered by ID
+func (r *CategoryRepository) FindByNameLikePage(ctx context.Context, keyword string, page PageRequest) (*Page[entity.Category], error) {
+	keys, err := primaryKeyset[entity.Category](r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(r.query(ctx).Where(containsCondition(r.DB, "name"), containsPattern(keyword)), page, keys)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"sample-mcp/db/repository/plain"
+	"sample-mcp/pkg/money"
+)
+
+// CategoryBreakdown sums the transactions matching the filter per category, with their count, average, smallest
+// and largest amount, on an income side for positive amounts and an expense side for the others.
+// A category with both is on both sides. Each category's percentage is its share of the total of its side.
+// The filter's sort is ignored.
+func (r *TransactionRepository) CategoryBreakdown(ctx context.Context, filter TransactionFilter) (*plain.CategoryBreakdown, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+
+	// SQL Server cannot group by an alias, so the side expression is repeated
+	const side = "CASE WHEN transactions.amount > 0 THEN 1 ELSE 0 END"
+	var rows []categoryStatsRow
+	err := filter.apply(r.query(ctx).Table("transactions")).
+		Select("categories.category_id, categories.name AS category_name, categories.category_type, " +
+			side + " AS income, SUM(transactions.amount) AS total, COUNT(transactions.transaction_id) AS count, " +
+			"MIN(transactions.amount) AS min_amount, MAX(transactions.amount) AS max_amount").
+		Joins("JOIN categories ON transactions.category_id = categories.category_id").
+		Group("categories.category_id, categories.name, categories.category_type, " + side).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &plain.CategoryBreakdown{Income: []plain.CategoryStats{}, Expenses: []plain.CategoryStats{}}
+	for _, row := range rows {
+		stats := plain.CategoryStats{
+			CategoryID:   row.CategoryID,
+			CategoryName: row.CategoryName,
+			CategoryType: row.CategoryType,
+			Total:        row.Total,
+			Count:        row.Count,
+			Min:          row.MinAmount,
+			Max:          row.MaxAmount,
+		}
+		if row.Count > 0 {
+			average, err := money.Round(row.Total.Decimal().Div(decimal.NewFromInt(row.Count)))
+			if err != nil {
+				return nil, err
+			}
+			stats.Average = average
+		}
+
+		if row.Income == 1 {
+			breakdown.Income = append(breakdown.Income, stats)
+			breakdown.TotalIncome += stats.Total
+		} else {
+			breakdown.Expenses = append(breakdown.Expenses, stats)
+			breakdown.TotalExpenses += stats.Total
+		}
+	}
+	breakdown.Net = breakdown.TotalIncome + breakdown.TotalExpenses
+
+	shareOf(breakdown.Income, breakdown.TotalIncome)
+	shareOf(breakdown.Expenses, breakdown.TotalExpenses)
+	return breakdown, nil
+}
+
+// GroupByCategory sums the transactions of an account per category, adding up both sides of the category
+// breakdown, largest total first
+func (r *TransactionRepository) GroupByCategory(ctx context.Context, accountID uint) ([]plain.TransactionSummary, error) {
+	breakdown, err := r.CategoryBreakdown(ctx, TransactionFilter{AccountIDs: []uint{accountID}})
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []plain.TransactionSummary
+	index := make(map[uint]int)
+	for _, stats := range append(breakdown.Expenses, breakdown.Income...) {
+		i, ok := index[stats.CategoryID]
+		if !ok {
+			i = len(summaries)
+			index[stats.CategoryID] = i
+			summaries = append(summaries, plain.TransactionSummary{CategoryName: stats.CategoryName})
+		}
+		summaries[i].TotalAmount += stats.Total
+		summaries[i].Count += stats.Count
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].TotalAmount.Abs() > summaries[j].TotalAmount.Abs()
+	})
+	return summaries, nil
+}
+
+// categoryStatsRow is a row of the category breakdown query; MIN and MAX are aliased as they are keywords.
+// Income is 1 for the positive amounts of the category, as not every database has a boolean type.
+type categoryStatsRow struct {
+	CategoryID   uint
+	CategoryName string
+	CategoryType string
+	Income       int
+	Total        money.Amount
+	Count        int64
+	MinAmount    money.Amount
+	MaxAmount    money.Amount
+}
+
+// shareOf sets the percentage of each category in the total of its side, rounded to two decimals,
+// and orders the categories by the size of their totals, largest first
+func shareOf(categories []plain.CategoryStats, total money.Amount) {
+	for i := range categories {
+		if total != 0 {
+			categories[i].Percentage = categories[i].Total.Decimal().
+				Mul(decimal.NewFromInt(100)).
+				Div(total.Decimal()).
+				Round(2).
+				InexactFloat64()
+		}
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		a, b := categories[i].Total.Abs(), categories[j].Total.Abs()
+		if a != b {
+			return a > b
+		}
+		return categories[i].CategoryID < categories[j].CategoryID
+	})
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/pkg/money"
+)
+
+var categoryStatsColumns = []string{"category_id", "category_name", "category_type", "income", "total", "count", "min_amount", "max_amount"}
+
+func TestTransactionRepository_CategoryBreakdown(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT categories.category_id, categories.name AS category_name, categories.category_type, `+
+		`CASE WHEN transactions.amount > 0 THEN 1 ELSE 0 END AS income, SUM(transactions.amount) AS total, COUNT(transactions.transaction_id) AS count, `+
+		`MIN(transactions.amount) AS min_amount, MAX(transactions.amount) AS max_amount `+
+		`FROM "transactions" JOIN categories ON transactions.category_id = categories.category_id `+
+		`WHERE transactions.account_id IN ($1,$2) AND transactions.transaction_date >= $3 AND transactions.transaction_date <= $4 `+
+		`GROUP BY categories.category_id, categories.name, categories.category_type, `+
+		`CASE WHEN transactions.amount > 0 THEN 1 ELSE 0 END`)).
+		WithArgs(uint(1), uint(4), start, end).
+		WillReturnRows(sqlmock.NewRows(categoryStatsColumns).
+			AddRow(3, "Groceries", "Expense", 0, "-100.00", 3, "-60.00", "-10.00").
+			AddRow(9, "Salary", "Income", 1, "3000.00", 1, "3000.00", "3000.00").
+			AddRow(5, "Rent", "Expense", 0, "-300.00", 1, "-300.00", "-300.00"))
+
+	// Test
+	breakdown, err := repo.CategoryBreakdown(context.Background(),
+		TransactionFilter{AccountIDs: []uint{1, 4}, StartDate: &start, EndDate: &end})
+	if err != nil {
+		t.Fatalf("Error building category breakdown: %v", err)
+	}
+
+	if len(breakdown.Income) != 1 || breakdown.Income[0].Percentage != 100 {
+		t.Errorf("Unexpected income %+v", breakdown.Income)
+	}
+	if len(breakdown.Expenses) != 2 {
+		t.Fatalf("Expected 2 expense categories, got %d", len(breakdown.Expenses))
+	}
+	rent, groceries := breakdown.Expenses[0], breakdown.Expenses[1]
+	if rent.CategoryName != "Rent" || rent.Percentage != 75 {
+		t.Errorf("Expected the largest expense first, got %+v", rent)
+	}
+	if groceries.Average != money.MustParse("-33.33") || groceries.Min != money.MustParse("-60") ||
+		groceries.Max != money.MustParse("-10") || groceries.Percentage != 25 {
+		t.Errorf("Unexpected groceries %+v", groceries)
+	}
+	if breakdown.TotalIncome != money.MustParse("3000") || breakdown.TotalExpenses != money.MustParse("-400") ||
+		breakdown.Net != money.MustParse("2600") {
+		t.Errorf("Unexpected totals %+v", breakdown)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_CategoryBreakdown_Empty(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT categories.category_id`)).
+		WillReturnRows(sqlmock.NewRows(categoryStatsColumns))
+
+	// Test
+	breakdown, err := repo.CategoryBreakdown(context.Background(), TransactionFilter{})
+	if err != nil {
+		t.Fatalf("Error building category breakdown: %v", err)
+	}
+	if breakdown.Income == nil || breakdown.Expenses == nil || breakdown.Net != 0 {
+		t.Errorf("Expected empty sides, got %+v", breakdown)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -312,7 +312,7 @@ func TestCategoryRepository_FindByNameLike(t *testing.T) {
 	keyword := "Food"
 
 	// Expectations
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name ILIKE $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name ILIKE $1 ESCAPE '!'`)).
 		WithArgs("%" + keyword + "%").
 		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type", "created_at", "updated_at"}).
 			AddRow(1, "Food", "Expense", time.Now(), time.Now()).
@@ -344,7 +344,7 @@ func TestCategoryRepository_FindByNameLike_NotFound(t *testing.T) {
 	keyword := "Nonexistent"
 
 	// Expectations
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name ILIKE $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name ILIKE $1 ESCAPE '!'`)).
 		WithArgs("%" + keyword + "%").
 		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type", "created_at", "updated_at"}))
 
@@ -374,7 +374,7 @@ func TestCategoryRepository_FindByNameLike_Error(t *testing.T) {
 	keyword := "Error"
 
 	// Expectations
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name ILIKE $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name ILIKE $1 ESCAPE '!'`)).
 		WithArgs("%" + keyword + "%").
 		WillReturnError(errors.New("database error"))
 
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+// rateDateLayout is the date format of the CSV files loaded by LoadCSV
+const rateDateLayout = "2006-01-02"
+
+// rateBatchSize keeps batched upserts well below the 2100 parameters SQL Server accepts per statement
+const rateBatchSize = 500
+
+// ExchangeRateRepository reads and loads the exchange_rates table.
+// Rates are shared reference data keyed by date and currency pair, so they are neither scoped nor
+// addressed by ID like the other repositories.
+type ExchangeRateRepository struct {
+	DB *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{DB: db}
+}
+
+// FindByPair retrieves the rates between two currencies in either direction, ordered by date
+func (r *ExchangeRateRepository) FindByPair(ctx context.Context, currency, other string) ([]entity.ExchangeRate, error) {
+	var rates []entity.ExchangeRate
+	if err := r.DB.WithContext(ctx).
+		Where("(base_currency = ? AND quote_currency = ?) OR (base_currency = ? AND quote_currency = ?)",
+			currency, other, other, currency).
+		Order("rate_date").
+		Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Upsert inserts the rates, replacing the rate of rows that already exist for the same date and pair
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rates []entity.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		CreateInBatches(rates, rateBatchSize).Error
+}
+
+// LoadCSV upserts the rates of a CSV file with the columns date, base, quote and rate, e.g.
+//
+//	date,base,quote,rate
+//	2024-01-02,EUR,USD,1.0956
+//
+// The header row is optional and lines starting with # are skipped. Either every row is loaded or none.
+// It returns the number of rates loaded.
+func (r *ExchangeRateRepository) LoadCSV(ctx context.Context, in io.Reader) (int, error) {
+	rates, err := parseRatesCSV(in)
+	if err != nil {
+		return 0, err
+	}
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return NewExchangeRateRepository(tx).Upsert(ctx, rates)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// parseRatesCSV reads the rates of a CSV file, see LoadCSV
+func parseRatesCSV(in io.Reader) ([]entity.ExchangeRate, error) {
+	reader := csv.NewReader(in)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	var rates []entity.ExchangeRate
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rates) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+
+		rate, err := parseRateRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate CSV: line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// parseRateRecord converts a date, base, quote, rate record into an ExchangeRate
+func parseRateRecord(record []string) (entity.ExchangeRate, error) {
+	date, err := time.Parse(rateDateLayout, strings.TrimSpace(record[0]))
+	if err != nil {
+		return entity.ExchangeRate{}, fmt.Errorf("date %q is not formatted as YYYY-MM-DD", record[0])
+	}
+	base, err := money.ParseCurrency(record[1])
+	if err != nil {
+		return entity.ExchangeRate{}, err
+	}
+	quote, err := money.ParseCurrency(record[2])
+	if err != nil {
+		return entity.ExchangeRate{}, err
+	}
+	if base == quote {
+		return entity.ExchangeRate{}, fmt.Errorf("base and quote are both %s", base)
+	}
+	rate, err := decimal.NewFromString(strings.TrimSpace(record[3]))
+	if err != nil || !rate.IsPositive() {
+		return entity.ExchangeRate{}, fmt.Errorf("rate %q is not a positive number", record[3])
+	}
+
+	return entity.ExchangeRate{RateDate: date, BaseCurrency: base, QuoteCurrency: quote, Rate: rate}, nil
+}
@@ -0,0 +1,39 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+)
+
+// TestExchangeRateRepository_LoadCSV loads the same date and pair twice and checks that the second load
+// replaces the rate instead of failing on the primary key
+func TestExchangeRateRepository_LoadCSV(t *testing.T) {
+	repo := repository.NewExchangeRateRepository(TestDB)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		TestDB.Where("base_currency = ?", "XTS").Delete(&entity.ExchangeRate{})
+	})
+
+	n, err := repo.LoadCSV(ctx, strings.NewReader("date,base,quote,rate\n2024-01-02,XTS,XXX,1.5\n2024-01-03,XTS,XXX,1.25\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = repo.LoadCSV(ctx, strings.NewReader("2024-01-03,XTS,XXX,1.2\n"))
+	require.NoError(t, err)
+
+	rates, err := repo.FindByPair(ctx, "XXX", "XTS")
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, 2024, rates[0].RateDate.Year())
+	assert.True(t, decimal.RequireFromString("1.5").Equal(rates[0].Rate), "got %s", rates[0].Rate)
+	assert.True(t, decimal.RequireFromString("1.2").Equal(rates[1].Rate), "got %s", rates[1].Rate)
+}
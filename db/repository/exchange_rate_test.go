@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sample-mcp/db/entity"
+)
+
+func TestParseRatesCSV(t *testing.T) {
+	in := `date,base,quote,rate
+# ECB reference rates
+2024-01-02,EUR,USD,1.0956
+2024-01-03, eur, gbp, 0.86518
+`
+	rates, err := parseRatesCSV(strings.NewReader(in))
+	require.NoError(t, err)
+	assert.Equal(t, []entity.ExchangeRate{
+		{RateDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: decimal.RequireFromString("1.0956")},
+		{RateDate: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), BaseCurrency: "EUR", QuoteCurrency: "GBP", Rate: decimal.RequireFromString("0.86518")},
+	}, rates)
+
+	rates, err = parseRatesCSV(strings.NewReader("2024-01-02,EUR,USD,1.0956\n"))
+	require.NoError(t, err, "the header is optional")
+	assert.Len(t, rates, 1)
+}
+
+func TestParseRatesCSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "date", in: "date,base,quote,rate\n02/01/2024,EUR,USD,1.09\n", want: `line 2: date "02/01/2024" is not formatted as YYYY-MM-DD`},
+		{name: "currency", in: "2024-01-02,EURO,USD,1.09\n", want: `line 1: invalid currency code "EURO"`},
+		{name: "same currency", in: "2024-01-02,EUR,EUR,1\n", want: "line 1: base and quote are both EUR"},
+		{name: "negative rate", in: "2024-01-02,EUR,USD,-1.09\n", want: `line 1: rate "-1.09" is not a positive number`},
+		{name: "zero rate", in: "2024-01-02,EUR,USD,0\n", want: `line 1: rate "0" is not a positive number`},
+		{name: "columns", in: "2024-01-02,EUR,USD\n", want: "wrong number of fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRatesCSV(strings.NewReader(tt.in))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestExchangeRateRepository_LoadCSV(t *testing.T) {
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewExchangeRateRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "exchange_rates" ("rate_date","base_currency","quote_currency","rate") VALUES ($1,$2,$3,$4),($5,$6,$7,$8) `+
+		`ON CONFLICT ("rate_date","base_currency","quote_currency") DO UPDATE SET "rate"="excluded"."rate"`)).
+		WithArgs(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "EUR", "USD", "1.0956",
+			time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), "EUR", "USD", "1.0919").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	n, err := repo.LoadCSV(context.Background(), strings.NewReader("2024-01-02,EUR,USD,1.0956\n2024-01-03,EUR,USD,1.0919\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateRepository_LoadCSV_InvalidRow(t *testing.T) {
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewExchangeRateRepository(gormDB)
+
+	// Nothing is written when any row is invalid
+	n, err := repo.LoadCSV(context.Background(), strings.NewReader("2024-01-02,EUR,USD,1.0956\n2024-01-03,EUR,USD,n/a\n"))
+	require.Error(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateRepository_FindByPair(t *testing.T) {
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewExchangeRateRepository(gormDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates" WHERE (base_currency = $1 AND quote_currency = $2) OR (base_currency = $3 AND quote_currency = $4) ORDER BY rate_date`)).
+		WithArgs("EUR", "USD", "USD", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base_currency", "quote_currency", "rate"}).
+			AddRow(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "EUR", "USD", "1.0956").
+			AddRow(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), "USD", "EUR", "0.9158"))
+
+	rates, err := repo.FindByPair(context.Background(), "EUR", "USD")
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "USD", rates[1].BaseCurrency)
+	assert.True(t, decimal.RequireFromString("0.9158").Equal(rates[1].Rate))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
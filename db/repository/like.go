@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// likeEscaper escapes the LIKE wildcards of a keyword with "!", which unlike a backslash needs no quoting
+// in any dialect. "[" is escaped as well since SQL Server treats it as the start of a character class.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "[", "![")
+
+// containsPattern returns a LIKE pattern matching values that contain the keyword literally
+func containsPattern(keyword string) string {
+	return "%" + likeEscaper.Replace(keyword) + "%"
+}
+
+// containsCondition returns a case-insensitive LIKE condition on column for the dialect of db,
+// taking a pattern built by containsPattern as its only argument
+func containsCondition(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "postgres" {
+		return column + " ILIKE ? ESCAPE '!'"
+	}
+	return "LOWER(" + column + ") LIKE LOWER(?) ESCAPE '!'"
+}
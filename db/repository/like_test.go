@@ -0,0 +1,121 @@
+// These tests verify that keyword searches escape LIKE wildcards and use the
+// case-insensitive matching supported by each dialect.
+
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+)
+
+func TestContainsPattern(t *testing.T) {
+	tests := map[string]string{
+		"rent":      "%rent%",
+		"50%":       "%50!%%",
+		"a_b":       "%a!_b%",
+		"[x]":       "%![x]%",
+		"wow!":      "%wow!!%",
+		"100% off!": "%100!% off!!%",
+	}
+
+	for keyword, want := range tests {
+		if got := containsPattern(keyword); got != want {
+			t.Errorf("containsPattern(%q) = %q, want %q", keyword, got, want)
+		}
+	}
+}
+
+func TestAccountRepository_FindByNameLike_EscapesWildcards(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewAccountRepository(gormDB)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name ILIKE $1 ESCAPE '!'`)).
+		WithArgs("%!_!%%").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type"}))
+
+	// Test
+	accounts, err := repo.FindByNameLike(context.Background(), "_%")
+	if err != nil {
+		t.Errorf("Error finding accounts by name: %v", err)
+	}
+
+	if len(accounts) != 0 {
+		t.Errorf("Expected 0 accounts, got %d", len(accounts))
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFindByNameLike_Dialects(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialector func(conn gorm.ConnPool) gorm.Dialector
+		query     string
+	}{
+		{
+			name: "mysql",
+			dialector: func(conn gorm.ConnPool) gorm.Dialector {
+				return mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+			},
+			query: "SELECT * FROM `categories` WHERE LOWER(name) LIKE LOWER(?) ESCAPE '!'",
+		},
+		{
+			name: "sqlserver",
+			dialector: func(conn gorm.ConnPool) gorm.Dialector {
+				return sqlserver.New(sqlserver.Config{Conn: conn})
+			},
+			query: `SELECT * FROM "categories" WHERE LOWER(name) LIKE LOWER(@p1) ESCAPE '!'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer mockDB.Close()
+
+			gormDB, err := gorm.Open(tt.dialector(mockDB), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("Failed to open gorm connection: %v", err)
+			}
+
+			repo := NewCategoryRepository(gormDB)
+
+			// Expectations
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs("%Food%").
+				WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).AddRow(1, "Food", "Expense"))
+
+			// Test
+			categories, err := repo.FindByNameLike(context.Background(), "Food")
+			if err != nil {
+				t.Errorf("Error finding categories by name: %v", err)
+			}
+
+			if len(categories) != 1 {
+				t.Errorf("Expected 1 category, got %d", len(categories))
+			}
+
+			// Verify expectations
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
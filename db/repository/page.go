@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+const (
+	// DefaultPageSize is the page size used when a PageRequest has no limit
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size of a PageRequest
+	MaxPageSize = 200
+)
+
+// ErrInvalidCursor is returned when a page cursor is malformed or was issued by a list ordered differently,
+// such as a cursor of the accounts list given to the categories list
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest selects a page of a list.
+// Cursor is the NextCursor of the previous page; without a cursor the page starts after Offset rows.
+// Cursors are preferred since offsets skip or repeat rows when the list changes between pages.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+// size returns the limit clamped to (0, MaxPageSize]
+func (p PageRequest) size() int {
+	switch {
+	case p.Limit <= 0:
+		return DefaultPageSize
+	case p.Limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return p.Limit
+	}
+}
+
+// Page is one page of a list; NextCursor is empty on the last page
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// cursor is the position of the last row of a page, encoded as opaque base64 JSON.
+// It holds the value of every column a list can be ordered by and the order of the list that issued it,
+// the keyset's ORDER BY clause, so that it only resumes lists ordered the same way.
+type cursor struct {
+	Keys   string        `json:"keys"`
+	Date   *time.Time    `json:"date,omitempty"`
+	Amount *money.Amount `json:"amount,omitempty"`
+	ID     uint64        `json:"id"`
+}
+
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// keyColumn is a column of a keyset and the cursor value it resumes after
+type keyColumn struct {
+	name  string
+	desc  bool
+	value func(cursor) (interface{}, bool)
+}
+
+func (c keyColumn) direction() (string, string) {
+	if c.desc {
+		return "DESC", "<"
+	}
+	return "ASC", ">"
+}
+
+// idColumn is a unique ID column, which ends every keyset
+func idColumn(name string, desc bool) keyColumn {
+	return keyColumn{name: name, desc: desc, value: func(c cursor) (interface{}, bool) {
+		return c.ID, true
+	}}
+}
+
+func transactionDateColumn(desc bool) keyColumn {
+	return keyColumn{name: "transaction_date", desc: desc, value: func(c cursor) (interface{}, bool) {
+		if c.Date == nil {
+			return nil, false
+		}
+		return *c.Date, true
+	}}
+}
+
+func amountColumn(desc bool) keyColumn {
+	return keyColumn{name: "amount", desc: desc, value: func(c cursor) (interface{}, bool) {
+		if c.Amount == nil {
+			return nil, false
+		}
+		return *c.Amount, true
+	}}
+}
+
+// keyset orders a list by a unique key so that pages can resume after the last row,
+// which stays stable under concurrent inserts unlike offsets
+type keyset[T any] struct {
+	// columns are compared in order; the last one must be unique
+	columns  []keyColumn
+	cursorOf func(*T) cursor
+}
+
+// transactionKeyset orders transactions by transaction_date, transaction_id
+var transactionKeyset = keyset[entity.Transaction]{
+	columns:  []keyColumn{transactionDateColumn(false), idColumn("transaction_id", false)},
+	cursorOf: transactionCursor,
+}
+
+func transactionCursor(t *entity.Transaction) cursor {
+	date, amount := t.TransactionDate, t.Amount
+	return cursor{Date: &date, Amount: &amount, ID: uint64(t.TransactionID)}
+}
+
+// order returns the ORDER BY clause of the keyset, like transaction_date ASC,transaction_id ASC
+func (k keyset[T]) order() string {
+	terms := make([]string, len(k.columns))
+	for i, c := range k.columns {
+		direction, _ := c.direction()
+		terms[i] = c.name + " " + direction
+	}
+	return strings.Join(terms, ",")
+}
+
+// descending returns the keyset in reverse order, for newest first lists
+func (k keyset[T]) descending() keyset[T] {
+	columns := make([]keyColumn, len(k.columns))
+	for i, c := range k.columns {
+		c.desc = !c.desc
+		columns[i] = c
+	}
+	k.columns = columns
+	return k
+}
+
+// primaryKeyset orders entities of type T by their unsigned integer primary key
+func primaryKeyset[T any](db *gorm.DB) (keyset[T], error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return keyset[T]{}, err
+	}
+	primaryKey := stmt.Schema.PrioritizedPrimaryField
+	if primaryKey == nil {
+		return keyset[T]{}, fmt.Errorf("%s has no primary key to paginate by", stmt.Schema.Name)
+	}
+	switch primaryKey.FieldType.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return keyset[T]{}, fmt.Errorf("%s must have an unsigned integer primary key to paginate by", stmt.Schema.Name)
+	}
+
+	return keyset[T]{
+		columns: []keyColumn{idColumn(primaryKey.DBName, false)},
+		cursorOf: func(row *T) cursor {
+			return cursor{ID: reflect.ValueOf(row).Elem().FieldByIndex(primaryKey.StructField.Index).Uint()}
+		},
+	}, nil
+}
+
+// apply orders the query by the keyset and restricts it to the rows after the cursor.
+// For columns a, b, c the rows after the cursor are a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c > ?),
+// expanded instead of a row value comparison since SQL Server does not support those and the directions may differ.
+// gorm parenthesizes the OR when combining it with other conditions.
+func (k keyset[T]) apply(db *gorm.DB, after *cursor) (*gorm.DB, error) {
+	if after != nil {
+		values := make([]interface{}, len(k.columns))
+		for i, c := range k.columns {
+			value, ok := c.value(*after)
+			if !ok {
+				return nil, ErrInvalidCursor
+			}
+			values[i] = value
+		}
+
+		var (
+			terms []string
+			args  []interface{}
+		)
+		for i, c := range k.columns {
+			var parts []string
+			for j := 0; j < i; j++ {
+				parts = append(parts, k.columns[j].name+" = ?")
+				args = append(args, values[j])
+			}
+			_, op := c.direction()
+			parts = append(parts, c.name+" "+op+" ?")
+			args = append(args, values[i])
+
+			term := strings.Join(parts, " AND ")
+			if i > 0 {
+				term = "(" + term + ")"
+			}
+			terms = append(terms, term)
+		}
+		db = db.Where(strings.Join(terms, " OR "), args...)
+	}
+
+	for _, c := range k.columns {
+		direction, _ := c.direction()
+		db = db.Order(c.name + " " + direction)
+	}
+	return db, nil
+}
+
+// paginate fetches one page of the query in keyset order.
+// One row beyond the page size is fetched to tell whether another page follows.
+func paginate[T any](db *gorm.DB, page PageRequest, keys keyset[T]) (*Page[T], error) {
+	after, err := decodeCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && after.Keys != keys.order() {
+		return nil, ErrInvalidCursor
+	}
+	db, err = keys.apply(db, after)
+	if err != nil {
+		return nil, err
+	}
+
+	if after == nil && page.Offset > 0 {
+		db = db.Offset(page.Offset)
+	}
+
+	size := page.size()
+	var items []T
+	if err := db.Limit(size + 1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	result := &Page[T]{Items: items}
+	if len(items) > size {
+		result.Items = items[:size]
+		next := keys.cursorOf(&items[size-1])
+		next.Keys = keys.order()
+		result.NextCursor = next.encode()
+	}
+	if result.Items == nil {
+		result.Items = []T{}
+	}
+	return result, nil
+}
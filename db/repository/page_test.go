@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPageRequest_Size(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: DefaultPageSize},
+		{limit: -5, want: DefaultPageSize},
+		{limit: 20, want: 20},
+		{limit: MaxPageSize + 1, want: MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		if got := (PageRequest{Limit: tt.limit}).size(); got != tt.want {
+			t.Errorf("size() with limit %d = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	date := time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC)
+	encoded := cursor{Date: &date, ID: 42}.encode()
+
+	decoded, err := decodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("Error decoding cursor: %v", err)
+	}
+	if decoded.ID != 42 || decoded.Date == nil || !decoded.Date.Equal(date) {
+		t.Errorf("Expected cursor (2023-03-14, 42), got %+v", decoded)
+	}
+
+	if _, err := decodeCursor("%%%"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for malformed cursor, got %v", err)
+	}
+}
+
+func TestAccountRepository_FindAllPage(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewAccountRepository(gormDB)
+	ctx := context.Background()
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE account_id > $1 ORDER BY account_id ASC LIMIT $2`)).
+		WithArgs(uint64(10), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "created_at", "updated_at"}).
+			AddRow(11, "Account 11", "Checking", time.Now(), time.Now()).
+			AddRow(12, "Account 12", "Savings", time.Now(), time.Now()).
+			AddRow(13, "Account 13", "Savings", time.Now(), time.Now()))
+
+	// Test
+	page, err := repo.FindAllPage(ctx, PageRequest{Limit: 2, Cursor: cursor{Keys: "account_id ASC", ID: 10}.encode()})
+	if err != nil {
+		t.Fatalf("Error finding page of accounts: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("Expected 2 accounts, got %d", len(page.Items))
+	}
+	next, err := decodeCursor(page.NextCursor)
+	if err != nil || next.ID != 12 || next.Date != nil || next.Keys != "account_id ASC" {
+		t.Errorf("Expected next cursor after account 12, got %+v (%v)", next, err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindAllPage_Offset(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := context.Background()
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" ORDER BY transaction_date ASC,transaction_id ASC LIMIT $1 OFFSET $2`)).
+		WithArgs(6, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date"}))
+
+	// Test
+	page, err := repo.FindAllPage(ctx, PageRequest{Limit: 5, Offset: 10})
+	if err != nil {
+		t.Fatalf("Error finding page of transactions: %v", err)
+	}
+	if page.Items == nil || len(page.Items) != 0 || page.NextCursor != "" {
+		t.Errorf("Expected an empty last page, got %+v", page)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindByAccountAndDateRangePage(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := context.Background()
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	// Expectations: newest first, so the page continues with older rows
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE (account_id = $1 AND transaction_date BETWEEN $2 AND $3) AND (transaction_date < $4 OR (transaction_date = $5 AND transaction_id < $6)) ORDER BY transaction_date DESC,transaction_id DESC LIMIT $7`)).
+		WithArgs(uint(1), start, end, after, after, uint64(40), 11).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date"}))
+
+	// Test
+	_, err := repo.FindByAccountAndDateRangePage(ctx, 1, start, end, PageRequest{Limit: 10, Cursor: cursor{Keys: "transaction_date DESC,transaction_id DESC", Date: &after, ID: 40}.encode()})
+	if err != nil {
+		t.Fatalf("Error finding page of transactions: %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindAllPage_CursorWithoutDate(t *testing.T) {
+	// Setup
+	_, _, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+
+	// A cursor of an ID-ordered list cannot resume a date-ordered one
+	_, err := repo.FindAllPage(context.Background(), PageRequest{Cursor: cursor{ID: 3}.encode()})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestCategoryRepository_FindAllPage_CursorOfOtherList(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	accounts := NewAccountRepository(gormDB)
+	categories := NewCategoryRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" ORDER BY account_id ASC LIMIT $1`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking").AddRow(2, "Savings"))
+
+	// Test: both lists are ordered by an ID, but the cursor of one does not resume the other
+	page, err := accounts.FindAllPage(ctx, PageRequest{Limit: 1})
+	if err != nil {
+		t.Fatalf("Error finding page of accounts: %v", err)
+	}
+	_, err = categories.FindAllPage(ctx, PageRequest{Cursor: page.NextCursor})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
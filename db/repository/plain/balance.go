@@ -0,0 +1,20 @@
+package plain
+
+import (
+	"time"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+// TransactionBalance represents a transaction with the balance of its account right after it
+type TransactionBalance struct {
+	entity.Transaction
+	RunningBalance money.Amount `json:"running_balance"`
+}
+
+// DailyBalance represents the balance of an account at the end of a day
+type DailyBalance struct {
+	Date    time.Time    `json:"date"`
+	Balance money.Amount `json:"balance"`
+}
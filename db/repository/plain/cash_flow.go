@@ -0,0 +1,19 @@
+package plain
+
+import (
+	"time"
+
+	"sample-mcp/pkg/money"
+)
+
+// CashFlow represents the income and expenses of one period, of one category or account when the report is split.
+// Expenses are negative, so Net is Income + Expenses.
+type CashFlow struct {
+	PeriodStart time.Time    `json:"period_start"`
+	GroupID     uint         `json:"group_id,omitempty"`
+	GroupName   string       `json:"group_name,omitempty"`
+	Income      money.Amount `json:"income"`
+	Expenses    money.Amount `json:"expenses"`
+	Net         money.Amount `json:"net"`
+	Count       int64        `json:"count"`
+}
@@ -0,0 +1,27 @@
+package plain
+
+import "sample-mcp/pkg/money"
+
+// CategoryStats represents the income or the expenses of one category in a category breakdown.
+// Percentage is the share of the category in the total of its side.
+type CategoryStats struct {
+	CategoryID   uint         `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	CategoryType string       `json:"category_type"`
+	Total        money.Amount `json:"total"`
+	Count        int64        `json:"count"`
+	Average      money.Amount `json:"average"`
+	Min          money.Amount `json:"min"`
+	Max          money.Amount `json:"max"`
+	Percentage   float64      `json:"percentage"`
+}
+
+// CategoryBreakdown represents transactions per category, split into income (positive amounts) and expenses
+// (the others), each side ordered by the size of its categories' totals. Net is TotalIncome + TotalExpenses.
+type CategoryBreakdown struct {
+	Income        []CategoryStats `json:"income"`
+	Expenses      []CategoryStats `json:"expenses"`
+	TotalIncome   money.Amount    `json:"total_income"`
+	TotalExpenses money.Amount    `json:"total_expenses"`
+	Net           money.Amount    `json:"net"`
+}
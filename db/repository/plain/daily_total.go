@@ -0,0 +1,18 @@
+package plain
+
+import (
+	"time"
+
+	"sample-mcp/pkg/money"
+)
+
+// DailyTotal represents the transactions of an account in one category on one day,
+// summed in the currency of the account
+type DailyTotal struct {
+	TransactionDate time.Time    `json:"transaction_date"`
+	AccountID       uint         `json:"account_id"`
+	Currency        string       `json:"currency"`
+	CategoryName    string       `json:"category_name"`
+	TotalAmount     money.Amount `json:"total_amount"`
+	Count           int64        `json:"count"`
+}
@@ -1,8 +1,10 @@
 package plain
 
+import "sample-mcp/pkg/money"
+
 // TransactionSummary represents grouped data by category
 type TransactionSummary struct {
-	CategoryName string
-	TotalAmount  float64
-	Count        int64
+	CategoryName string       `json:"category_name"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	Count        int64        `json:"count"`
 }
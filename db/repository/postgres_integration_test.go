@@ -0,0 +1,24 @@
+//go:build integration && !sqlite
+
+package repository_test
+
+import (
+	"time"
+
+	pkgDB "sample-mcp/pkg/db"
+)
+
+// testConnectionConfig connects to the Postgres server of the development setup
+func testConnectionConfig() pkgDB.ConnectionConfig {
+	return pkgDB.ConnectionConfig{
+		DbType:       pkgDB.Postgresql,
+		Host:         "localhost",
+		Port:         5432,
+		Username:     "jasoet",
+		Password:     "localhost",
+		DbName:       "mcp_db",
+		Timeout:      10 * time.Second,
+		MaxIdleConns: 5,
+		MaxOpenConns: 10,
+	}
+}
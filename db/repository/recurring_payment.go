@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sample-mcp/db/entity"
+)
+
+// recurringPaymentBatchSize keeps batched upserts well below the 2100 parameters SQL Server accepts per statement
+const recurringPaymentBatchSize = 100
+
+// RecurringPaymentRepository stores the recurring payments detected among the transactions,
+// restricted like transactions to the accounts of the caller's scope
+type RecurringPaymentRepository struct {
+	*BaseRepository[entity.RecurringPayment]
+}
+
+func NewRecurringPaymentRepository(db *gorm.DB) *RecurringPaymentRepository {
+	return &RecurringPaymentRepository{
+		BaseRepository: &BaseRepository[entity.RecurringPayment]{DB: db, Scope: recurringPaymentScope},
+	}
+}
+
+// FindByAccountIDs retrieves the recurring payments of the accounts, every account in scope when none are given,
+// with their account and category, ordered by next due date
+func (r *RecurringPaymentRepository) FindByAccountIDs(ctx context.Context, accountIDs []uint) ([]entity.RecurringPayment, error) {
+	query := r.query(ctx).Preload("Account").Preload("Category").Order("next_due_date").Order("recurring_payment_id")
+	if len(accountIDs) > 0 {
+		query = query.Where("recurring_payments.account_id IN ?", accountIDs)
+	}
+	var payments []entity.RecurringPayment
+	if err := query.Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// Upsert inserts the payments, replacing the detected values of rows that already exist for the same account,
+// category and description key. Payments outside the caller's scope are rejected with ErrOutOfScope.
+func (r *RecurringPaymentRepository) Upsert(ctx context.Context, payments []entity.RecurringPayment) error {
+	if len(payments) == 0 {
+		return nil
+	}
+	for i := range payments {
+		if err := r.checkScope(ctx, &payments[i], false); err != nil {
+			return err
+		}
+	}
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "account_id"}, {Name: "category_id"}, {Name: "description_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"description", "frequency", "typical_amount", "occurrences",
+				"first_date", "last_date", "last_amount", "next_due_date", "updated_at",
+			}),
+		}).
+		CreateInBatches(payments, recurringPaymentBatchSize).Error
+}
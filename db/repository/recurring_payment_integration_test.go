@@ -0,0 +1,70 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// TestRecurringPaymentRepository_Upsert stores a recurring payment of a fresh category twice, as two analyses would,
+// and checks that the second one updates the first instead of adding a row
+func TestRecurringPaymentRepository_Upsert(t *testing.T) {
+	repo := repository.NewRecurringPaymentRepository(TestDB)
+	ctx := context.Background()
+
+	category := createTestCategory(t, "Subscriptions", "Expense")
+	first := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	payment := entity.RecurringPayment{
+		AccountID:      1,
+		CategoryID:     category.CategoryID,
+		DescriptionKey: "streaming service",
+		Description:    "Streaming Service 0001",
+		Frequency:      "monthly",
+		TypicalAmount:  money.MustParse("-12.99"),
+		Occurrences:    3,
+		FirstDate:      first,
+		LastDate:       first.AddDate(0, 2, 0),
+		LastAmount:     money.MustParse("-12.99"),
+		NextDueDate:    first.AddDate(0, 3, 0),
+	}
+	stored := []entity.RecurringPayment{payment}
+	require.NoError(t, repo.Upsert(ctx, stored))
+	t.Cleanup(func() {
+		TestDB.Where("category_id = ?", category.CategoryID).Delete(&entity.RecurringPayment{})
+	})
+
+	payment.Description = "Streaming Service 0002"
+	payment.Occurrences = 4
+	payment.LastDate = first.AddDate(0, 3, 0)
+	payment.LastAmount = money.MustParse("-15.99")
+	payment.NextDueDate = first.AddDate(0, 4, 0)
+	require.NoError(t, repo.Upsert(ctx, []entity.RecurringPayment{payment}))
+
+	found, err := repo.FindByAccountIDs(ctx, []uint{1})
+	require.NoError(t, err)
+	var matches []entity.RecurringPayment
+	for _, p := range found {
+		if p.CategoryID == category.CategoryID {
+			matches = append(matches, p)
+		}
+	}
+	require.Len(t, matches, 1)
+	got := matches[0]
+	assert.NotZero(t, got.RecurringPaymentID)
+	assert.Equal(t, "Streaming Service 0002", got.Description)
+	assert.Equal(t, 4, got.Occurrences)
+	assert.Equal(t, money.MustParse("-12.99"), got.TypicalAmount)
+	assert.Equal(t, money.MustParse("-15.99"), got.LastAmount)
+	assert.Equal(t, first, got.FirstDate.UTC())
+	assert.Equal(t, first.AddDate(0, 4, 0), got.NextDueDate.UTC())
+	require.NotNil(t, got.Category)
+	assert.Equal(t, category.Name, got.Category.Name)
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func TestRecurringPaymentRepository_FindByAccountIDs_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewRecurringPaymentRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "recurring_payments" WHERE recurring_payments.account_id IN ($1) AND recurring_payments.account_id IN ($2,$3) ORDER BY next_due_date,recurring_payment_id`)).
+		WithArgs(1, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"recurring_payment_id", "account_id", "category_id", "description_key", "frequency"}).
+			AddRow(7, 1, 4, "property tax payment", "monthly"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(4, "Property Taxes"))
+
+	// Test
+	payments, err := repo.FindByAccountIDs(ctx, []uint{1, 2})
+	if err != nil {
+		t.Fatalf("Error finding recurring payments: %v", err)
+	}
+
+	if len(payments) != 1 || payments[0].Account == nil || payments[0].Category == nil ||
+		payments[0].Category.Name != "Property Taxes" {
+		t.Errorf("Unexpected recurring payments %+v", payments)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecurringPaymentRepository_Upsert(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewRecurringPaymentRepository(gormDB)
+	first := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	payment := entity.RecurringPayment{
+		AccountID:      1,
+		CategoryID:     4,
+		DescriptionKey: "property tax payment",
+		Description:    "Property Tax Payment",
+		Frequency:      "monthly",
+		TypicalAmount:  money.MustParse("-363.95"),
+		Occurrences:    3,
+		FirstDate:      first,
+		LastDate:       last,
+		LastAmount:     money.MustParse("-363.95"),
+		NextDueDate:    next,
+	}
+
+	// Expectations: a payment found before keeps its ID and creation time
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "recurring_payments" ("account_id","category_id","description_key","description","frequency","typical_amount","occurrences","first_date","last_date","last_amount","next_due_date") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) `+
+		`ON CONFLICT ("account_id","category_id","description_key") DO UPDATE SET "description"="excluded"."description","frequency"="excluded"."frequency","typical_amount"="excluded"."typical_amount","occurrences"="excluded"."occurrences","first_date"="excluded"."first_date","last_date"="excluded"."last_date","last_amount"="excluded"."last_amount","next_due_date"="excluded"."next_due_date","updated_at"="excluded"."updated_at" `+
+		`RETURNING "created_at","updated_at","recurring_payment_id"`)).
+		WithArgs(1, 4, "property tax payment", "Property Tax Payment", "monthly", "-363.95", 3, first, last, "-363.95", next).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "recurring_payment_id"}).AddRow(time.Now(), time.Now(), 7))
+	mock.ExpectCommit()
+
+	// Test
+	payments := []entity.RecurringPayment{payment}
+	if err := repo.Upsert(context.Background(), payments); err != nil {
+		t.Errorf("Error upserting recurring payments: %v", err)
+	}
+	if payments[0].RecurringPaymentID != 7 {
+		t.Errorf("Expected recurring payment ID 7, got %d", payments[0].RecurringPaymentID)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecurringPaymentRepository_Upsert_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewRecurringPaymentRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Test: nothing is written when any payment is outside the scope
+	err := repo.Upsert(ctx, []entity.RecurringPayment{{AccountID: 1, CategoryID: 4}, {AccountID: 5, CategoryID: 4}})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"reflect"
+
 	"gorm.io/gorm"
 )
 
@@ -12,6 +15,8 @@ type Repository[T any] interface {
 
 	FindAll(ctx context.Context) ([]T, error)
 
+	FindAllPage(ctx context.Context, page PageRequest) (*Page[T], error)
+
 	Update(ctx context.Context, entity *T) error
 
 	Delete(ctx context.Context, entity *T) error
@@ -21,15 +26,29 @@ type Repository[T any] interface {
 
 type BaseRepository[T any] struct {
 	DB *gorm.DB
+	// Scope restricts queries and writes to the caller's scope; nil means unrestricted
+	Scope RowScope[T]
+}
+
+// query returns a session bound to ctx with the caller's scope applied
+func (r *BaseRepository[T]) query(ctx context.Context) *gorm.DB {
+	db := r.DB.WithContext(ctx)
+	if r.Scope != nil {
+		db = r.Scope.Apply(ctx, db)
+	}
+	return db
 }
 
 func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
+	if err := r.checkScope(ctx, entity, false); err != nil {
+		return err
+	}
 	return r.DB.WithContext(ctx).Create(entity).Error
 }
 
 func (r *BaseRepository[T]) FindByID(ctx context.Context, id uint) (*T, error) {
 	var entity T
-	if err := r.DB.WithContext(ctx).First(&entity, id).Error; err != nil {
+	if err := r.query(ctx).First(&entity, id).Error; err != nil {
 		return nil, err
 	}
 	return &entity, nil
@@ -37,21 +56,75 @@ func (r *BaseRepository[T]) FindByID(ctx context.Context, id uint) (*T, error) {
 
 func (r *BaseRepository[T]) FindAll(ctx context.Context) ([]T, error) {
 	var entities []T
-	if err := r.DB.WithContext(ctx).Find(&entities).Error; err != nil {
+	if err := r.query(ctx).Find(&entities).Error; err != nil {
 		return nil, err
 	}
 	return entities, nil
 }
 
+// FindAllPage retrieves a page of entities ordered by primary key
+func (r *BaseRepository[T]) FindAllPage(ctx context.Context, page PageRequest) (*Page[T], error) {
+	keys, err := primaryKeyset[T](r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(r.query(ctx), page, keys)
+}
+
 func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) error {
+	if err := r.checkScope(ctx, entity, true); err != nil {
+		return err
+	}
 	return r.DB.WithContext(ctx).Save(entity).Error
 }
 
 func (r *BaseRepository[T]) Delete(ctx context.Context, entity *T) error {
-	return r.DB.WithContext(ctx).Delete(entity).Error
+	return r.query(ctx).Delete(entity).Error
 }
 
 func (r *BaseRepository[T]) DeleteByID(ctx context.Context, id uint) error {
 	var entity T
-	return r.DB.WithContext(ctx).Delete(&entity, id).Error
+	return r.query(ctx).Delete(&entity, id).Error
+}
+
+// checkScope rejects writes of entities outside the caller's scope.
+// Save inserts the row when it cannot update it, so for updates the stored row must be visible as well.
+func (r *BaseRepository[T]) checkScope(ctx context.Context, entity *T, update bool) error {
+	if r.Scope == nil {
+		return nil
+	}
+	if _, scoped := ScopeFromContext(ctx); !scoped {
+		return nil
+	}
+
+	allowed, err := r.Scope.Allows(ctx, r.DB, entity)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrOutOfScope
+	}
+	if !update {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: r.DB}
+	if err := stmt.Parse(entity); err != nil {
+		return err
+	}
+	primaryKey := stmt.Schema.PrioritizedPrimaryField
+	if primaryKey == nil {
+		return nil
+	}
+	id, isZero := primaryKey.ValueOf(ctx, reflect.ValueOf(entity).Elem())
+	if isZero {
+		return nil
+	}
+
+	var stored T
+	err = r.query(ctx).First(&stored, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrOutOfScope
+	}
+	return err
 }
@@ -5,32 +5,22 @@ package repository_test
 import (
 	"gorm.io/gorm"
 	"sample-mcp/db"
-	pkgDB "sample-mcp/pkg/db"
 	"testing"
-	"time"
 )
 
 var TestDB *gorm.DB
 
+// TestMain migrates the database of testConnectionConfig, which is a local Postgres server,
+// or an in-memory SQLite database needing no external service when built with -tags sqlite
 func TestMain(m *testing.M) {
-	cfg := pkgDB.ConnectionConfig{
-		DbType:       pkgDB.Postgresql,
-		Host:         "localhost",
-		Port:         5432,
-		Username:     "jasoet",
-		Password:     "localhost",
-		DbName:       "mcp_db",
-		Timeout:      10 * time.Second,
-		MaxIdleConns: 5,
-		MaxOpenConns: 10,
-	}
+	cfg := testConnectionConfig()
 
 	pool, err := cfg.Pool()
 	if err != nil {
 		panic(err)
 	}
 
-	err = db.RunMigrations(pool)
+	err = db.RunMigrations(pool, cfg.DbType)
 	if err != nil {
 		panic(err)
 	}
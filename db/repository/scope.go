@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"sample-mcp/db/entity"
+)
+
+// ErrOutOfScope is returned when a write targets an account outside the caller's scope
+var ErrOutOfScope = errors.New("record is outside the caller's account scope")
+
+// Scope is the set of accounts a caller may access, granted by account ID or by account type.
+// A context without a Scope is unrestricted; an empty Scope grants no accounts.
+type Scope struct {
+	AccountIDs   []uint
+	AccountTypes []string
+}
+
+type scopeKey struct{}
+
+// WithScope returns a copy of ctx whose repository queries are restricted to the scope
+func WithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFromContext returns the scope stored in ctx, if any
+func ScopeFromContext(ctx context.Context) (*Scope, bool) {
+	scope, ok := ctx.Value(scopeKey{}).(*Scope)
+	return scope, ok && scope != nil
+}
+
+// RowScope restricts the rows of T that a context can read and write
+type RowScope[T any] interface {
+	// Apply adds the restriction of the context's scope to a query
+	Apply(ctx context.Context, db *gorm.DB) *gorm.DB
+	// Allows reports whether the entity may be written within the context's scope
+	Allows(ctx context.Context, db *gorm.DB, entity *T) (bool, error)
+}
+
+// where restricts a query to the granted accounts: idColumn must be one of the account IDs,
+// or typeCondition, which receives the account types as its only argument, must hold
+func (s *Scope) where(db *gorm.DB, idColumn, typeCondition string, typeArg interface{}) *gorm.DB {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if len(s.AccountIDs) > 0 {
+		conditions = append(conditions, idColumn+" IN ?")
+		args = append(args, s.AccountIDs)
+	}
+	if len(s.AccountTypes) > 0 {
+		conditions = append(conditions, typeCondition)
+		args = append(args, typeArg)
+	}
+	if len(conditions) == 0 {
+		return db.Where("1 = 0")
+	}
+	// gorm wraps conditions containing OR in parentheses, so they cannot combine with the query's own conditions
+	return db.Where(strings.Join(conditions, " OR "), args...)
+}
+
+// whereAccount restricts a query to the rows whose account ID column holds a granted account,
+// matching the account types through a subquery on the accounts table
+func (s *Scope) whereAccount(db *gorm.DB, column string) *gorm.DB {
+	accounts := db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.Account{}).
+		Select("account_id").
+		Where("account_type IN ?", s.AccountTypes)
+	return s.where(db, column, column+" IN (?)", accounts)
+}
+
+// allowsAccount reports whether the account is granted by ID or by its type
+func (s *Scope) allowsAccount(ctx context.Context, db *gorm.DB, accountID uint) (bool, error) {
+	if slices.Contains(s.AccountIDs, accountID) {
+		return true, nil
+	}
+	if len(s.AccountTypes) == 0 {
+		return false, nil
+	}
+
+	var count int64
+	err := db.WithContext(ctx).
+		Model(&entity.Account{}).
+		Where("account_id = ? AND account_type IN ?", accountID, s.AccountTypes).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// accountScope restricts the accounts table to the granted accounts
+type accountScope struct{}
+
+func (accountScope) Apply(ctx context.Context, db *gorm.DB) *gorm.DB {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return db
+	}
+	return scope.where(db, "accounts.account_id", "accounts.account_type IN ?", scope.AccountTypes)
+}
+
+func (accountScope) Allows(ctx context.Context, db *gorm.DB, account *entity.Account) (bool, error) {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return true, nil
+	}
+	return slices.Contains(scope.AccountIDs, account.AccountID) ||
+		slices.Contains(scope.AccountTypes, account.AccountType), nil
+}
+
+// accountOwnedScope restricts a table whose rows belong to an account to the rows of the granted accounts
+type accountOwnedScope[T any] struct {
+	// column is the account ID column of the table, qualified by the table name
+	column    string
+	accountID func(*T) uint
+}
+
+func (s accountOwnedScope[T]) Apply(ctx context.Context, db *gorm.DB) *gorm.DB {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return db
+	}
+	return scope.whereAccount(db, s.column)
+}
+
+func (s accountOwnedScope[T]) Allows(ctx context.Context, db *gorm.DB, entity *T) (bool, error) {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return true, nil
+	}
+	return scope.allowsAccount(ctx, db, s.accountID(entity))
+}
+
+// transactionScope restricts the transactions table to those of the granted accounts
+var transactionScope = accountOwnedScope[entity.Transaction]{
+	column:    "transactions.account_id",
+	accountID: func(t *entity.Transaction) uint { return t.AccountID },
+}
+
+// recurringPaymentScope restricts the recurring_payments table to those of the granted accounts
+var recurringPaymentScope = accountOwnedScope[entity.RecurringPayment]{
+	column:    "recurring_payments.account_id",
+	accountID: func(p *entity.RecurringPayment) uint { return p.AccountID },
+}
+
+// statementBalanceScope restricts the statement_balances table to those of the granted accounts
+var statementBalanceScope = accountOwnedScope[entity.StatementBalance]{
+	column:    "statement_balances.account_id",
+	accountID: func(b *entity.StatementBalance) uint { return b.AccountID },
+}
+
+// categorizationRuleScope restricts the categorization_rules table to the rules of every account and those of the
+// granted accounts. Rules of every account categorize the transactions of other scopes as well, so only unscoped
+// callers may write them.
+type categorizationRuleScope struct{}
+
+func (categorizationRuleScope) Apply(ctx context.Context, db *gorm.DB) *gorm.DB {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return db
+	}
+	granted := scope.whereAccount(db.Session(&gorm.Session{NewDB: true}), "categorization_rules.account_id")
+	return db.Where(db.Session(&gorm.Session{NewDB: true}).Where("categorization_rules.account_id IS NULL").Or(granted))
+}
+
+func (categorizationRuleScope) Allows(ctx context.Context, db *gorm.DB, rule *entity.CategorizationRule) (bool, error) {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return true, nil
+	}
+	if rule.AccountID == nil {
+		return false, nil
+	}
+	return scope.allowsAccount(ctx, db, *rule.AccountID)
+}
@@ -0,0 +1,411 @@
+// These tests verify that a Scope stored on the context is added to every repository query,
+// so that callers can never read or write rows of accounts outside the scope.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func TestTransactionRepository_CountByAccountID_ScopedByID(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1, 2}})
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM "transactions" WHERE transactions.account_id IN ($1,$2) AND account_id = $3`)).
+		WithArgs(1, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	// Test
+	count, err := repo.CountByAccountID(ctx, 1)
+	if err != nil {
+		t.Errorf("Error counting transactions by account ID: %v", err)
+	}
+
+	if count != 4 {
+		t.Errorf("Expected count 4, got %d", count)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindByDateRange_ScopedByType(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountTypes: []string{"Checking"}})
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN (SELECT "account_id" FROM "accounts" WHERE account_type IN ($1)) AND (transaction_date BETWEEN $2 AND $3)`)).
+		WithArgs("Checking", start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "amount"}))
+
+	// Test
+	transactions, err := repo.FindByDateRange(ctx, start, end)
+	if err != nil {
+		t.Errorf("Error finding transactions by date range: %v", err)
+	}
+
+	if len(transactions) != 0 {
+		t.Errorf("Expected 0 transactions, got %d", len(transactions))
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindByDescriptionLike_ScopedByIDAndType(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{3}, AccountTypes: []string{"Savings", "Credit"}})
+
+	// Expectations: the OR of the scope must be grouped so that it cannot widen the query's own condition
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE (transactions.account_id IN ($1) OR transactions.account_id IN (SELECT "account_id" FROM "accounts" WHERE account_type IN ($2,$3))) AND (description IS NOT NULL AND description ILIKE $4 ESCAPE '!')`)).
+		WithArgs(3, "Savings", "Credit", "%rent%").
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "amount"}))
+
+	// Test
+	_, err := repo.FindByDescriptionLike(ctx, "rent")
+	if err != nil {
+		t.Errorf("Error finding transactions by description: %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_GroupByCategory_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT categories.category_id, categories.name AS category_name, categories.category_type, `+
+		`CASE WHEN transactions.amount > 0 THEN 1 ELSE 0 END AS income, SUM(transactions.amount) AS total, COUNT(transactions.transaction_id) AS count, `+
+		`MIN(transactions.amount) AS min_amount, MAX(transactions.amount) AS max_amount `+
+		`FROM "transactions" JOIN categories ON transactions.category_id = categories.category_id `+
+		`WHERE transactions.account_id IN ($1) AND transactions.account_id IN ($2) `+
+		`GROUP BY categories.category_id, categories.name, categories.category_type, `+
+		`CASE WHEN transactions.amount > 0 THEN 1 ELSE 0 END`)).
+		WithArgs(1, uint(1)).
+		WillReturnRows(sqlmock.NewRows(categoryStatsColumns).
+			AddRow(3, "Food", "Expense", 0, "-120.25", 3, "-60.00", "-20.25").
+			AddRow(3, "Food", "Expense", 1, "20.00", 1, "20.00", "20.00").
+			AddRow(9, "Salary", "Income", 1, "3000.00", 1, "3000.00", "3000.00"))
+
+	// Test
+	summaries, err := repo.GroupByCategory(ctx, 1)
+	if err != nil {
+		t.Errorf("Error grouping transactions by category: %v", err)
+	}
+
+	// The refund is added to the expenses of its category, and the largest total comes first
+	if len(summaries) != 2 || summaries[0].CategoryName != "Salary" ||
+		summaries[1].CategoryName != "Food" || summaries[1].TotalAmount != money.MustParse("-100.25") || summaries[1].Count != 4 {
+		t.Errorf("Unexpected summaries: %+v", summaries)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_SumByAccountID_EmptyScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{})
+
+	// Expectations: a scope without grants matches no rows
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE 1 = 0 AND account_id = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(0))
+
+	// Test
+	sum, err := repo.SumByAccountID(ctx, 1)
+	if err != nil {
+		t.Errorf("Error calculating sum by account ID: %v", err)
+	}
+
+	if sum != 0 {
+		t.Errorf("Expected sum 0, got %s", sum)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_FindByID_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) AND "transactions"."transaction_id" = $2 ORDER BY "transactions"."transaction_id" LIMIT $3`)).
+		WithArgs(1, 7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "amount"}))
+
+	// Test
+	transaction, err := repo.FindByID(ctx, 7)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected record not found, got %v", err)
+	}
+
+	if transaction != nil {
+		t.Errorf("Expected nil transaction, got %v", transaction)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_DeleteByID_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "transactions" WHERE transactions.account_id IN ($1) AND "transactions"."transaction_id" = $2`)).
+		WithArgs(1, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// Test
+	err := repo.DeleteByID(ctx, 7)
+	if err != nil {
+		t.Errorf("Error deleting transaction by ID: %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_Create_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}, AccountTypes: []string{"Checking"}})
+
+	// Expectations: account 5 is not granted by ID, so its type is checked, and nothing is inserted
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "accounts" WHERE account_id = $1 AND account_type IN ($2)`)).
+		WithArgs(5, "Checking").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	// Test
+	err := repo.Create(ctx, &entity.Transaction{AccountID: 5, CategoryID: 2, Amount: money.FromCents(1000)})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_CreateAll_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Test: nothing is inserted when any transaction is outside the scope
+	err := repo.CreateAll(ctx, []entity.Transaction{
+		{AccountID: 1, CategoryID: 2, Amount: money.FromCents(1000)},
+		{AccountID: 5, CategoryID: 2, Amount: money.FromCents(1000)},
+	})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_Update_StoredRowOutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations: the new account is granted, but the stored transaction belongs to another account
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) AND "transactions"."transaction_id" = $2 ORDER BY "transactions"."transaction_id" LIMIT $3`)).
+		WithArgs(1, 9, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "amount"}))
+
+	// Test
+	err := repo.Update(ctx, &entity.Transaction{TransactionID: 9, AccountID: 1, CategoryID: 2, Amount: money.FromCents(1000)})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAccountRepository_FindByNameLike_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewAccountRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{3}, AccountTypes: []string{"Savings"}})
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE (accounts.account_id IN ($1) OR accounts.account_type IN ($2)) AND name ILIKE $3 ESCAPE '!'`)).
+		WithArgs(3, "Savings", "%joint%").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type"}).AddRow(3, "Joint Savings", "Savings"))
+
+	// Test
+	accounts, err := repo.FindByNameLike(ctx, "joint")
+	if err != nil {
+		t.Errorf("Error finding accounts by name: %v", err)
+	}
+
+	if len(accounts) != 1 {
+		t.Errorf("Expected 1 account, got %d", len(accounts))
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAccountRepository_Create_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewAccountRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountTypes: []string{"Savings"}})
+
+	// Test
+	err := repo.Create(ctx, &entity.Account{Name: "Business", AccountType: "Checking"})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCategoryRepository_FindAll_NotScoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewCategoryRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{})
+
+	// Expectations: categories are shared reference data
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).AddRow(1, "Food", "Expense"))
+
+	// Test
+	categories, err := repo.FindAll(ctx)
+	if err != nil {
+		t.Errorf("Error finding all categories: %v", err)
+	}
+
+	if len(categories) != 1 {
+		t.Errorf("Expected 1 category, got %d", len(categories))
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_UpdateCategories_Scoped(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Expectations: one update per category in one database transaction, each leaving transaction 9 of another
+	// account as it is
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "category_id"=$1,"updated_at"=$2 WHERE transactions.account_id IN ($3) AND transaction_id IN ($4,$5)`)).
+		WithArgs(3, sqlmock.AnyArg(), 1, 7, 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "category_id"=$1,"updated_at"=$2 WHERE transactions.account_id IN ($3) AND transaction_id IN ($4)`)).
+		WithArgs(5, sqlmock.AnyArg(), 1, 8).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Test
+	moved, err := repo.UpdateCategories(ctx, map[uint][]uint{5: {8}, 3: {7, 9}})
+	if err != nil {
+		t.Fatalf("Error updating categories: %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("Expected 2 transactions to be moved, got %d", moved)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build integration && sqlite
+
+package repository_test
+
+import (
+	"time"
+
+	pkgDB "sample-mcp/pkg/db"
+)
+
+// testConnectionConfig opens an in-memory SQLite database, which the pool keeps on a single connection
+func testConnectionConfig() pkgDB.ConnectionConfig {
+	return pkgDB.ConnectionConfig{
+		DbType:       pkgDB.Sqlite,
+		DbName:       pkgDB.SqliteMemory,
+		Timeout:      10 * time.Second,
+		MaxIdleConns: 1,
+		MaxOpenConns: 1,
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sample-mcp/db/entity"
+)
+
+// StatementBalanceRepository stores the ledger balances of imported bank statements,
+// restricted like transactions to the accounts of the caller's scope
+type StatementBalanceRepository struct {
+	*BaseRepository[entity.StatementBalance]
+}
+
+func NewStatementBalanceRepository(db *gorm.DB) *StatementBalanceRepository {
+	return &StatementBalanceRepository{
+		BaseRepository: &BaseRepository[entity.StatementBalance]{DB: db, Scope: statementBalanceScope},
+	}
+}
+
+// Upsert inserts the balances, replacing the balance of an account already stated for the same day.
+// Balances outside the caller's scope are rejected with ErrOutOfScope.
+func (r *StatementBalanceRepository) Upsert(ctx context.Context, balances []entity.StatementBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+	for i := range balances {
+		if err := r.checkScope(ctx, &balances[i], false); err != nil {
+			return err
+		}
+	}
+	return r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "account_id"}, {Name: "balance_date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"balance", "updated_at"}),
+		}).
+		Create(&balances).Error
+}
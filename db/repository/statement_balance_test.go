@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func TestStatementBalanceRepository_Upsert(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewStatementBalanceRepository(gormDB)
+	date := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	// Expectations: a balance stated before for the same day is replaced
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "statement_balances" ("account_id","balance_date","balance") VALUES ($1,$2,$3) `+
+		`ON CONFLICT ("account_id","balance_date") DO UPDATE SET "balance"="excluded"."balance","updated_at"="excluded"."updated_at" `+
+		`RETURNING "created_at","updated_at"`)).
+		WithArgs(1, date, "3287.50").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+	mock.ExpectCommit()
+
+	// Test
+	err := repo.Upsert(context.Background(), []entity.StatementBalance{
+		{AccountID: 1, BalanceDate: date, Balance: money.MustParse("3287.50")},
+	})
+	if err != nil {
+		t.Errorf("Error upserting statement balances: %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStatementBalanceRepository_Upsert_OutOfScope(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewStatementBalanceRepository(gormDB)
+	ctx := WithScope(context.Background(), &Scope{AccountIDs: []uint{1}})
+
+	// Test: nothing is stored when any balance is outside the scope
+	err := repo.Upsert(ctx, []entity.StatementBalance{
+		{AccountID: 1, BalanceDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Balance: money.FromCents(100)},
+		{AccountID: 5, BalanceDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Balance: money.FromCents(100)},
+	})
+	if !errors.Is(err, ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"maps"
+	"slices"
+
 	"gorm.io/gorm"
 	"sample-mcp/db/repository/plain"
+	"sample-mcp/pkg/money"
 	"time"
 
 	"sample-mcp/db/entity"
@@ -13,15 +17,79 @@ type TransactionRepository struct {
 	*BaseRepository[entity.Transaction]
 }
 
+const (
+	// transactionBatchSize keeps batched inserts well below the 2100 parameters SQL Server accepts per statement
+	transactionBatchSize = 200
+	// externalIDBatchSize bounds the IN lists of external ID lookups for the same reason
+	externalIDBatchSize = 1000
+	// transactionIDBatchSize bounds the IN lists of updates by transaction ID likewise
+	transactionIDBatchSize = 1000
+)
+
 func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
 	return &TransactionRepository{
-		BaseRepository: &BaseRepository[entity.Transaction]{DB: db},
+		BaseRepository: &BaseRepository[entity.Transaction]{DB: db, Scope: transactionScope},
+	}
+}
+
+// CreateAll inserts the transactions in batches, setting their IDs.
+// Transactions outside the caller's scope are rejected with ErrOutOfScope before anything is inserted.
+func (r *TransactionRepository) CreateAll(ctx context.Context, transactions []entity.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	for i := range transactions {
+		if err := r.checkScope(ctx, &transactions[i], false); err != nil {
+			return err
+		}
 	}
+	return r.DB.WithContext(ctx).CreateInBatches(transactions, transactionBatchSize).Error
+}
+
+// UpdateCategories moves the transactions listed under each category ID to that category in one database
+// transaction and returns how many it moved. Transactions outside the caller's scope are left as they are.
+func (r *TransactionRepository) UpdateCategories(ctx context.Context, moves map[uint][]uint) (int64, error) {
+	var moved int64
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, categoryID := range slices.Sorted(maps.Keys(moves)) {
+			ids := moves[categoryID]
+			for start := 0; start < len(ids); start += transactionIDBatchSize {
+				end := min(start+transactionIDBatchSize, len(ids))
+				result := r.Scope.Apply(ctx, tx).
+					Model(&entity.Transaction{}).
+					Where("transaction_id IN ?", ids[start:end]).
+					Update("category_id", categoryID)
+				if result.Error != nil {
+					return result.Error
+				}
+				moved += result.RowsAffected
+			}
+		}
+		return nil
+	})
+	return moved, err
+}
+
+// FindExternalIDs returns those of the external IDs that transactions of the account already have
+func (r *TransactionRepository) FindExternalIDs(ctx context.Context, accountID uint, externalIDs []string) ([]string, error) {
+	var found []string
+	for start := 0; start < len(externalIDs); start += externalIDBatchSize {
+		end := min(start+externalIDBatchSize, len(externalIDs))
+		var batch []string
+		if err := r.query(ctx).
+			Model(&entity.Transaction{}).
+			Where("account_id = ? AND external_id IN ?", accountID, externalIDs[start:end]).
+			Pluck("external_id", &batch).Error; err != nil {
+			return nil, err
+		}
+		found = append(found, batch...)
+	}
+	return found, nil
 }
 
 func (r *TransactionRepository) FindByAccountID(ctx context.Context, accountID uint) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	if err := r.DB.WithContext(ctx).
+	if err := r.query(ctx).
 		Preload("Account").
 		Preload("Category").
 		Where("account_id = ?", accountID).
@@ -33,7 +101,7 @@ func (r *TransactionRepository) FindByAccountID(ctx context.Context, accountID u
 
 func (r *TransactionRepository) FindByDateRange(ctx context.Context, start, end time.Time) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	if err := r.DB.WithContext(ctx).
+	if err := r.query(ctx).
 		Preload("Account").
 		Preload("Category").
 		Where("transaction_date BETWEEN ? AND ?", start, end).
@@ -45,10 +113,10 @@ func (r *TransactionRepository) FindByDateRange(ctx context.Context, start, end
 
 func (r *TransactionRepository) FindByDescriptionLike(ctx context.Context, keyword string) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	if err := r.DB.WithContext(ctx).
+	if err := r.query(ctx).
 		Preload("Account").
 		Preload("Category").
-		Where("description IS NOT NULL AND description ILIKE ?", "%"+keyword+"%").
+		Where("description IS NOT NULL AND "+containsCondition(r.DB, "description"), containsPattern(keyword)).
 		Find(&transactions).Error; err != nil {
 		return nil, err
 	}
@@ -61,7 +129,7 @@ func (r *TransactionRepository) FindByAccountAndDateRange(
 	start, end time.Time,
 ) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	if err := r.DB.WithContext(ctx).
+	if err := r.query(ctx).
 		Preload("Account").
 		Preload("Category").
 		Where("account_id = ? AND transaction_date BETWEEN ? AND ?", accountID, start, end).
@@ -72,9 +140,47 @@ func (r *TransactionRepository) FindByAccountAndDateRange(
 	return transactions, nil
 }
 
-func (r *TransactionRepository) SumByAccountID(ctx context.Context, accountID uint) (float64, error) {
-	var sum float64
-	err := r.DB.WithContext(ctx).
+// FindAllPage retrieves a page of transactions ordered by transaction_date, transaction_id
+func (r *TransactionRepository) FindAllPage(ctx context.Context, page PageRequest) (*Page[entity.Transaction], error) {
+	return paginate(r.query(ctx), page, transactionKeyset)
+}
+
+// FindByAccountIDPage retrieves a page of the transactions of an account ordered by transaction_date, transaction_id
+func (r *TransactionRepository) FindByAccountIDPage(ctx context.Context, accountID uint, page PageRequest) (*Page[entity.Transaction], error) {
+	return paginate(r.preloaded(ctx).Where("account_id = ?", accountID), page, transactionKeyset)
+}
+
+// FindByDateRangePage retrieves a page of the transactions within a date range ordered by transaction_date, transaction_id
+func (r *TransactionRepository) FindByDateRangePage(ctx context.Context, start, end time.Time, page PageRequest) (*Page[entity.Transaction], error) {
+	return paginate(r.preloaded(ctx).Where("transaction_date BETWEEN ? AND ?", start, end), page, transactionKeyset)
+}
+
+// FindByDescriptionLikePage retrieves a page of the transactions whose descriptions contain the keyword
+// ordered by transaction_date, transaction_id
+func (r *TransactionRepository) FindByDescriptionLikePage(ctx context.Context, keyword string, page PageRequest) (*Page[entity.Transaction], error) {
+	query := r.preloaded(ctx).Where("description IS NOT NULL AND "+containsCondition(r.DB, "description"), containsPattern(keyword))
+	return paginate(query, page, transactionKeyset)
+}
+
+// FindByAccountAndDateRangePage retrieves a page of the transactions of an account within a date range, newest first
+func (r *TransactionRepository) FindByAccountAndDateRangePage(
+	ctx context.Context,
+	accountID uint,
+	start, end time.Time,
+	page PageRequest,
+) (*Page[entity.Transaction], error) {
+	query := r.preloaded(ctx).Where("account_id = ? AND transaction_date BETWEEN ? AND ?", accountID, start, end)
+	return paginate(query, page, transactionKeyset.descending())
+}
+
+// preloaded returns a scoped query loading the account and category of each transaction
+func (r *TransactionRepository) preloaded(ctx context.Context) *gorm.DB {
+	return r.query(ctx).Preload("Account").Preload("Category")
+}
+
+func (r *TransactionRepository) SumByAccountID(ctx context.Context, accountID uint) (money.Amount, error) {
+	var sum money.Amount
+	err := r.query(ctx).
 		Model(&entity.Transaction{}).
 		Where("account_id = ?", accountID).
 		Select("COALESCE(SUM(amount), 0)").
@@ -84,7 +190,7 @@ func (r *TransactionRepository) SumByAccountID(ctx context.Context, accountID ui
 
 func (r *TransactionRepository) CountByAccountID(ctx context.Context, accountID uint) (int64, error) {
 	var count int64
-	err := r.DB.WithContext(ctx).
+	err := r.query(ctx).
 		Model(&entity.Transaction{}).
 		Where("account_id = ?", accountID).
 		Select("COUNT(*)").
@@ -94,7 +200,7 @@ func (r *TransactionRepository) CountByAccountID(ctx context.Context, accountID
 
 func (r *TransactionRepository) FindLatestForAccount(ctx context.Context, accountID uint, limit int) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	if err := r.DB.WithContext(ctx).
+	if err := r.query(ctx).
 		Preload("Account").
 		Preload("Category").
 		Where("account_id = ?", accountID).
@@ -106,14 +212,48 @@ func (r *TransactionRepository) FindLatestForAccount(ctx context.Context, accoun
 	return transactions, nil
 }
 
-func (r *TransactionRepository) GroupByCategory(ctx context.Context, accountID uint) ([]plain.TransactionSummary, error) {
-	var result []plain.TransactionSummary
-	err := r.DB.WithContext(ctx).
+// NetByCategory sums the amounts of the transactions matching the filter per category ID.
+// Categories without matching transactions are absent. The filter's sort is ignored.
+func (r *TransactionRepository) NetByCategory(ctx context.Context, filter TransactionFilter) (map[uint]money.Amount, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		CategoryID uint
+		Total      money.Amount
+	}
+	err := filter.apply(r.query(ctx).Table("transactions")).
+		Select("transactions.category_id, SUM(transactions.amount) AS total").
+		Group("transactions.category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]money.Amount, len(rows))
+	for _, row := range rows {
+		totals[row.CategoryID] = row.Total
+	}
+	return totals, nil
+}
+
+// DailyTotals sums transactions per day, account and category along with the currency of the account,
+// the granularity at which amounts are converted with the rate of their transaction date.
+// Without account IDs every account in the caller's scope is included.
+func (r *TransactionRepository) DailyTotals(ctx context.Context, accountIDs []uint) ([]plain.DailyTotal, error) {
+	var result []plain.DailyTotal
+	query := r.query(ctx).
 		Table("transactions").
-		Select("c.name as category_name, SUM(t.amount) as total_amount, COUNT(t.transaction_id) as count").
-		Joins("JOIN categories c ON t.category_id = c.category_id").
-		Where("t.account_id = ?", accountID).
-		Group("c.name").
+		Select("transactions.transaction_date, transactions.account_id, accounts.currency, categories.name as category_name, " +
+			"SUM(transactions.amount) as total_amount, COUNT(transactions.transaction_id) as count").
+		Joins("JOIN accounts ON transactions.account_id = accounts.account_id").
+		Joins("JOIN categories ON transactions.category_id = categories.category_id")
+	if len(accountIDs) > 0 {
+		query = query.Where("transactions.account_id IN ?", accountIDs)
+	}
+	err := query.
+		Group("transactions.transaction_date, transactions.account_id, accounts.currency, categories.name").
+		Order("transactions.transaction_date").
 		Scan(&result).Error
 	return result, err
 }
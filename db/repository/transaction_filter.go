@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+// ErrInvalidFilter is returned when a TransactionFilter contradicts itself or names an unknown field
+var ErrInvalidFilter = errors.New("invalid transaction filter")
+
+// TransactionSign selects transactions by the sign of their amount; income is positive, expenses are negative
+type TransactionSign string
+
+const (
+	SignIncome  TransactionSign = "income"
+	SignExpense TransactionSign = "expense"
+)
+
+// TransactionSortField is a column transactions can be sorted by
+type TransactionSortField string
+
+const (
+	SortByDate   TransactionSortField = "transaction_date"
+	SortByAmount TransactionSortField = "amount"
+)
+
+// TransactionSort orders transactions by one field
+type TransactionSort struct {
+	Field TransactionSortField
+	Desc  bool
+}
+
+// TransactionFilter selects transactions for TransactionRepository.Search.
+// Zero fields match every transaction, and every set field must match.
+type TransactionFilter struct {
+	AccountIDs  []uint
+	CategoryIDs []uint
+	// CategoryType matches transactions whose category has this type, Income or Expense
+	CategoryType string
+	// MinAmount and MaxAmount bound the signed amount, inclusive
+	MinAmount *money.Amount
+	MaxAmount *money.Amount
+	// StartDate and EndDate bound the transaction date, inclusive
+	StartDate *time.Time
+	EndDate   *time.Time
+	// Description matches descriptions containing the text, case-insensitively
+	Description string
+	Sign        TransactionSign
+	// Sort orders the results, by transaction_date when empty; ties are broken by transaction_id
+	Sort []TransactionSort
+}
+
+// validate rejects filters that can only match nothing by mistake, such as reversed ranges
+func (f TransactionFilter) validate() error {
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		return fmt.Errorf("%w: min amount is greater than max amount", ErrInvalidFilter)
+	}
+	if f.StartDate != nil && f.EndDate != nil && f.EndDate.Before(*f.StartDate) {
+		return fmt.Errorf("%w: end date is before start date", ErrInvalidFilter)
+	}
+	switch f.Sign {
+	case "", SignIncome, SignExpense:
+	default:
+		return fmt.Errorf("%w: unknown sign %q", ErrInvalidFilter, f.Sign)
+	}
+	return nil
+}
+
+// apply adds the conditions of the filter to a transactions query
+func (f TransactionFilter) apply(db *gorm.DB) *gorm.DB {
+	if len(f.AccountIDs) > 0 {
+		db = db.Where("transactions.account_id IN ?", f.AccountIDs)
+	}
+	if len(f.CategoryIDs) > 0 {
+		db = db.Where("transactions.category_id IN ?", f.CategoryIDs)
+	}
+	if f.CategoryType != "" {
+		// A subquery rather than a join keeps the unqualified columns of the keyset unambiguous
+		categories := db.Session(&gorm.Session{NewDB: true}).
+			Model(&entity.Category{}).
+			Select("category_id").
+			Where("category_type = ?", f.CategoryType)
+		db = db.Where("transactions.category_id IN (?)", categories)
+	}
+	if f.MinAmount != nil {
+		db = db.Where("transactions.amount >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		db = db.Where("transactions.amount <= ?", *f.MaxAmount)
+	}
+	if f.StartDate != nil {
+		db = db.Where("transactions.transaction_date >= ?", *f.StartDate)
+	}
+	if f.EndDate != nil {
+		db = db.Where("transactions.transaction_date <= ?", *f.EndDate)
+	}
+	if f.Description != "" {
+		db = db.Where("transactions.description IS NOT NULL AND "+containsCondition(db, "transactions.description"), containsPattern(f.Description))
+	}
+	switch f.Sign {
+	case SignIncome:
+		db = db.Where("transactions.amount > 0")
+	case SignExpense:
+		db = db.Where("transactions.amount < 0")
+	}
+	return db
+}
+
+// keyset orders the results by the sort fields followed by transaction_id,
+// which takes the direction of the last sort field
+func (f TransactionFilter) keyset() (keyset[entity.Transaction], error) {
+	if len(f.Sort) == 0 {
+		return transactionKeyset, nil
+	}
+
+	seen := make(map[TransactionSortField]bool, len(f.Sort))
+	columns := make([]keyColumn, 0, len(f.Sort)+1)
+	for _, sort := range f.Sort {
+		if seen[sort.Field] {
+			return keyset[entity.Transaction]{}, fmt.Errorf("%w: %s is sorted by twice", ErrInvalidFilter, sort.Field)
+		}
+		seen[sort.Field] = true
+
+		switch sort.Field {
+		case SortByDate:
+			columns = append(columns, transactionDateColumn(sort.Desc))
+		case SortByAmount:
+			columns = append(columns, amountColumn(sort.Desc))
+		default:
+			return keyset[entity.Transaction]{}, fmt.Errorf("%w: cannot sort by %q", ErrInvalidFilter, sort.Field)
+		}
+	}
+	columns = append(columns, idColumn("transaction_id", f.Sort[len(f.Sort)-1].Desc))
+
+	return keyset[entity.Transaction]{columns: columns, cursorOf: transactionCursor}, nil
+}
+
+// Search retrieves a page of the transactions matching the filter, with their account and category
+func (r *TransactionRepository) Search(ctx context.Context, filter TransactionFilter, page PageRequest) (*Page[entity.Transaction], error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	keys, err := filter.keyset()
+	if err != nil {
+		return nil, err
+	}
+	return paginate(filter.apply(r.preloaded(ctx)), page, keys)
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/pkg/money"
+)
+
+var transactionColumns = []string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "created_at", "updated_at"}
+
+func TestTransactionRepository_Search(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := context.Background()
+
+	minAmount, maxAmount := money.MustParse("-500"), money.MustParse("-10")
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := TransactionFilter{
+		AccountIDs:   []uint{1, 2},
+		CategoryType: "Expense",
+		MinAmount:    &minAmount,
+		MaxAmount:    &maxAmount,
+		StartDate:    &start,
+		Description:  "grocery",
+		Sign:         SignExpense,
+	}
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1,$2) `+
+		`AND transactions.category_id IN (SELECT "category_id" FROM "categories" WHERE category_type = $3) `+
+		`AND transactions.amount >= $4 AND transactions.amount <= $5 AND transactions.transaction_date >= $6 `+
+		`AND (transactions.description IS NOT NULL AND transactions.description ILIKE $7 ESCAPE '!') `+
+		`AND transactions.amount < 0 ORDER BY transaction_date ASC,transaction_id ASC LIMIT $8`)).
+		WithArgs(uint(1), uint(2), "Expense", minAmount, maxAmount, start, "%grocery%", 11).
+		WillReturnRows(sqlmock.NewRows(transactionColumns))
+
+	// Test
+	page, err := repo.Search(ctx, filter, PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("Error searching transactions: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("Expected no transactions, got %d", len(page.Items))
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_Search_SortedByAmount(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := context.Background()
+
+	filter := TransactionFilter{
+		CategoryIDs: []uint{4},
+		Sort:        []TransactionSort{{Field: SortByAmount, Desc: true}},
+	}
+	amount := money.MustParse("-20")
+	after := cursor{Keys: "amount DESC,transaction_id DESC", Amount: &amount, ID: 8}.encode()
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.category_id IN ($1) `+
+		`AND (amount < $2 OR (amount = $3 AND transaction_id < $4)) ORDER BY amount DESC,transaction_id DESC LIMIT $5`)).
+		WithArgs(uint(4), amount, amount, uint64(8), 2).
+		WillReturnRows(sqlmock.NewRows(transactionColumns).
+			AddRow(7, 1, 4, -20.0, time.Now(), nil, time.Now(), time.Now()).
+			AddRow(3, 1, 4, -45.5, time.Now(), nil, time.Now(), time.Now()))
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking Account ****0001"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(uint(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(4, "Property Taxes"))
+
+	// Test
+	page, err := repo.Search(ctx, filter, PageRequest{Limit: 1, Cursor: after})
+	if err != nil {
+		t.Fatalf("Error searching transactions: %v", err)
+	}
+	next, err := decodeCursor(page.NextCursor)
+	if err != nil || next == nil || next.ID != 7 || next.Amount == nil || *next.Amount != amount {
+		t.Errorf("Expected next cursor after transaction 7, got %+v (%v)", next, err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_Search_InvalidFilter(t *testing.T) {
+	// Setup
+	_, _, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+
+	minAmount, maxAmount := money.MustParse("10"), money.MustParse("5")
+	start := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter TransactionFilter
+	}{
+		{name: "reversed amounts", filter: TransactionFilter{MinAmount: &minAmount, MaxAmount: &maxAmount}},
+		{name: "reversed dates", filter: TransactionFilter{StartDate: &start, EndDate: &end}},
+		{name: "unknown sign", filter: TransactionFilter{Sign: "refund"}},
+		{name: "unknown sort field", filter: TransactionFilter{Sort: []TransactionSort{{Field: "description"}}}},
+		{name: "duplicate sort field", filter: TransactionFilter{Sort: []TransactionSort{{Field: SortByAmount}, {Field: SortByAmount, Desc: true}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repo.Search(context.Background(), tt.filter, PageRequest{}); !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("Expected ErrInvalidFilter, got %v", err)
+			}
+		})
+	}
+}
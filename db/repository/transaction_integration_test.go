@@ -0,0 +1,242 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/db/repository/plain"
+	"sample-mcp/pkg/money"
+)
+
+// TestTransactionRepository_SumByAccountID_Exact checks that the database sum of each seeded account
+// equals the sum of its transactions added up in Go, to the cent
+func TestTransactionRepository_SumByAccountID_Exact(t *testing.T) {
+	repo := repository.NewTransactionRepository(TestDB)
+	ctx := context.Background()
+
+	for _, accountID := range []uint{1, 4, 55, 67, 101} {
+		transactions, err := repo.FindByAccountID(ctx, accountID)
+		require.NoError(t, err)
+
+		var expected money.Amount
+		for _, transaction := range transactions {
+			expected += transaction.Amount
+		}
+
+		sum, err := repo.SumByAccountID(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, sum, "account %d", accountID)
+	}
+}
+
+// TestTransactionRepository_CashFlow_Buckets checks the database's date truncation against buckets computed in Go
+// from the seeded transactions of one account
+func TestTransactionRepository_CashFlow_Buckets(t *testing.T) {
+	repo := repository.NewTransactionRepository(TestDB)
+	ctx := context.Background()
+	accountID := uint(67)
+
+	transactions, err := repo.FindByAccountID(ctx, accountID)
+	require.NoError(t, err)
+	require.NotEmpty(t, transactions)
+
+	starts := map[repository.CashFlowInterval]func(time.Time) time.Time{
+		repository.IntervalWeek: func(d time.Time) time.Time {
+			return d.AddDate(0, 0, -(int(d.Weekday())+6)%7)
+		},
+		repository.IntervalMonth: func(d time.Time) time.Time {
+			return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+		},
+		repository.IntervalQuarter: func(d time.Time) time.Time {
+			return time.Date(d.Year(), (d.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+		},
+		repository.IntervalYear: func(d time.Time) time.Time {
+			return time.Date(d.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		},
+	}
+
+	for interval, start := range starts {
+		t.Run(string(interval), func(t *testing.T) {
+			expected := make(map[string]money.Amount)
+			for _, transaction := range transactions {
+				d := transaction.TransactionDate
+				key := start(time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)).Format(time.DateOnly)
+				expected[key] += transaction.Amount
+			}
+
+			flows, err := repo.CashFlow(ctx, repository.TransactionFilter{AccountIDs: []uint{accountID}}, interval, repository.GroupByNone)
+			require.NoError(t, err)
+
+			actual := make(map[string]money.Amount)
+			for _, flow := range flows {
+				actual[flow.PeriodStart.Format(time.DateOnly)] = flow.Net
+				assert.Equal(t, flow.Net, flow.Income+flow.Expenses)
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+// TestTransactionRepository_Balances_Agree checks that the running balances, the balance as of a day and
+// the daily series tell the same story for a seeded account
+func TestTransactionRepository_Balances_Agree(t *testing.T) {
+	repo := repository.NewTransactionRepository(TestDB)
+	ctx := context.Background()
+	accountID := uint(67)
+
+	total, err := repo.SumByAccountID(ctx, accountID)
+	require.NoError(t, err)
+
+	var (
+		last    *time.Time
+		balance money.Amount
+		page    = repository.PageRequest{Limit: 7}
+	)
+	for {
+		balances, err := repo.FindRunningBalancesPage(ctx, accountID, nil, nil, page)
+		require.NoError(t, err)
+		for _, row := range balances.Items {
+			balance += row.Amount
+			require.Equal(t, balance, row.RunningBalance, "transaction %d", row.TransactionID)
+			date := row.TransactionDate
+			last = &date
+		}
+		if balances.NextCursor == "" {
+			break
+		}
+		page.Cursor = balances.NextCursor
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, total, balance)
+
+	asOf, err := repo.SumByAccountIDAsOf(ctx, accountID, *last)
+	require.NoError(t, err)
+	assert.Equal(t, total, asOf)
+
+	series, err := repo.DailyBalances(ctx, accountID, last.AddDate(0, 0, -30), *last)
+	require.NoError(t, err)
+	require.Len(t, series, 31)
+	assert.Equal(t, total, series[30].Balance)
+
+	before, err := repo.SumByAccountIDAsOf(ctx, accountID, last.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, before, series[0].Balance)
+}
+
+// TestTransactionRepository_CategoryBreakdown_Seed checks the category breakdown of seeded accounts
+// against the statistics of their transactions computed in Go
+func TestTransactionRepository_CategoryBreakdown_Seed(t *testing.T) {
+	repo := repository.NewTransactionRepository(TestDB)
+	ctx := context.Background()
+	accountIDs := []uint{4, 55, 67}
+
+	var transactions []entity.Transaction
+	for _, accountID := range accountIDs {
+		found, err := repo.FindByAccountID(ctx, accountID)
+		require.NoError(t, err)
+		transactions = append(transactions, found...)
+	}
+	require.NotEmpty(t, transactions)
+
+	// Statistics per category on each side, keyed by whether the amounts are income
+	expected := map[bool]map[uint]*plain.CategoryStats{true: {}, false: {}}
+	for _, transaction := range transactions {
+		side := expected[transaction.Amount > 0]
+		stats, ok := side[transaction.CategoryID]
+		if !ok {
+			stats = &plain.CategoryStats{
+				CategoryName: transaction.Category.Name,
+				CategoryType: transaction.Category.CategoryType,
+				Min:          transaction.Amount,
+				Max:          transaction.Amount,
+			}
+			side[transaction.CategoryID] = stats
+		}
+		stats.Total += transaction.Amount
+		stats.Count++
+		stats.Min = min(stats.Min, transaction.Amount)
+		stats.Max = max(stats.Max, transaction.Amount)
+	}
+
+	breakdown, err := repo.CategoryBreakdown(ctx, repository.TransactionFilter{AccountIDs: accountIDs})
+	require.NoError(t, err)
+
+	for income, categories := range map[bool][]plain.CategoryStats{true: breakdown.Income, false: breakdown.Expenses} {
+		require.Len(t, categories, len(expected[income]), "income %t", income)
+		var total money.Amount
+		var percentage float64
+		for _, stats := range categories {
+			want, ok := expected[income][stats.CategoryID]
+			require.True(t, ok, "unexpected category %d", stats.CategoryID)
+			assert.Equal(t, want.CategoryName, stats.CategoryName)
+			assert.Equal(t, want.CategoryType, stats.CategoryType)
+			assert.Equal(t, want.Total, stats.Total, want.CategoryName)
+			assert.Equal(t, want.Count, stats.Count, want.CategoryName)
+			assert.Equal(t, want.Min, stats.Min, want.CategoryName)
+			assert.Equal(t, want.Max, stats.Max, want.CategoryName)
+			total += stats.Total
+			percentage += stats.Percentage
+		}
+		if len(categories) > 0 {
+			assert.InDelta(t, 100, percentage, 0.1, "income %t", income)
+		}
+	}
+	assert.Equal(t, breakdown.TotalIncome+breakdown.TotalExpenses, breakdown.Net)
+
+	// Account 4 has two expenses in the Property Taxes category
+	property := expected[false][4]
+	require.NotNil(t, property)
+	assert.Equal(t, int64(2), property.Count)
+	for _, stats := range breakdown.Expenses {
+		if stats.CategoryID == 4 {
+			assert.Equal(t, money.MustParse("-1296.64"), stats.Total)
+			assert.Equal(t, money.MustParse("-648.32"), stats.Average)
+			assert.Equal(t, money.MustParse("-932.69"), stats.Min)
+			assert.Equal(t, money.MustParse("-363.95"), stats.Max)
+		}
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC)
+	quarter, err := repo.CategoryBreakdown(ctx, repository.TransactionFilter{AccountIDs: accountIDs, StartDate: &start, EndDate: &end})
+	require.NoError(t, err)
+	var inQuarter money.Amount
+	for _, transaction := range transactions {
+		if !transaction.TransactionDate.Before(start) && !transaction.TransactionDate.After(end) {
+			inQuarter += transaction.Amount
+		}
+	}
+	assert.Equal(t, inQuarter, quarter.Net)
+}
+
+// TestTransactionRepository_GroupByCategory_Seed checks that the per-category sums of each seeded account add up to
+// its balance, both sides of a category included
+func TestTransactionRepository_GroupByCategory_Seed(t *testing.T) {
+	repo := repository.NewTransactionRepository(TestDB)
+	ctx := context.Background()
+
+	for _, accountID := range []uint{1, 4, 55} {
+		summaries, err := repo.GroupByCategory(ctx, accountID)
+		require.NoError(t, err)
+		count, err := repo.CountByAccountID(ctx, accountID)
+		require.NoError(t, err)
+		balance, err := repo.SumByAccountID(ctx, accountID)
+		require.NoError(t, err)
+
+		var total money.Amount
+		var transactions int64
+		for _, summary := range summaries {
+			total += summary.TotalAmount
+			transactions += summary.Count
+		}
+		assert.Equal(t, balance, total, "account %d", accountID)
+		assert.Equal(t, count, transactions, "account %d", accountID)
+	}
+}
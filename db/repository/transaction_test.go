@@ -20,6 +20,8 @@
 // - CountByAccountID: Tests counting transactions for an account
 // - FindLatestForAccount: Tests finding the latest transactions for an account with a limit
 // - GroupByCategory: Tests grouping transactions by category with sum and count
+// - DailyTotals: Tests summing transactions per day, account and category with the account currency
+// - NetByCategory: Tests summing the transactions matching a filter per category ID
 //
 // Each test sets up expectations for SQL queries and verifies that the repository methods
 // interact with the database as expected.
@@ -35,6 +37,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"gorm.io/gorm"
 	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
 )
 
 // setupMockDB is already defined in account_test.go and is reused here
@@ -53,7 +56,7 @@ func TestTransactionRepository_Create(t *testing.T) {
 	transaction := &entity.Transaction{
 		AccountID:       1,
 		CategoryID:      2,
-		Amount:          100.50,
+		Amount:          money.MustParse("100.50"),
 		TransactionDate: transactionDate,
 		Description:     &description,
 		CreatedAt:       time.Now(),
@@ -62,8 +65,8 @@ func TestTransactionRepository_Create(t *testing.T) {
 
 	// Expectations
 	mock.ExpectBegin()
-	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions" ("account_id","category_id","amount","transaction_date","description","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "created_at","updated_at","transaction_id"`)).
-		WithArgs(transaction.AccountID, transaction.CategoryID, transaction.Amount, transaction.TransactionDate, transaction.Description, sqlmock.AnyArg(), sqlmock.AnyArg()).
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions" ("account_id","category_id","amount","transaction_date","description","external_id","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "created_at","updated_at","transaction_id"`)).
+		WithArgs(transaction.AccountID, transaction.CategoryID, transaction.Amount, transaction.TransactionDate, transaction.Description, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "transaction_id"}).AddRow(time.Now(), time.Now(), 1))
 	mock.ExpectCommit()
 
@@ -194,7 +197,7 @@ func TestTransactionRepository_Update(t *testing.T) {
 		TransactionID:   1,
 		AccountID:       1,
 		CategoryID:      2,
-		Amount:          150.75,
+		Amount:          money.MustParse("150.75"),
 		TransactionDate: transactionDate,
 		Description:     &description,
 		UpdatedAt:       time.Now(),
@@ -202,8 +205,8 @@ func TestTransactionRepository_Update(t *testing.T) {
 
 	// Expectations
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "account_id"=$1,"category_id"=$2,"amount"=$3,"transaction_date"=$4,"description"=$5,"created_at"=$6,"updated_at"=$7 WHERE "transaction_id" = $8`)).
-		WithArgs(transaction.AccountID, transaction.CategoryID, transaction.Amount, transaction.TransactionDate, transaction.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), transaction.TransactionID).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "account_id"=$1,"category_id"=$2,"amount"=$3,"transaction_date"=$4,"description"=$5,"external_id"=$6,"created_at"=$7,"updated_at"=$8 WHERE "transaction_id" = $9`)).
+		WithArgs(transaction.AccountID, transaction.CategoryID, transaction.Amount, transaction.TransactionDate, transaction.Description, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), transaction.TransactionID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -286,12 +289,12 @@ func TestTransactionRepository_SumByAccountID(t *testing.T) {
 	repo := NewTransactionRepository(gormDB)
 	ctx := context.Background()
 	accountID := uint(1)
-	expectedSum := 300.25
+	expectedSum := money.MustParse("300.25")
 
 	// Expectations
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1`)).
 		WithArgs(accountID).
-		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(expectedSum))
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("300.25"))
 
 	// Test
 	sum, err := repo.SumByAccountID(ctx, accountID)
@@ -300,7 +303,7 @@ func TestTransactionRepository_SumByAccountID(t *testing.T) {
 	}
 
 	if sum != expectedSum {
-		t.Errorf("Expected sum %f, got %f", expectedSum, sum)
+		t.Errorf("Expected sum %s, got %s", expectedSum, sum)
 	}
 
 	// Verify expectations
@@ -339,3 +342,73 @@ func TestTransactionRepository_CountByAccountID(t *testing.T) {
 		t.Errorf("Unfulfilled expectations: %v", err)
 	}
 }
+
+func TestTransactionRepository_DailyTotals(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	ctx := context.Background()
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transactions.transaction_date, transactions.account_id, accounts.currency, categories.name as category_name, `+
+		`SUM(transactions.amount) as total_amount, COUNT(transactions.transaction_id) as count FROM "transactions" `+
+		`JOIN accounts ON transactions.account_id = accounts.account_id JOIN categories ON transactions.category_id = categories.category_id `+
+		`WHERE transactions.account_id IN ($1,$2) `+
+		`GROUP BY transactions.transaction_date, transactions.account_id, accounts.currency, categories.name ORDER BY transactions.transaction_date`)).
+		WithArgs(uint(1), uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_date", "account_id", "currency", "category_name", "total_amount", "count"}).
+			AddRow(date, 1, "USD", "Groceries", "-45.10", 2).
+			AddRow(date, 2, "EUR", "Groceries", "-12.00", 1))
+
+	// Test
+	totals, err := repo.DailyTotals(ctx, []uint{1, 2})
+	if err != nil {
+		t.Fatalf("Error summing daily totals: %v", err)
+	}
+
+	if len(totals) != 2 {
+		t.Fatalf("Expected 2 totals, got %d", len(totals))
+	}
+	if totals[1].Currency != "EUR" || totals[1].TotalAmount != money.MustParse("-12") || totals[1].Count != 1 {
+		t.Errorf("Unexpected total %+v", totals[1])
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransactionRepository_NetByCategory(t *testing.T) {
+	// Setup
+	_, mock, gormDB, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTransactionRepository(gormDB)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transactions.category_id, SUM(transactions.amount) AS total FROM "transactions" `+
+		`WHERE transactions.category_id IN ($1,$2) AND transactions.transaction_date >= $3 GROUP BY "transactions"."category_id"`)).
+		WithArgs(uint(3), uint(5), start).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "total"}).
+			AddRow(3, "-45.10"))
+
+	// Test
+	totals, err := repo.NetByCategory(context.Background(), TransactionFilter{CategoryIDs: []uint{3, 5}, StartDate: &start})
+	if err != nil {
+		t.Fatalf("Error summing per category: %v", err)
+	}
+
+	if len(totals) != 1 || totals[3] != money.MustParse("-45.10") {
+		t.Errorf("Unexpected totals %v", totals)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"sample-mcp/pkg/money"
+)
+
+// seedAmount matches the account ID and amount of a seeded transaction row
+var seedAmount = regexp.MustCompile(`(?m)^\s*(?:VALUES\s*)?\((\d+), \d+, (-?\d+\.\d{2}), '\d{4}-\d{2}-\d{2}`)
+
+// TestSeedSumsAreExact sums every seeded transaction amount, per account and in total,
+// and checks the result against arbitrary precision decimal arithmetic
+func TestSeedSumsAreExact(t *testing.T) {
+	f, err := migrationsFS.Open("migrations/postgres/000001_schema.up.sql")
+	if err != nil {
+		t.Fatalf("Failed to open seed data: %v", err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("Failed to read seed data: %v", err)
+	}
+
+	matches := seedAmount.FindAllStringSubmatch(string(data), -1)
+	if len(matches) < 100 {
+		t.Fatalf("Expected hundreds of seeded transactions, found %d", len(matches))
+	}
+
+	var (
+		total        money.Amount
+		totalDecimal = decimal.Zero
+		totalFloat   float64
+		accounts     = make(map[string]money.Amount)
+		decimals     = make(map[string]decimal.Decimal)
+	)
+	for _, m := range matches {
+		account, text := m[1], m[2]
+		amount := money.MustParse(text)
+		exact := decimal.RequireFromString(text)
+
+		total += amount
+		totalDecimal = totalDecimal.Add(exact)
+		totalFloat += amount.Float64()
+		accounts[account] += amount
+		decimals[account] = decimals[account].Add(exact)
+	}
+
+	if !total.Decimal().Equal(totalDecimal) {
+		t.Errorf("Total %s differs from the exact sum %s", total, totalDecimal)
+	}
+	for account, sum := range accounts {
+		if !sum.Decimal().Equal(decimals[account]) {
+			t.Errorf("Account %s: sum %s differs from the exact sum %s", account, sum, decimals[account])
+		}
+	}
+	t.Logf("%d transactions, total %s (float64 sum %.10f)", len(matches), total, totalFloat)
+}
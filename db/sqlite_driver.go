@@ -0,0 +1,142 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang-migrate/migrate/v4/database"
+)
+
+// sqliteMigrationsTable records the applied version in the same layout as the other golang-migrate drivers
+const sqliteMigrationsTable = "schema_migrations"
+
+// sqliteDriver is a golang-migrate driver running on the SQLite connection opened by pkg/db.
+// golang-migrate's own sqlite driver links modernc.org/sqlite, which registers the same
+// database/sql driver name as the SQLite driver used by pkg/db, so the two cannot share a binary.
+type sqliteDriver struct {
+	db     *sql.DB
+	mu     sync.Mutex
+	locked bool
+}
+
+// newSqliteDriver creates the migrations table if needed and returns the driver
+func newSqliteDriver(db *sql.DB) (database.Driver, error) {
+	query := `CREATE TABLE IF NOT EXISTS ` + sqliteMigrationsTable + ` (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+	if _, err := db.Exec(query); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return &sqliteDriver{db: db}, nil
+}
+
+func (d *sqliteDriver) Open(string) (database.Driver, error) {
+	return nil, errors.New("the sqlite migration driver only runs on an existing connection")
+}
+
+// Close leaves the connection open, since it belongs to the gorm pool
+func (d *sqliteDriver) Close() error {
+	return nil
+}
+
+// Lock guards against concurrent migrations within the process; SQLite serializes writers itself
+func (d *sqliteDriver) Lock() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.locked {
+		return database.ErrLocked
+	}
+	d.locked = true
+	return nil
+}
+
+func (d *sqliteDriver) Unlock() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.locked {
+		return database.ErrNotLocked
+	}
+	d.locked = false
+	return nil
+}
+
+// Run applies a migration file in a single transaction
+func (d *sqliteDriver) Run(migration io.Reader) error {
+	query, err := io.ReadAll(migration)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(string(query)); err != nil {
+		_ = tx.Rollback()
+		return &database.Error{OrigErr: err, Query: query, Err: "migration failed"}
+	}
+	return tx.Commit()
+}
+
+func (d *sqliteDriver) SetVersion(version int, dirty bool) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM ` + sqliteMigrationsTable); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// A nil version is only recorded while dirty, like the other drivers do
+	if version >= 0 || (version == database.NilVersion && dirty) {
+		if _, err := tx.Exec(`INSERT INTO `+sqliteMigrationsTable+` (version, dirty) VALUES (?, ?)`, version, dirty); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (d *sqliteDriver) Version() (int, bool, error) {
+	var (
+		version int
+		dirty   bool
+	)
+	err := d.db.QueryRow(`SELECT version, dirty FROM `+sqliteMigrationsTable+` LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
+
+// Drop drops every table, including the migrations table
+func (d *sqliteDriver) Drop() error {
+	rows, err := d.db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			rows.Close()
+			return err
+		}
+		tables = append(tables, table)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := d.db.Exec(`DROP TABLE IF EXISTS "` + table + `"`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
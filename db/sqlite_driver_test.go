@@ -0,0 +1,122 @@
+package db
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-migrate/migrate/v4/database"
+)
+
+func newMockSqliteDriver(t *testing.T) (database.Driver, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	driver, err := newSqliteDriver(mockDB)
+	if err != nil {
+		t.Fatalf("Failed to create sqlite driver: %v", err)
+	}
+	return driver, mock
+}
+
+func TestSqliteDriver_Version(t *testing.T) {
+	driver, mock := newMockSqliteDriver(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, dirty FROM schema_migrations LIMIT 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, dirty FROM schema_migrations LIMIT 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+
+	version, dirty, err := driver.Version()
+	if err != nil || version != database.NilVersion || dirty {
+		t.Errorf("Expected nil version before any migration, got %d, %v, %v", version, dirty, err)
+	}
+
+	version, dirty, err = driver.Version()
+	if err != nil || version != 1 || dirty {
+		t.Errorf("Expected version 1, got %d, %v, %v", version, dirty, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSqliteDriver_SetVersion(t *testing.T) {
+	driver, mock := newMockSqliteDriver(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)`)).
+		WithArgs(1, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// Resetting to the nil version leaves the table empty
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := driver.SetVersion(1, true); err != nil {
+		t.Errorf("Error setting version: %v", err)
+	}
+	if err := driver.SetVersion(database.NilVersion, false); err != nil {
+		t.Errorf("Error resetting version: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSqliteDriver_Run(t *testing.T) {
+	driver, mock := newMockSqliteDriver(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE accounts (account_id INTEGER PRIMARY KEY);`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE broken`)).WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+
+	if err := driver.Run(strings.NewReader(`CREATE TABLE accounts (account_id INTEGER PRIMARY KEY);`)); err != nil {
+		t.Errorf("Error running migration: %v", err)
+	}
+
+	err := driver.Run(strings.NewReader(`CREATE TABLE broken`))
+	var migrationErr *database.Error
+	if !errors.As(err, &migrationErr) {
+		t.Errorf("Expected a database.Error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSqliteDriver_Lock(t *testing.T) {
+	driver, _ := newMockSqliteDriver(t)
+
+	if err := driver.Lock(); err != nil {
+		t.Fatalf("Error acquiring lock: %v", err)
+	}
+	if err := driver.Lock(); !errors.Is(err, database.ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+	if err := driver.Unlock(); err != nil {
+		t.Errorf("Error releasing lock: %v", err)
+	}
+	if err := driver.Unlock(); !errors.Is(err, database.ErrNotLocked) {
+		t.Errorf("Expected ErrNotLocked, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/repository"
+	"sample-mcp/ops"
+)
+
+// runExport writes the transactions matching the filter flags to a file, or to standard output without -output:
+//
+//	sample-mcp export -format xlsx -output march.xlsx -start 2024-03-01 -end 2024-03-31 -account-ids 1,2
+//
+// The command runs with the database owner's rights, so the account scopes of the server do not apply.
+func runExport(pool *gorm.DB, args []string, logger *log.Logger) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "csv", "format of the file: csv, ndjson or xlsx")
+	output := flags.String("output", "", "file to write, standard output when omitted")
+	accountIDs := flags.String("account-ids", "", "comma-separated IDs of the accounts to export")
+	categoryIDs := flags.String("category-ids", "", "comma-separated IDs of the categories to export")
+	categoryType := flags.String("category-type", "", "only transactions whose category has this type, Income or Expense")
+	start := flags.String("start", "", "earliest transaction date, inclusive, like 2024-03-01")
+	end := flags.String("end", "", "latest transaction date, inclusive")
+	description := flags.String("description", "", "text the descriptions must contain, case-insensitive")
+	sign := flags.String("sign", "", "only income or expense amounts")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	filter := repository.TransactionFilter{
+		CategoryType: *categoryType,
+		Description:  *description,
+		Sign:         repository.TransactionSign(*sign),
+	}
+	var err error
+	if filter.AccountIDs, err = parseIDs(*accountIDs); err != nil {
+		return fmt.Errorf("-account-ids: %w", err)
+	}
+	if filter.CategoryIDs, err = parseIDs(*categoryIDs); err != nil {
+		return fmt.Errorf("-category-ids: %w", err)
+	}
+	if filter.StartDate, err = parseDateFlag(*start); err != nil {
+		return fmt.Errorf("-start: %w", err)
+	}
+	if filter.EndDate, err = parseDateFlag(*end); err != nil {
+		return fmt.Errorf("-end: %w", err)
+	}
+
+	queryOps, err := ops.NewQueryOps(ops.WithGormDB(pool))
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		count, err := export(queryOps, filter, ops.ExportFormat(*format), os.Stdout)
+		if err != nil {
+			return err
+		}
+		logger.Printf("Exported %d transactions", count)
+		return nil
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	count, err := export(queryOps, filter, ops.ExportFormat(*format), file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// An incomplete file is of no use, an XLSX file not even readable
+		return errors.Join(err, os.Remove(*output))
+	}
+	logger.Printf("Exported %d transactions to %s", count, *output)
+	return nil
+}
+
+// export writes the transactions through a buffer, which ExportTransactions leaves to the caller
+func export(queryOps *ops.QueryOps, filter repository.TransactionFilter, format ops.ExportFormat, w io.Writer) (int, error) {
+	buffered := bufio.NewWriter(w)
+	count, err := queryOps.ExportTransactions(context.Background(), filter, format, buffered)
+	if err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+// parseIDs reads a comma-separated list of IDs, nil when it is empty
+func parseIDs(s string) ([]uint, error) {
+	var ids []uint
+	for _, field := range strings.Split(s, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(field, 10, 0)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%q is not an ID", field)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// parseDateFlag reads an optional date like 2024-03-01
+func parseDateFlag(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a date like 2024-03-01", s)
+	}
+	return &date, nil
+}
@@ -0,0 +1,477 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"sample-mcp/importer"
+	"sample-mcp/ops"
+	"sample-mcp/pkg/money"
+)
+
+// DryRunInput asks a write tool to report the change without making it
+type DryRunInput struct {
+	DryRun bool `json:"dry_run" description:"Only return the change that would be made, without saving it" default:"false"`
+}
+
+// RecordTransactionInput holds the fields of a new transaction
+type RecordTransactionInput struct {
+	AccountID       uint         `json:"account_id" description:"The ID of the account" validate:"required"`
+	CategoryID      uint         `json:"category_id" description:"The ID of the category" validate:"required"`
+	Amount          money.Amount `json:"amount" description:"The amount with at most two decimals, negative for money leaving the account" validate:"required"`
+	TransactionDate Date         `json:"transaction_date" description:"The date of the transaction" validate:"required"`
+	Description     string       `json:"description" description:"An optional description" validate:"max=500"`
+	DryRunInput
+}
+
+// RecategorizeTransactionInput moves a transaction to another category
+type RecategorizeTransactionInput struct {
+	TransactionIDInput
+	CategoryID uint `json:"category_id" description:"The ID of the new category" validate:"required"`
+	DryRunInput
+}
+
+// EditTransactionDescriptionInput replaces the description of a transaction
+type EditTransactionDescriptionInput struct {
+	TransactionIDInput
+	Description string `json:"description" description:"The new description, empty to clear it" validate:"max=500"`
+	DryRunInput
+}
+
+// DeleteTransactionInput identifies the transaction to delete
+type DeleteTransactionInput struct {
+	TransactionIDInput
+	DryRunInput
+}
+
+// CreateBudgetInput holds the fields of a new budget
+type CreateBudgetInput struct {
+	CategoryID uint         `json:"category_id" description:"The ID of the category whose spending is limited" validate:"required"`
+	Period     string       `json:"period" description:"The period the amount is for; weeks start on Monday" validate:"oneof=week month quarter year" default:"month"`
+	Amount     money.Amount `json:"amount" description:"The most that may be spent per period, positive with at most two decimals" validate:"required"`
+	Rollover   bool         `json:"rollover" description:"Carry the unspent amount, or the overspending, of each period over to the next" default:"false"`
+	StartDate  Date         `json:"start_date" description:"A day of the first period of the budget, today when omitted"`
+	DryRunInput
+}
+
+// ImportTransactionsInput holds a bank's CSV export and the mapping of its columns.
+// Columns are named by their header or by their 1-based number.
+type ImportTransactionsInput struct {
+	Content            string   `json:"content" description:"The contents of the CSV file" validate:"required,max=10000000"`
+	Account            string   `json:"account" description:"Name of the account of every line, unless account_column is mapped"`
+	Category           string   `json:"category" description:"Name of the category of lines no categorization rule matches, unless category_column is mapped"`
+	Delimiter          string   `json:"delimiter" description:"The field separator, such as ; or a tab" validate:"max=1" default:","`
+	NoHeader           bool     `json:"no_header" description:"The first row holds data instead of column names" default:"false"`
+	SkipLines          int      `json:"skip_lines" description:"Rows to skip before the header, such as an account summary" validate:"min=0,max=100"`
+	DateColumn         string   `json:"date_column" description:"The column of the booking date" validate:"required"`
+	DateFormat         string   `json:"date_format" description:"The date format spelled with YYYY, YY, MM and DD, e.g. DD.MM.YYYY" default:"YYYY-MM-DD"`
+	AmountColumn       string   `json:"amount_column" description:"The column of the amount, unless debit_column and credit_column are mapped"`
+	DebitColumn        string   `json:"debit_column" description:"The column of money leaving the account, mapped together with credit_column"`
+	CreditColumn       string   `json:"credit_column" description:"The column of money arriving in the account, mapped together with debit_column"`
+	IndicatorColumn    string   `json:"indicator_column" description:"A column telling debits from credits when the amounts are unsigned"`
+	DebitIndicators    []string `json:"debit_indicators" description:"The values of indicator_column marking debits, e.g. D or DBIT" validate:"max=10"`
+	Invert             bool     `json:"invert" description:"Positive amounts leave the account, as in many credit card exports" default:"false"`
+	DecimalComma       bool     `json:"decimal_comma" description:"Amounts are written like 1.234,56" default:"false"`
+	DescriptionColumns []string `json:"description_columns" description:"Columns joined into the description, such as payee and reference" validate:"max=10"`
+	AccountColumn      string   `json:"account_column" description:"A column naming the account of each line"`
+	CategoryColumn     string   `json:"category_column" description:"A column naming the category of each line"`
+	DryRunInput
+}
+
+// mapping converts the input into the CSV mapping of the importer
+func (in ImportTransactionsInput) mapping() importer.CSVMapping {
+	return importer.CSVMapping{
+		Delimiter:       in.Delimiter,
+		NoHeader:        in.NoHeader,
+		SkipLines:       in.SkipLines,
+		Date:            in.DateColumn,
+		DateFormat:      in.DateFormat,
+		Amount:          in.AmountColumn,
+		Debit:           in.DebitColumn,
+		Credit:          in.CreditColumn,
+		Indicator:       in.IndicatorColumn,
+		DebitIndicators: in.DebitIndicators,
+		Invert:          in.Invert,
+		DecimalComma:    in.DecimalComma,
+		Description:     in.DescriptionColumns,
+		Account:         in.AccountColumn,
+		Category:        in.CategoryColumn,
+	}
+}
+
+// ImportStatementInput holds an OFX, QFX, QIF or camt.053 bank statement file
+type ImportStatementInput struct {
+	Content      string   `json:"content" description:"The contents of the statement file" validate:"required,max=10000000"`
+	Format       string   `json:"format" description:"The format of the file" validate:"required,oneof=ofx qfx qif camt053"`
+	Account      string   `json:"account" description:"Name of the account of a file holding a single statement"`
+	Category     string   `json:"category" description:"Name of the category of the transactions no categorization rule matches"`
+	AccountMap   []string `json:"account_map" description:"Accounts of the statements as number=name pairs, e.g. 000123456789=Checking" validate:"max=50"`
+	DayFirst     bool     `json:"day_first" description:"QIF dates are written day first, like 31/12/2024" default:"false"`
+	DecimalComma bool     `json:"decimal_comma" description:"QIF amounts are written like 1.234,56" default:"false"`
+	DryRunInput
+}
+
+// CreateCategorizationRuleInput holds the category and the conditions of a new categorization rule;
+// a transaction must meet every condition given
+type CreateCategorizationRuleInput struct {
+	Name                string        `json:"name" description:"A name describing the rule" validate:"required,max=100"`
+	CategoryID          uint          `json:"category_id" description:"The ID of the category the rule assigns" validate:"required"`
+	Priority            int           `json:"priority" description:"Rules are tried by descending priority, then in the order they were created; the first matching rule wins" default:"0"`
+	AccountID           uint          `json:"account_id" description:"Only transactions of this account"`
+	DescriptionContains string        `json:"description_contains" description:"Text the description must contain, case-insensitive" validate:"max=255"`
+	DescriptionPattern  string        `json:"description_pattern" description:"A regular expression in RE2 syntax the description must match, case-insensitive, e.g. ^(shell|bp) " validate:"max=255"`
+	Counterparty        string        `json:"counterparty" description:"The counterparty the description must start with, case-insensitive, e.g. Netflix" validate:"max=255"`
+	MinAmount           *money.Amount `json:"min_amount" description:"Minimum signed amount, inclusive; expenses are negative"`
+	MaxAmount           *money.Amount `json:"max_amount" description:"Maximum signed amount, inclusive; expenses are negative"`
+	DryRunInput
+}
+
+// Validate checks that the amount range is not reversed
+func (in CreateCategorizationRuleInput) Validate() error {
+	if in.MinAmount != nil && in.MaxAmount != nil && *in.MinAmount > *in.MaxAmount {
+		return fmt.Errorf("invalid amount range: min_amount is greater than max_amount")
+	}
+	return nil
+}
+
+// CreateCategorizationRuleFromTransactionInput describes a categorization rule by a transaction it should match
+type CreateCategorizationRuleFromTransactionInput struct {
+	TransactionIDInput
+	CategoryID      uint     `json:"category_id" description:"The ID of the category the rule assigns, the transaction's category when omitted"`
+	Match           []string `json:"match" description:"The properties of the transaction the rule matches, counterparty when omitted: counterparty (the words its description starts with), description (its whole description), account or amount (within amount_tolerance)" validate:"max=4,dive,oneof=counterparty description account amount"`
+	AmountTolerance int      `json:"amount_tolerance" description:"How far matched amounts may differ from the transaction's, in percent of it" validate:"min=0,max=100" default:"10"`
+	Name            string   `json:"name" description:"A name describing the rule, the matched counterparty or description when omitted" validate:"max=100"`
+	Priority        int      `json:"priority" description:"Rules are tried by descending priority, then in the order they were created; the first matching rule wins" default:"0"`
+	DryRunInput
+}
+
+// ApplyCategorizationRulesInput selects the recorded transactions the categorization rules are run over
+type ApplyCategorizationRulesInput struct {
+	TransactionFilterInput
+	DryRunInput
+}
+
+// RefreshRecurringPaymentsInput selects the accounts whose recurring payments are detected and stored
+type RefreshRecurringPaymentsInput struct {
+	AccountIDs []uint `json:"account_ids" description:"Only these accounts, every account when omitted" validate:"max=50"`
+	DryRunInput
+}
+
+// CommandHandler exposes the CommandOps write operations as MCP tools
+type CommandHandler struct {
+	commandOps *ops.CommandOps
+}
+
+// NewCommandHandler creates a CommandHandler backed by the given CommandOps
+func NewCommandHandler(commandOps *ops.CommandOps) *CommandHandler {
+	return &CommandHandler{commandOps: commandOps}
+}
+
+// Register adds all CommandHandler tools to the registry
+func (h *CommandHandler) Register(r *Registry) error {
+	return errors.Join(
+		Register(r, "record_transaction", "Records a new transaction", h.RecordTransaction),
+		Register(r, "recategorize_transaction", "Moves a transaction to another category", h.RecategorizeTransaction),
+		Register(r, "edit_transaction_description", "Replaces the description of a transaction", h.EditTransactionDescription),
+		Register(r, "delete_transaction", "Deletes a transaction", h.DeleteTransaction),
+		Register(r, "import_transactions",
+			"Imports the transactions of a bank's CSV export with a configurable column mapping, date and amount format and "+
+				"sign convention, skipping lines already recorded; the file is imported completely or not at all",
+			h.ImportTransactions),
+		Register(r, "import_statement",
+			"Imports the transactions of an OFX, QFX, QIF or ISO 20022 camt.053 bank statement, skipping those already "+
+				"recorded by their bank ID or their account, date, amount and description, and stores the ledger balances of "+
+				"OFX and camt.053 statements to compare with the recorded transactions; the file is imported completely or not at all",
+			h.ImportStatement),
+		Register(r, "create_budget",
+			"Creates a budget limiting the spending in a category per week, month, quarter or year; budgets are shared by "+
+				"every account, so callers restricted to some accounts cannot create them",
+			h.CreateBudget),
+		Register(r, "create_categorization_rule",
+			"Creates a rule assigning a category to transactions by their account, description text or regular expression, "+
+				"counterparty and amount range; imports categorize the lines that name no category by the first matching rule",
+			h.CreateCategorizationRule),
+		Register(r, "create_categorization_rule_from_transaction",
+			"Creates a categorization rule from an example transaction, matching its counterparty, description, account or "+
+				"amount, for requests such as always filing payments like this one under a category",
+			h.CreateCategorizationRuleFromTransaction),
+		Register(r, "apply_categorization_rules",
+			"Runs the categorization rules over the recorded transactions matching any combination of the search_transactions "+
+				"filters and moves those a rule matches to its category; a dry run reports how many would change",
+			h.ApplyCategorizationRules),
+		Register(r, "refresh_recurring_payments",
+			"Detects the recurring payments of the accounts as of today, as list_recurring_payments does, and stores them, "+
+				"updating those stored before; a dry run lists the payments that would be stored",
+			h.RefreshRecurringPayments),
+	)
+}
+
+// RecordTransaction handles the record_transaction tool
+func (h *CommandHandler) RecordTransaction(ctx context.Context, in RecordTransactionInput) (interface{}, error) {
+	transaction := ops.NewTransaction{
+		AccountID:       in.AccountID,
+		CategoryID:      in.CategoryID,
+		Amount:          in.Amount,
+		TransactionDate: in.TransactionDate.Time,
+	}
+	if in.Description != "" {
+		transaction.Description = &in.Description
+	}
+
+	result, err := h.commandOps.RecordTransaction(ctx, transaction, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "transaction")
+	}
+	return writeResult(result)
+}
+
+// RecategorizeTransaction handles the recategorize_transaction tool
+func (h *CommandHandler) RecategorizeTransaction(ctx context.Context, in RecategorizeTransactionInput) (interface{}, error) {
+	result, err := h.commandOps.RecategorizeTransaction(ctx, in.TransactionID, in.CategoryID, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "transaction %d", in.TransactionID)
+	}
+	return writeResult(result)
+}
+
+// EditTransactionDescription handles the edit_transaction_description tool
+func (h *CommandHandler) EditTransactionDescription(ctx context.Context, in EditTransactionDescriptionInput) (interface{}, error) {
+	result, err := h.commandOps.EditTransactionDescription(ctx, in.TransactionID, in.Description, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "transaction %d", in.TransactionID)
+	}
+	return writeResult(result)
+}
+
+// DeleteTransaction handles the delete_transaction tool
+func (h *CommandHandler) DeleteTransaction(ctx context.Context, in DeleteTransactionInput) (interface{}, error) {
+	result, err := h.commandOps.DeleteTransaction(ctx, in.TransactionID, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "transaction %d", in.TransactionID)
+	}
+	return writeResult(result)
+}
+
+// CreateBudget handles the create_budget tool
+func (h *CommandHandler) CreateBudget(ctx context.Context, in CreateBudgetInput) (interface{}, error) {
+	startDate := in.StartDate.Time
+	if startDate.IsZero() {
+		startDate = time.Now().UTC()
+	}
+	budget := ops.NewBudget{
+		CategoryID: in.CategoryID,
+		Period:     in.Period,
+		Amount:     in.Amount,
+		Rollover:   in.Rollover,
+		StartDate:  startDate,
+	}
+
+	result, err := h.commandOps.CreateBudget(ctx, budget, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "budget")
+	}
+	return writeResult(result)
+}
+
+// CreateCategorizationRule handles the create_categorization_rule tool
+func (h *CommandHandler) CreateCategorizationRule(ctx context.Context, in CreateCategorizationRuleInput) (interface{}, error) {
+	rule := ops.NewRule{
+		Name:       in.Name,
+		Priority:   in.Priority,
+		CategoryID: in.CategoryID,
+		MinAmount:  in.MinAmount,
+		MaxAmount:  in.MaxAmount,
+	}
+	if in.AccountID != 0 {
+		rule.AccountID = &in.AccountID
+	}
+	if in.DescriptionContains != "" {
+		rule.DescriptionContains = &in.DescriptionContains
+	}
+	if in.DescriptionPattern != "" {
+		rule.DescriptionPattern = &in.DescriptionPattern
+	}
+	if in.Counterparty != "" {
+		rule.Counterparty = &in.Counterparty
+	}
+
+	result, err := h.commandOps.CreateCategorizationRule(ctx, rule, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "categorization rule")
+	}
+	return writeResult(result)
+}
+
+// CreateCategorizationRuleFromTransaction handles the create_categorization_rule_from_transaction tool
+func (h *CommandHandler) CreateCategorizationRuleFromTransaction(ctx context.Context, in CreateCategorizationRuleFromTransactionInput) (interface{}, error) {
+	example := ops.RuleExample{
+		TransactionID:   in.TransactionID,
+		CategoryID:      in.CategoryID,
+		AmountTolerance: in.AmountTolerance,
+		Name:            in.Name,
+		Priority:        in.Priority,
+	}
+	for _, condition := range in.Match {
+		example.Match = append(example.Match, ops.RuleCondition(condition))
+	}
+	if len(example.Match) == 0 {
+		example.Match = []ops.RuleCondition{ops.MatchCounterparty}
+	}
+
+	result, err := h.commandOps.CreateCategorizationRuleFromTransaction(ctx, example, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "transaction %d", in.TransactionID)
+	}
+	return writeResult(result)
+}
+
+// ApplyCategorizationRules handles the apply_categorization_rules tool
+func (h *CommandHandler) ApplyCategorizationRules(ctx context.Context, in ApplyCategorizationRulesInput) (interface{}, error) {
+	run, err := h.commandOps.ApplyCategorizationRules(ctx, in.filter(), in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "categorization rules")
+	}
+
+	summary := fmt.Sprintf("Recategorized %d of %d transactions; %d matched a rule.", run.Changed, run.Examined, run.Matched)
+	if run.DryRun {
+		summary = fmt.Sprintf("Dry run: would recategorize %d of %d transactions, %d matched a rule; nothing was saved.",
+			run.Changed, run.Examined, run.Matched)
+	}
+	return NewResult(run).WithSummary("%s", summary).WithStructuredContent().WithMarkdownTableOf(run.Rules).Build()
+}
+
+// RefreshRecurringPayments handles the refresh_recurring_payments tool
+func (h *CommandHandler) RefreshRecurringPayments(ctx context.Context, in RefreshRecurringPaymentsInput) (interface{}, error) {
+	refresh, err := h.commandOps.RefreshRecurringPayments(ctx, in.AccountIDs, in.DryRun)
+	if err != nil {
+		return nil, commandError(err, "recurring payments")
+	}
+
+	summary := fmt.Sprintf("Stored %d recurring payments detected as of %s.", len(refresh.Payments), refresh.Date.Format(DateLayout))
+	if refresh.DryRun {
+		summary = fmt.Sprintf("Dry run: would store %d recurring payments detected as of %s; nothing was saved.",
+			len(refresh.Payments), refresh.Date.Format(DateLayout))
+	}
+	return NewResult(refresh).WithSummary("%s", summary).WithStructuredContent().WithMarkdownTableOf(refresh.Payments).Build()
+}
+
+// ImportTransactions handles the import_transactions tool
+func (h *CommandHandler) ImportTransactions(ctx context.Context, in ImportTransactionsInput) (interface{}, error) {
+	lines, err := importer.ParseCSV(strings.NewReader(in.Content), in.mapping())
+	if err != nil {
+		return nil, InvalidInputError("%s", err)
+	}
+
+	result, err := h.commandOps.ImportStatements(ctx, []importer.Statement{{Lines: lines}}, importer.Options{
+		Account:  in.Account,
+		Category: in.Category,
+		DryRun:   in.DryRun,
+	})
+	if err != nil {
+		return nil, commandError(err, "import")
+	}
+	return importResult(result)
+}
+
+// ImportStatement handles the import_statement tool
+func (h *CommandHandler) ImportStatement(ctx context.Context, in ImportStatementInput) (interface{}, error) {
+	accounts, err := importer.ParseAccountMap(in.AccountMap)
+	if err != nil {
+		return nil, InvalidInputError("%s", err)
+	}
+	statements, err := importer.ParseStatements(in.Format, strings.NewReader(in.Content),
+		importer.QIFFormat{DayFirst: in.DayFirst, DecimalComma: in.DecimalComma})
+	if err != nil {
+		return nil, InvalidInputError("%s", err)
+	}
+
+	result, err := h.commandOps.ImportStatements(ctx, statements, importer.Options{
+		Account:  in.Account,
+		Category: in.Category,
+		Accounts: accounts,
+		DryRun:   in.DryRun,
+	})
+	if err != nil {
+		return nil, commandError(err, "import")
+	}
+	return importResult(result)
+}
+
+// importResult renders an import result with a summary of the lines imported and skipped
+func importResult(result *importer.Result) (interface{}, error) {
+	summary := fmt.Sprintf("Imported %d of %d lines, skipped %d duplicates.", result.Imported, result.Lines, result.Duplicates)
+	if result.DryRun {
+		summary = fmt.Sprintf("Dry run: would import %d of %d lines and skip %d duplicates, nothing was saved.",
+			result.Imported, result.Lines, result.Duplicates)
+	}
+	if result.Categorized > 0 {
+		summary += fmt.Sprintf("\nCategorized %d lines by categorization rules.", result.Categorized)
+	}
+	if len(result.Balances) == 0 {
+		return NewResult(result).WithSummary("%s", summary).WithStructuredContent().Build()
+	}
+	for _, balance := range result.Balances {
+		summary += fmt.Sprintf("\nThe statement balance of %s on %s is %s, the recorded transactions add up to %s.",
+			balance.Account, balance.Date.Format(time.DateOnly), balance.Statement, balance.Recorded)
+	}
+	return NewResult(result).WithSummary("%s", summary).WithStructuredContent().WithMarkdownTableOf(result.Balances).Build()
+}
+
+// commandError reports validation failures as invalid_input and missing records as not_found
+func commandError(err error, format string, args ...interface{}) error {
+	switch {
+	case errors.Is(err, ops.ErrInvalidCommand):
+		return InvalidInputError("%s", strings.TrimPrefix(err.Error(), ops.ErrInvalidCommand.Error()+": "))
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFoundError("%s not found", fmt.Sprintf(format, args...))
+	default:
+		return err
+	}
+}
+
+// writeResult renders a write result with a summary listing the changed fields
+func writeResult(result *ops.WriteResult) (interface{}, error) {
+	var sb strings.Builder
+	switch {
+	case len(result.Changes) == 0:
+		sb.WriteString("No changes.")
+	case result.DryRun:
+		fmt.Fprintf(&sb, "Dry run: would %s the %s, nothing was saved.", result.Action, writeSubject(result))
+	case result.Budget != nil:
+		fmt.Fprintf(&sb, "Budget %d: %s done.", result.Budget.BudgetID, result.Action)
+	case result.Rule != nil:
+		fmt.Fprintf(&sb, "Categorization rule %d: %s done.", result.Rule.RuleID, result.Action)
+	default:
+		fmt.Fprintf(&sb, "Transaction %d: %s done.", result.Transaction.TransactionID, result.Action)
+	}
+	for _, change := range result.Changes {
+		fmt.Fprintf(&sb, "\n- %s: %s → %s", change.Field, formatChange(change.Before), formatChange(change.After))
+	}
+
+	return NewResult(result).WithSummary("%s", sb.String()).WithStructuredContent().Build()
+}
+
+// writeSubject names the kind of record a write result is about
+func writeSubject(result *ops.WriteResult) string {
+	switch {
+	case result.Budget != nil:
+		return "budget"
+	case result.Rule != nil:
+		return "categorization rule"
+	}
+	return "transaction"
+}
+
+// formatChange renders one side of a field change, showing absent values as an empty set
+func formatChange(value interface{}) string {
+	if cell := formatCell(reflect.ValueOf(value)); cell != "" {
+		return cell
+	}
+	return "∅"
+}
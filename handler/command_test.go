@@ -0,0 +1,532 @@
+package handler
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"sample-mcp/ops"
+)
+
+const (
+	selectTransaction = `SELECT * FROM "transactions" WHERE "transactions"."transaction_id" = $1 ORDER BY "transactions"."transaction_id" LIMIT $2`
+	selectCategory    = `SELECT * FROM "categories" WHERE "categories"."category_id" = $1 ORDER BY "categories"."category_id" LIMIT $2`
+	selectAccount     = `SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 ORDER BY "accounts"."account_id" LIMIT $2`
+)
+
+func setupCommandRegistry(t *testing.T, policy ops.WritePolicy) (*Registry, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	commandOps, err := ops.NewCommandOps(ops.WithCommandGormDB(gormDB), ops.WithWritePolicy(policy))
+	require.NoError(t, err)
+
+	registry := NewRegistry()
+	require.NoError(t, NewCommandHandler(commandOps).Register(registry))
+	return registry, mock
+}
+
+func expectTransaction(mock sqlmock.Sqlmock, id, categoryID uint, description string) {
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransaction)).
+		WithArgs(id, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "created_at", "updated_at"}).
+			AddRow(id, 1, categoryID, -42.5, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), description, time.Now(), time.Now()))
+}
+
+func expectCategory(mock sqlmock.Sqlmock, id uint) {
+	mock.ExpectQuery(regexp.QuoteMeta(selectCategory)).
+		WithArgs(id, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).AddRow(id, "Dining", "Expense"))
+}
+
+func TestCommandHandler_RecategorizeTransaction_DryRun(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	expectCategory(mock, 4)
+	expectTransaction(mock, 9, 2, "Lunch")
+
+	response, err := callTool(t, registry, "recategorize_transaction", map[string]interface{}{
+		"transaction_id": float64(9), "category_id": float64(4), "dry_run": true,
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Dry run: would update the transaction, nothing was saved.")
+	assert.Contains(t, text, "- category_id: 2 → 4")
+
+	structured := response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Equal(t, true, structured["dry_run"])
+	assert.NoError(t, mock.ExpectationsWereMet(), "a dry run must not write")
+}
+
+func TestCommandHandler_RecategorizeTransaction(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	expectCategory(mock, 4)
+	expectTransaction(mock, 9, 2, "Lunch")
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "account_id"=$1,"category_id"=$2,"amount"=$3,"transaction_date"=$4,"description"=$5,"external_id"=$6,"created_at"=$7,"updated_at"=$8 WHERE "transaction_id" = $9`)).
+		WithArgs(1, 4, "-42.50", sqlmock.AnyArg(), "Lunch", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "recategorize_transaction", map[string]interface{}{
+		"transaction_id": float64(9), "category_id": float64(4),
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, response), "Transaction 9: update done.")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_EditTransactionDescription_Unchanged(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	expectTransaction(mock, 9, 2, "Lunch")
+
+	response, err := callTool(t, registry, "edit_transaction_description", map[string]interface{}{
+		"transaction_id": float64(9), "description": "Lunch",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, response), "No changes.")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_RecordTransaction(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccount)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type"}).AddRow(1, "Checking", "Checking"))
+	expectCategory(mock, 4)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions" ("account_id","category_id","amount","transaction_date","description","external_id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "created_at","updated_at","transaction_id"`)).
+		WithArgs(1, 4, "-12.30", time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), "Coffee", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "transaction_id"}).AddRow(time.Now(), time.Now(), 31))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "record_transaction", map[string]interface{}{
+		"account_id": float64(1), "category_id": float64(4), "amount": -12.3,
+		"transaction_date": "2023-02-01", "description": "Coffee",
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Transaction 31: create done.")
+	assert.Contains(t, text, "- amount: ∅ → -12.30")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_RecordTransaction_ExcessPrecision(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	response, err := callTool(t, registry, "record_transaction", map[string]interface{}{
+		"account_id": float64(1), "category_id": float64(4), "amount": 10.005, "transaction_date": "2023-02-01",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, `invalid 'amount' parameter: amount "10.005" has more than two decimals`)
+	assert.NoError(t, mock.ExpectationsWereMet(), "the amount must be rejected before any query")
+}
+
+func TestCommandHandler_RecordTransaction_UnknownCategory(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccount)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type"}).AddRow(1, "Checking", "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectCategory)).
+		WithArgs(99, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}))
+
+	response, err := callTool(t, registry, "record_transaction", map[string]interface{}{
+		"account_id": float64(1), "category_id": float64(99), "amount": 5, "transaction_date": "2023-02-01",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "category 99 does not exist")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_WritesDisabled(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	response, err := callTool(t, registry, "edit_transaction_description", map[string]interface{}{
+		"transaction_id": float64(9), "description": "Dinner",
+	})
+
+	requireToolError(t, response, err, ErrorForbidden, "writes are disabled")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_DeleteTransaction(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	response, err := callTool(t, registry, "delete_transaction", map[string]interface{}{"transaction_id": float64(9)})
+	requireToolError(t, response, err, ErrorForbidden, "deleting transactions is not allowed")
+
+	registry, mock = setupCommandRegistry(t, ops.WritePolicy{Enabled: true, AllowDelete: true})
+	expectTransaction(mock, 9, 2, "Lunch")
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "transactions" WHERE "transactions"."transaction_id" = $1`)).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	response, err = callTool(t, registry, "delete_transaction", map[string]interface{}{"transaction_id": float64(9)})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Transaction 9: delete done.")
+	assert.Contains(t, text, "- description: Lunch → ∅")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_DeleteTransaction_NotFound(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true, AllowDelete: true})
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransaction)).
+		WithArgs(9, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+
+	response, err := callTool(t, registry, "delete_transaction", map[string]interface{}{"transaction_id": float64(9), "dry_run": true})
+
+	requireToolError(t, response, err, ErrorNotFound, "transaction 9 not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_CreateBudget(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	expectCategory(mock, 4)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "budgets" WHERE category_id = $1 AND period = $2 ORDER BY "budgets"."budget_id" LIMIT $3`)).
+		WithArgs(4, "month", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"budget_id"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "budgets" ("category_id","period","amount","rollover","start_date") VALUES ($1,$2,$3,$4,$5) RETURNING "created_at","updated_at","budget_id"`)).
+		WithArgs(4, "month", "450.00", true, start).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "budget_id"}).AddRow(time.Now(), time.Now(), 7))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "create_budget", map[string]interface{}{
+		"category_id": float64(4), "amount": 450, "rollover": true, "start_date": "2024-03-01",
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Budget 7: create done.")
+	assert.Contains(t, text, "- period: ∅ → month")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_CreateBudget_Duplicate(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	expectCategory(mock, 4)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "budgets" WHERE category_id = $1 AND period = $2`)).
+		WithArgs(4, "week", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"budget_id", "category_id", "period"}).AddRow(2, 4, "week"))
+
+	response, err := callTool(t, registry, "create_budget", map[string]interface{}{
+		"category_id": float64(4), "period": "week", "amount": 50, "dry_run": true,
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "category 4 already has a week budget")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_ImportTransactions_DryRun(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name = $1`)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name = $1`)).
+		WithArgs("Groceries", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE account_id = $1 AND transaction_date BETWEEN $2 AND $3`)).
+		WithArgs(1, date, date).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "import_transactions", map[string]interface{}{
+		"content":             "Datum;Betrag;Empfänger\n05.03.2024;-12,50;Grocer\n",
+		"delimiter":           ";",
+		"date_column":         "Datum",
+		"date_format":         "DD.MM.YYYY",
+		"amount_column":       "Betrag",
+		"decimal_comma":       true,
+		"description_columns": []interface{}{"Empfänger"},
+		"account":             "Checking",
+		"category":            "Groceries",
+		"dry_run":             true,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, response), "Dry run: would import 1 of 1 lines and skip 0 duplicates, nothing was saved.")
+	assert.NoError(t, mock.ExpectationsWereMet(), "a dry run must not write")
+}
+
+func TestCommandHandler_ImportTransactions_InvalidFile(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	response, err := callTool(t, registry, "import_transactions", map[string]interface{}{
+		"content":       "Date,Amount\n2024-03-05,twelve\n",
+		"date_column":   "Date",
+		"amount_column": "Amount",
+		"account":       "Checking",
+		"category":      "Groceries",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, `line 2: amount "twelve" is not a number`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_ImportTransactions_WritesDisabled(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	response, err := callTool(t, registry, "import_transactions", map[string]interface{}{
+		"content":       "Date,Amount\n2024-03-05,-12.50\n",
+		"date_column":   "Date",
+		"amount_column": "Amount",
+		"account":       "Checking",
+		"category":      "Groceries",
+	})
+
+	requireToolError(t, response, err, ErrorForbidden, "writes are disabled")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_ImportStatement_DryRun(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE name = $1`)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "currency"}).AddRow(1, "Checking", "USD"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE name = $1`)).
+		WithArgs("Groceries", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "external_id" FROM "transactions" WHERE account_id = $1 AND external_id IN ($2)`)).
+		WithArgs(1, "T1").
+		WillReturnRows(sqlmock.NewRows([]string{"external_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE account_id = $1 AND transaction_date BETWEEN $2 AND $3`)).
+		WithArgs(1, date, date).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1 AND transaction_date <= $2`)).
+		WithArgs(1, date).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow("100.00"))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "import_statement", map[string]interface{}{
+		"content": "<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD<BANKACCTFROM><ACCTID>0001</BANKACCTFROM>" +
+			"<BANKTRANLIST><STMTTRN><DTPOSTED>20240305<TRNAMT>-12.50<FITID>T1<NAME>Grocer</STMTTRN></BANKTRANLIST>" +
+			"<LEDGERBAL><BALAMT>87.50<DTASOF>20240305</LEDGERBAL></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>",
+		"format":      "ofx",
+		"account_map": []interface{}{"0001=Checking"},
+		"category":    "Groceries",
+		"dry_run":     true,
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Dry run: would import 1 of 1 lines and skip 0 duplicates, nothing was saved.")
+	assert.Contains(t, text, "The statement balance of Checking on 2024-03-05 is 87.50, the recorded transactions add up to 87.50.")
+	assert.NoError(t, mock.ExpectationsWereMet(), "a dry run must not write")
+}
+
+func TestCommandHandler_ImportStatement_InvalidFile(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	response, err := callTool(t, registry, "import_statement", map[string]interface{}{
+		"content":  "!Type:Bank\nD31/12/2024\nT-12.50\n^\n",
+		"format":   "qif",
+		"account":  "Checking",
+		"category": "Groceries",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, `line 2: date "31/12/2024" does not exist`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_ImportStatement_InvalidCAMT053(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	response, err := callTool(t, registry, "import_statement", map[string]interface{}{
+		"content":  `<Document><BkToCstmrStmt><GrpHdr><MsgId>1</MsgId></GrpHdr></BkToCstmrStmt></Document>`,
+		"format":   "camt053",
+		"account":  "Checking",
+		"category": "Groceries",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "the file holds no camt.053 statement")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_CreateCategorizationRule_DryRun(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	expectCategory(mock, 4)
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccount)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+
+	response, err := callTool(t, registry, "create_categorization_rule", map[string]interface{}{
+		"name": "Streaming", "category_id": float64(4), "account_id": float64(1), "counterparty": "Netflix",
+		"max_amount": -0.01, "dry_run": true,
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Dry run: would create the categorization rule, nothing was saved.")
+	assert.Contains(t, text, "- counterparty: ∅ → Netflix")
+	assert.Contains(t, text, "- max_amount: ∅ → -0.01")
+	assert.NoError(t, mock.ExpectationsWereMet(), "a dry run must not write")
+}
+
+func TestCommandHandler_CreateCategorizationRule_InvalidInput(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{name: "no condition", params: map[string]interface{}{"name": "Everything", "category_id": float64(4)},
+			want: "at least one condition is required"},
+		{name: "reversed amounts", params: map[string]interface{}{"name": "Rent", "category_id": float64(4), "min_amount": -1000, "max_amount": -1500},
+			want: "min_amount is greater than max_amount"},
+		{name: "invalid pattern", params: map[string]interface{}{"name": "Fuel", "category_id": float64(4), "description_pattern": "(shell"},
+			want: "description_pattern is not a regular expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := callTool(t, registry, "create_categorization_rule", tt.params)
+			requireToolError(t, response, err, ErrorInvalidInput, tt.want)
+		})
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_CreateCategorizationRuleFromTransaction(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	expectTransaction(mock, 9, 4, "NETFLIX.COM 866-579-7172")
+	expectCategory(mock, 4)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "categorization_rules" ("name","priority","category_id","account_id","description_contains","description_pattern","counterparty","min_amount","max_amount") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "created_at","updated_at","rule_id"`)).
+		WithArgs("NETFLIX.COM", 0, 4, nil, nil, nil, "NETFLIX.COM", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "rule_id"}).AddRow(time.Now(), time.Now(), 3))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "create_categorization_rule_from_transaction", map[string]interface{}{
+		"transaction_id": float64(9),
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Categorization rule 3: create done.")
+	assert.Contains(t, text, "- counterparty: ∅ → NETFLIX.COM")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_CreateCategorizationRuleFromTransaction_UnknownCondition(t *testing.T) {
+	registry, _ := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+
+	response, err := callTool(t, registry, "create_categorization_rule_from_transaction", map[string]interface{}{
+		"transaction_id": float64(9), "match": []interface{}{"counterparty", "date"},
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "'match[1]'")
+}
+
+func TestCommandHandler_ApplyCategorizationRules_DryRun(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules" ORDER BY priority DESC, rule_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "name", "priority", "category_id", "counterparty"}).
+			AddRow(1, "Streaming", 0, 4, "Netflix"))
+	selectCategories := regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)
+	mock.ExpectQuery(selectCategories).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(4, "Streaming"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" ORDER BY transaction_date ASC,transaction_id ASC LIMIT $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description"}).
+			AddRow(7, 1, 2, "-15.99", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), "NETFLIX.COM 866-579-7172").
+			AddRow(8, 1, 2, "-30.00", time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), "Market"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(selectCategories).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(2, "Groceries"))
+
+	response, err := callTool(t, registry, "apply_categorization_rules", map[string]interface{}{"dry_run": true})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Dry run: would recategorize 1 of 2 transactions, 1 matched a rule; nothing was saved.")
+	assert.Contains(t, text, "| 1 | Streaming | 4 | Streaming | 1 |")
+	assert.NoError(t, mock.ExpectationsWereMet(), "a dry run must not write")
+}
+
+func TestCommandHandler_RefreshRecurringPayments(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{Enabled: true})
+	now := time.Now().UTC()
+	ago := func(months int) time.Time {
+		return time.Date(now.Year(), now.Month()-time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "transaction_date", "amount", "description"}).
+			AddRow(1, 2, 6, ago(3), "-45.00", "Insurance Premium").
+			AddRow(2, 2, 6, ago(2), "-45.00", "Insurance Premium").
+			AddRow(3, 2, 6, ago(1), "-45.00", "Insurance Premium"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(2, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(6, "Insurance"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "recurring_payments"`)).
+		WithArgs(2, 6, "insurance premium", "Insurance Premium", "monthly", "-45.00", 3, ago(3), ago(1), "-45.00", ago(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "recurring_payment_id"}).AddRow(time.Now(), time.Now(), 1))
+	mock.ExpectCommit()
+
+	response, err := callTool(t, registry, "refresh_recurring_payments", map[string]interface{}{
+		"account_ids": []interface{}{float64(2)},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, response), "Stored 1 recurring payments detected as of "+now.Format(DateLayout)+".")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommandHandler_RefreshRecurringPayments_WritesDisabled(t *testing.T) {
+	registry, mock := setupCommandRegistry(t, ops.WritePolicy{})
+
+	response, err := callTool(t, registry, "refresh_recurring_payments", map[string]interface{}{})
+
+	requireToolError(t, response, err, ErrorForbidden, "writes are disabled")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the date format accepted by tool parameters
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date tool parameter serialized as YYYY-MM-DD
+type Date struct {
+	time.Time
+}
+
+// NewDate creates a Date from the year, month and day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// UnmarshalJSON parses a YYYY-MM-DD string
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("expected a date string in YYYY-MM-DD format")
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		d.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return fmt.Errorf("expected a date in YYYY-MM-DD format, got %q", value)
+	}
+	d.Time = parsed
+	return nil
+}
+
+// MarshalJSON formats the date as YYYY-MM-DD
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// String formats the date as YYYY-MM-DD, or an empty string for the zero date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateLayout)
+}
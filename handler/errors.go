@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+
+	"gorm.io/gorm"
+	"sample-mcp/db/repository"
+	"sample-mcp/ops"
+	"sample-mcp/pkg/money"
+)
+
+// ErrorKind classifies a failed tool call so that clients can react without parsing the message
+type ErrorKind string
+
+const (
+	// ErrorNotFound means the requested record does not exist
+	ErrorNotFound ErrorKind = "not_found"
+	// ErrorForbidden means the write policy or the caller's account scope does not allow the call
+	ErrorForbidden ErrorKind = "forbidden"
+	// ErrorInvalidInput means the tool parameters were missing or invalid
+	ErrorInvalidInput ErrorKind = "invalid_input"
+	// ErrorUnavailable means the database could not be reached
+	ErrorUnavailable ErrorKind = "database_unavailable"
+	// ErrorInternal covers every other failure
+	ErrorInternal ErrorKind = "internal"
+)
+
+// ToolError is an error returned by a tool handler with its classification
+type ToolError struct {
+	Kind    ErrorKind
+	Message string
+	Err     error
+}
+
+func (e *ToolError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *ToolError) Unwrap() error {
+	return e.Err
+}
+
+// NotFoundError creates a not_found ToolError
+func NotFoundError(format string, args ...interface{}) *ToolError {
+	return &ToolError{Kind: ErrorNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidInputError creates an invalid_input ToolError
+func InvalidInputError(format string, args ...interface{}) *ToolError {
+	return &ToolError{Kind: ErrorInvalidInput, Message: fmt.Sprintf(format, args...)}
+}
+
+// lookupError converts an error from fetching a single record into a ToolError,
+// reporting gorm.ErrRecordNotFound as not_found
+func lookupError(err error, format string, args ...interface{}) error {
+	subject := fmt.Sprintf(format, args...)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFoundError("%s not found", subject)
+	}
+	return fmt.Errorf("failed to get %s: %w", subject, err)
+}
+
+// classifyError wraps any handler error into a ToolError
+func classifyError(err error) *ToolError {
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) {
+		return toolErr
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return &ToolError{Kind: ErrorNotFound, Message: "record not found", Err: err}
+	case errors.Is(err, repository.ErrOutOfScope):
+		return &ToolError{Kind: ErrorForbidden, Message: "access denied", Err: err}
+	case errors.Is(err, repository.ErrInvalidCursor):
+		return &ToolError{Kind: ErrorInvalidInput, Message: "invalid 'cursor' parameter: pass the next_cursor of the previous page", Err: err}
+	case errors.Is(err, repository.ErrInvalidFilter):
+		return &ToolError{Kind: ErrorInvalidInput, Message: err.Error()}
+	case errors.Is(err, ops.ErrInvalidExport):
+		return &ToolError{Kind: ErrorInvalidInput, Message: err.Error()}
+	case errors.Is(err, ops.ErrNoExchangeRate):
+		return &ToolError{Kind: ErrorNotFound, Message: err.Error()}
+	case errors.Is(err, money.ErrInvalidCurrency):
+		return &ToolError{Kind: ErrorInvalidInput, Message: err.Error()}
+	case errors.Is(err, ops.ErrWritesDisabled):
+		return &ToolError{Kind: ErrorForbidden, Message: "write rejected", Err: err}
+	case isUnavailable(err):
+		return &ToolError{Kind: ErrorUnavailable, Message: "database unavailable", Err: err}
+	default:
+		return &ToolError{Kind: ErrorInternal, Message: err.Error()}
+	}
+}
+
+// isUnavailable reports whether the error comes from a lost or refused database connection
+func isUnavailable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr)
+}
+
+// ErrorResult renders an error as an MCP tool result with isError set,
+// so the client sees the failure instead of a JSON-RPC protocol error
+func ErrorResult(err error) map[string]interface{} {
+	toolErr := classifyError(err)
+	return map[string]interface{}{
+		"isError": true,
+		"content": []map[string]interface{}{
+			textContent(fmt.Sprintf("Error (%s): %s", toolErr.Kind, toolErr.Error())),
+		},
+		"structuredContent": map[string]interface{}{
+			"error": map[string]interface{}{
+				"kind":    toolErr.Kind,
+				"message": toolErr.Error(),
+			},
+		},
+	}
+}
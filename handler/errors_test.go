@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"sample-mcp/db/repository"
+)
+
+func TestErrorResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		kind    ErrorKind
+		message string
+	}{
+		{name: "not found", err: lookupError(gorm.ErrRecordNotFound, "account %d", 9), kind: ErrorNotFound, message: "account 9 not found"},
+		{name: "wrapped not found", err: fmt.Errorf("lookup: %w", gorm.ErrRecordNotFound), kind: ErrorNotFound, message: "record not found"},
+		{name: "invalid input", err: InvalidInputError("missing 'account_id' parameter"), kind: ErrorInvalidInput, message: "missing 'account_id' parameter"},
+		{name: "out of scope", err: fmt.Errorf("failed to update transaction: %w", repository.ErrOutOfScope), kind: ErrorForbidden, message: "access denied"},
+		{name: "unavailable", err: fmt.Errorf("failed to get accounts: %w", driver.ErrBadConn), kind: ErrorUnavailable, message: "database unavailable"},
+		{name: "internal", err: errors.New("boom"), kind: ErrorInternal, message: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ErrorResult(tt.err)
+
+			assert.Equal(t, true, result["isError"])
+			content := result["content"].([]map[string]interface{})
+			assert.Contains(t, content[0]["text"], fmt.Sprintf("Error (%s): ", tt.kind))
+
+			structured := result["structuredContent"].(map[string]interface{})["error"].(map[string]interface{})
+			assert.Equal(t, tt.kind, structured["kind"])
+			assert.Contains(t, structured["message"], tt.message)
+		})
+	}
+}
+
+func TestLookupError_KeepsOtherErrors(t *testing.T) {
+	err := lookupError(errors.New("syntax error"), "category %d", 3)
+
+	assert.EqualError(t, err, "failed to get category 3: syntax error")
+	assert.Equal(t, ErrorInternal, classifyError(err).Kind)
+}
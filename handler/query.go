@@ -0,0 +1,854 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sample-mcp/db/repository"
+	"sample-mcp/db/repository/plain"
+	"sample-mcp/ops"
+	"sample-mcp/pkg/money"
+)
+
+// AccountIDInput identifies an account
+type AccountIDInput struct {
+	AccountID uint `json:"account_id" description:"The ID of the account" validate:"required"`
+}
+
+// AccountNameInput identifies an account by its exact name
+type AccountNameInput struct {
+	Name string `json:"name" description:"The exact name of the account" validate:"required"`
+}
+
+// KeywordInput is a free-text search keyword
+type KeywordInput struct {
+	Keyword string `json:"keyword" description:"The keyword to search for" validate:"required,max=100"`
+}
+
+// CategoryIDInput identifies a category
+type CategoryIDInput struct {
+	CategoryID uint `json:"category_id" description:"The ID of the category" validate:"required"`
+}
+
+// CategoryTypeInput selects categories by type
+type CategoryTypeInput struct {
+	CategoryType string `json:"category_type" description:"The category type" validate:"required,oneof=Income Expense"`
+}
+
+// TransactionIDInput identifies a transaction
+type TransactionIDInput struct {
+	TransactionID uint `json:"transaction_id" description:"The ID of the transaction" validate:"required"`
+}
+
+// DateRangeInput is an inclusive range of transaction dates
+type DateRangeInput struct {
+	StartDate Date `json:"start_date" description:"Start date, inclusive" validate:"required"`
+	EndDate   Date `json:"end_date" description:"End date, inclusive" validate:"required"`
+}
+
+// Validate checks that the range is not reversed
+func (in DateRangeInput) Validate() error {
+	if in.EndDate.Before(in.StartDate.Time) {
+		return fmt.Errorf("invalid date range: end_date is before start_date")
+	}
+	return nil
+}
+
+// AccountDateRangeInput is an account and an inclusive range of transaction dates
+type AccountDateRangeInput struct {
+	AccountIDInput
+	DateRangeInput
+}
+
+// LatestTransactionsInput selects the most recent transactions of an account
+type LatestTransactionsInput struct {
+	AccountIDInput
+	Limit int `json:"limit" description:"Maximum number of transactions to return" validate:"min=1,max=100" default:"10"`
+}
+
+// PageInput selects a page of a list tool
+type PageInput struct {
+	Limit  int    `json:"limit" description:"Maximum number of records to return" validate:"min=1,max=200" default:"50"`
+	Cursor string `json:"cursor" description:"The next_cursor of the previous page, omit for the first page"`
+	Offset int    `json:"offset" description:"Number of records to skip, ignored when cursor is set" validate:"min=0"`
+}
+
+// request converts the input into a repository page request
+func (in PageInput) request() repository.PageRequest {
+	return repository.PageRequest{Limit: in.Limit, Offset: in.Offset, Cursor: in.Cursor}
+}
+
+// KeywordPageInput is a search keyword and a page of the results
+type KeywordPageInput struct {
+	KeywordInput
+	PageInput
+}
+
+// CategoryTypePageInput is a category type and a page of its categories
+type CategoryTypePageInput struct {
+	CategoryTypeInput
+	PageInput
+}
+
+// AccountPageInput is an account and a page of its transactions
+type AccountPageInput struct {
+	AccountIDInput
+	PageInput
+}
+
+// DateRangePageInput is a date range and a page of the transactions within it
+type DateRangePageInput struct {
+	DateRangeInput
+	PageInput
+}
+
+// AccountDateRangePageInput is an account, a date range and a page of the transactions matching both
+type AccountDateRangePageInput struct {
+	AccountDateRangeInput
+	PageInput
+}
+
+// TransactionFilterInput combines any of the transaction filters; omitted filters match every transaction
+type TransactionFilterInput struct {
+	AccountIDs   []uint        `json:"account_ids" description:"Only transactions of these accounts" validate:"max=50"`
+	CategoryIDs  []uint        `json:"category_ids" description:"Only transactions in these categories" validate:"max=50"`
+	CategoryType string        `json:"category_type" description:"Only transactions whose category has this type" validate:"omitempty,oneof=Income Expense"`
+	MinAmount    *money.Amount `json:"min_amount" description:"Minimum signed amount, inclusive; expenses are negative"`
+	MaxAmount    *money.Amount `json:"max_amount" description:"Maximum signed amount, inclusive; expenses are negative"`
+	StartDate    Date          `json:"start_date" description:"Earliest transaction date, inclusive"`
+	EndDate      Date          `json:"end_date" description:"Latest transaction date, inclusive"`
+	Description  string        `json:"description" description:"Text the description must contain, case-insensitive" validate:"max=100"`
+	Sign         string        `json:"sign" description:"Only income (positive) or expense (negative) amounts" validate:"omitempty,oneof=income expense"`
+	Sort         []string      `json:"sort" description:"Sort fields, transaction_date when omitted; a leading - sorts descending, e.g. [\"-amount\"]" validate:"max=2,dive,oneof=transaction_date -transaction_date amount -amount"`
+}
+
+// Validate checks that the ranges are not reversed
+func (in TransactionFilterInput) Validate() error {
+	if in.MinAmount != nil && in.MaxAmount != nil && *in.MinAmount > *in.MaxAmount {
+		return fmt.Errorf("invalid amount range: min_amount is greater than max_amount")
+	}
+	if !in.StartDate.IsZero() && !in.EndDate.IsZero() && in.EndDate.Before(in.StartDate.Time) {
+		return fmt.Errorf("invalid date range: end_date is before start_date")
+	}
+	return nil
+}
+
+// filter converts the input into a repository transaction filter
+func (in TransactionFilterInput) filter() repository.TransactionFilter {
+	filter := repository.TransactionFilter{
+		AccountIDs:   in.AccountIDs,
+		CategoryIDs:  in.CategoryIDs,
+		CategoryType: in.CategoryType,
+		MinAmount:    in.MinAmount,
+		MaxAmount:    in.MaxAmount,
+		Description:  in.Description,
+		Sign:         repository.TransactionSign(in.Sign),
+	}
+	if !in.StartDate.IsZero() {
+		filter.StartDate = &in.StartDate.Time
+	}
+	if !in.EndDate.IsZero() {
+		filter.EndDate = &in.EndDate.Time
+	}
+	for _, field := range in.Sort {
+		name, desc := strings.CutPrefix(field, "-")
+		filter.Sort = append(filter.Sort, repository.TransactionSort{Field: repository.TransactionSortField(name), Desc: desc})
+	}
+	return filter
+}
+
+// SearchTransactionsInput is a transaction filter and a page of the transactions matching it
+type SearchTransactionsInput struct {
+	TransactionFilterInput
+	PageInput
+}
+
+// ExportTransactionsInput is a transaction filter and the format of the file the matching transactions are exported to
+type ExportTransactionsInput struct {
+	Format string `json:"format" description:"The file format: csv, ndjson (a JSON object per line) or xlsx" validate:"oneof=csv ndjson xlsx" default:"csv"`
+	TransactionFilterInput
+}
+
+// BalanceAsOfInput is an account and the day whose closing balance is requested
+type BalanceAsOfInput struct {
+	AccountIDInput
+	Date Date `json:"date" description:"The day, the balance includes its transactions" validate:"required"`
+}
+
+// RunningBalancesInput is an account, an optional date range and a page of its transactions with running balances
+type RunningBalancesInput struct {
+	AccountIDInput
+	StartDate Date `json:"start_date" description:"Earliest transaction date, inclusive; earlier transactions still count towards the balance"`
+	EndDate   Date `json:"end_date" description:"Latest transaction date, inclusive"`
+	PageInput
+}
+
+// Validate checks that the range is not reversed
+func (in RunningBalancesInput) Validate() error {
+	if !in.StartDate.IsZero() && !in.EndDate.IsZero() && in.EndDate.Before(in.StartDate.Time) {
+		return fmt.Errorf("invalid date range: end_date is before start_date")
+	}
+	return nil
+}
+
+// dates returns the optional date range
+func (in RunningBalancesInput) dates() (start, end *time.Time) {
+	if !in.StartDate.IsZero() {
+		start = &in.StartDate.Time
+	}
+	if !in.EndDate.IsZero() {
+		end = &in.EndDate.Time
+	}
+	return start, end
+}
+
+// DailyBalancesInput is an account and the days of its end-of-day balance series
+type DailyBalancesInput struct {
+	AccountIDInput
+	DateRangeInput
+}
+
+// Validate checks that the range is not reversed and not longer than a series may be
+func (in DailyBalancesInput) Validate() error {
+	if err := in.DateRangeInput.Validate(); err != nil {
+		return err
+	}
+	if in.EndDate.Sub(in.StartDate.Time) >= repository.MaxDailyBalanceDays*24*time.Hour {
+		return fmt.Errorf("invalid date range: at most %d days are allowed", repository.MaxDailyBalanceDays)
+	}
+	return nil
+}
+
+// ReportFilterInput selects the transactions of a report
+type ReportFilterInput struct {
+	StartDate    Date   `json:"start_date" description:"Earliest transaction date, inclusive"`
+	EndDate      Date   `json:"end_date" description:"Latest transaction date, inclusive"`
+	AccountIDs   []uint `json:"account_ids" description:"Only transactions of these accounts" validate:"max=50"`
+	CategoryIDs  []uint `json:"category_ids" description:"Only transactions in these categories" validate:"max=50"`
+	CategoryType string `json:"category_type" description:"Only transactions whose category has this type" validate:"omitempty,oneof=Income Expense"`
+}
+
+// Validate checks that the date range is not reversed
+func (in ReportFilterInput) Validate() error {
+	if !in.StartDate.IsZero() && !in.EndDate.IsZero() && in.EndDate.Before(in.StartDate.Time) {
+		return fmt.Errorf("invalid date range: end_date is before start_date")
+	}
+	return nil
+}
+
+// filter converts the input into a repository transaction filter
+func (in ReportFilterInput) filter() repository.TransactionFilter {
+	filter := repository.TransactionFilter{
+		AccountIDs:   in.AccountIDs,
+		CategoryIDs:  in.CategoryIDs,
+		CategoryType: in.CategoryType,
+	}
+	if !in.StartDate.IsZero() {
+		filter.StartDate = &in.StartDate.Time
+	}
+	if !in.EndDate.IsZero() {
+		filter.EndDate = &in.EndDate.Time
+	}
+	return filter
+}
+
+// CashFlowReportInput selects the transactions of a cash flow report and how they are bucketed
+type CashFlowReportInput struct {
+	Interval string `json:"interval" description:"Length of each period; weeks start on Monday" validate:"oneof=day week month quarter year" default:"month"`
+	GroupBy  string `json:"group_by" description:"Split each period by category or by account" validate:"omitempty,oneof=category account"`
+	ReportFilterInput
+}
+
+// AnomalyInput selects the transactions checked for anomalies and how unusual they must be to be flagged
+type AnomalyInput struct {
+	Sensitivity    string `json:"sensitivity" description:"How readily amounts are flagged; high flags more" validate:"oneof=low medium high" default:"medium"`
+	BaselineMonths int    `json:"baseline_months" description:"Months of history before each transaction or month that it is compared with" validate:"min=1,max=36" default:"12"`
+	ReportFilterInput
+}
+
+// BudgetStatusInput selects the budgets to compare with spending and the day whose period they are compared in
+type BudgetStatusInput struct {
+	Date           Date   `json:"date" description:"A day of the periods to check, today when omitted"`
+	CategoryIDs    []uint `json:"category_ids" description:"Only the budgets of these categories" validate:"max=50"`
+	OverBudgetOnly bool   `json:"over_budget_only" description:"Only budgets already over or projected to go over by the end of the period" default:"false"`
+}
+
+// RecurringPaymentsInput selects the accounts whose recurring payments are detected and the day they are checked on
+type RecurringPaymentsInput struct {
+	Date        Date   `json:"date" description:"The day to check for missed payments, today when omitted"`
+	AccountIDs  []uint `json:"account_ids" description:"Only these accounts, every account when omitted" validate:"max=50"`
+	FlaggedOnly bool   `json:"flagged_only" description:"Only payments that were missed or had an unusually large occurrence" default:"false"`
+}
+
+// ConversionInput selects a reporting currency and the accounts whose amounts are converted to it
+type ConversionInput struct {
+	Currency   string `json:"currency" description:"ISO 4217 code of the reporting currency, e.g. EUR" validate:"required,iso4217"`
+	AccountIDs []uint `json:"account_ids" description:"Only these accounts, every account when omitted" validate:"max=50"`
+}
+
+// AccountBalance is the result of the get_account_balance tool
+type AccountBalance struct {
+	AccountID uint         `json:"account_id"`
+	Balance   money.Amount `json:"balance"`
+}
+
+// BalanceAsOf is the result of the get_balance_as_of tool
+type BalanceAsOf struct {
+	AccountID uint         `json:"account_id"`
+	Date      Date         `json:"date"`
+	Balance   money.Amount `json:"balance"`
+}
+
+// DailyBalance is a day of the result of the get_daily_balances tool
+type DailyBalance struct {
+	Date    Date         `json:"date"`
+	Balance money.Amount `json:"balance"`
+}
+
+// TransactionCount is the result of the get_transaction_count tool
+type TransactionCount struct {
+	AccountID uint  `json:"account_id"`
+	Count     int64 `json:"count"`
+}
+
+// TransactionExport is the result of the export_transactions tool, next to the file it embeds
+type TransactionExport struct {
+	Format       string `json:"format"`
+	Transactions int    `json:"transactions"`
+	Bytes        int    `json:"bytes"`
+	URI          string `json:"uri"`
+}
+
+// maxExportSize bounds the files of the export_transactions tool, which are held in memory to embed them;
+// larger exports are left to the export command
+const maxExportSize = 10_000_000
+
+// errExportTooLarge is returned by exportBuffer once an export exceeds maxExportSize
+var errExportTooLarge = errors.New("export too large")
+
+// exportBuffer collects an export, failing it as soon as it grows beyond maxExportSize
+type exportBuffer struct {
+	bytes.Buffer
+}
+
+func (b *exportBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxExportSize {
+		return 0, errExportTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// QueryHandler exposes every QueryOps method as an MCP tool.
+// A single QueryOps instance is shared by all tools, so the database pool is opened once at startup.
+type QueryHandler struct {
+	queryOps *ops.QueryOps
+}
+
+// NewQueryHandler creates a QueryHandler backed by the given QueryOps
+func NewQueryHandler(queryOps *ops.QueryOps) *QueryHandler {
+	return &QueryHandler{queryOps: queryOps}
+}
+
+// Register adds all QueryHandler tools to the registry
+func (h *QueryHandler) Register(r *Registry) error {
+	return errors.Join(
+		Register(r, "get_account_by_id", "Retrieves an account by its ID", h.GetAccountByID),
+		Register(r, "get_account_by_name", "Retrieves an account by its exact name", h.GetAccountByName),
+		Register(r, "search_accounts", "Searches for accounts whose names contain a keyword", h.SearchAccounts),
+		Register(r, "get_all_accounts", "Retrieves all accounts, one page at a time", h.GetAllAccounts),
+		Register(r, "get_category_by_id", "Retrieves a category by its ID", h.GetCategoryByID),
+		Register(r, "get_categories_by_type", "Retrieves the categories of a given type, one page at a time", h.GetCategoriesByType),
+		Register(r, "search_categories", "Searches for categories whose names contain a keyword", h.SearchCategories),
+		Register(r, "get_all_categories", "Retrieves all categories, one page at a time", h.GetAllCategories),
+		Register(r, "get_transaction_by_id", "Retrieves a transaction by its ID", h.GetTransactionByID),
+		Register(r, "get_transactions_by_account_id", "Retrieves the transactions of an account, oldest first, one page at a time", h.GetTransactionsByAccountID),
+		Register(r, "get_transactions_by_date_range", "Retrieves transactions within a date range (inclusive), oldest first, one page at a time", h.GetTransactionsByDateRange),
+		Register(r, "get_transactions_by_account_and_date_range",
+			"Retrieves transactions for an account within a date range (inclusive), newest first, one page at a time",
+			h.GetTransactionsByAccountAndDateRange),
+		Register(r, "search_transactions_by_description",
+			"Searches for transactions whose descriptions contain a keyword",
+			h.SearchTransactionsByDescription),
+		Register(r, "search_transactions",
+			"Searches transactions by any combination of accounts, categories, category type, amount range, date range, "+
+				"description text and income or expense, with configurable sorting, one page at a time",
+			h.SearchTransactions),
+		Register(r, "export_transactions",
+			"Exports the transactions matching any combination of the search_transactions filters to a CSV, NDJSON or XLSX "+
+				"file with their account and category names, for handing a set of transactions to an accountant; "+
+				"the file is returned as an embedded resource of at most 10 MB",
+			h.ExportTransactions),
+		Register(r, "cash_flow_report",
+			"Reports income, expenses (negative) and net per day, week, month, quarter or year, optionally split by category "+
+				"or account, for trends such as how spending developed over a year",
+			h.CashFlowReport),
+		Register(r, "category_breakdown_report",
+			"Reports the total, count, average, smallest and largest amount and share of every income and expense category, "+
+				"for questions such as where the money went in a month",
+			h.CategoryBreakdownReport),
+		Register(r, "detect_spending_anomalies",
+			"Flags transactions and monthly category totals that are unusually large for their account and category compared "+
+				"with the months before, each with an explanation; checks the three months up to end_date (default today) "+
+				"unless a start_date is given",
+			h.DetectSpendingAnomalies),
+		Register(r, "get_budget_status",
+			"Compares the spending of each budgeted category with its budget for the current week, month, quarter or year, "+
+				"with the remaining amount and the spending projected for the end of the period, "+
+				"for questions such as which categories are over budget this month",
+			h.GetBudgetStatus),
+		Register(r, "list_categorization_rules",
+			"Lists the rules assigning categories to imported transactions in the order they are tried, the first matching one winning",
+			h.ListCategorizationRules),
+		Register(r, "list_recurring_payments",
+			"Detects weekly, monthly and annual payments such as mortgages, taxes and insurance premiums from the transactions "+
+				"of the last three years, with their typical amount and next due date, flagging missed and unusually large occurrences",
+			h.ListRecurringPayments),
+		Register(r, "get_account_balance", "Calculates the balance of an account as the sum of all its transactions", h.GetAccountBalance),
+		Register(r, "get_balance_as_of",
+			"Calculates the balance of an account at the end of a day, including the transactions of that day",
+			h.GetBalanceAsOf),
+		Register(r, "get_running_balances",
+			"Retrieves the transactions of an account, oldest first, each with the balance of the account right after it, one page at a time",
+			h.GetRunningBalances),
+		Register(r, "get_daily_balances",
+			"Retrieves the end-of-day balance of an account for every day of a date range (inclusive), including days without transactions",
+			h.GetDailyBalances),
+		Register(r, "get_transaction_count", "Counts the transactions of an account", h.GetTransactionCount),
+		Register(r, "get_latest_transactions", "Retrieves the most recent transactions of an account", h.GetLatestTransactions),
+		Register(r, "get_transaction_summary_by_category",
+			"Summarizes the transactions of an account per category, largest total first; see category_breakdown_report for income and expense sides",
+			h.GetTransactionSummaryByCategory),
+		Register(r, "get_account_balances_in_currency",
+			"Calculates the balances of accounts in their own currency and converted to a reporting currency, "+
+				"using the exchange rate effective on each transaction date",
+			h.GetAccountBalancesInCurrency),
+		Register(r, "get_transaction_summary_by_category_in_currency",
+			"Summarizes the transactions of accounts grouped by category, converted to a reporting currency "+
+				"using the exchange rate effective on each transaction date",
+			h.GetTransactionSummaryByCategoryInCurrency),
+		Register(r, "get_all_transactions", "Retrieves all transactions, oldest first, one page at a time", h.GetAllTransactions),
+	)
+}
+
+// GetAccountByID handles the get_account_by_id tool
+func (h *QueryHandler) GetAccountByID(ctx context.Context, in AccountIDInput) (interface{}, error) {
+	account, err := h.queryOps.GetAccountByID(ctx, in.AccountID)
+	if err != nil {
+		return nil, lookupError(err, "account %d", in.AccountID)
+	}
+	return recordResult(account)
+}
+
+// GetAccountByName handles the get_account_by_name tool
+func (h *QueryHandler) GetAccountByName(ctx context.Context, in AccountNameInput) (interface{}, error) {
+	account, err := h.queryOps.GetAccountByName(ctx, in.Name)
+	if err != nil {
+		return nil, lookupError(err, "account %q", in.Name)
+	}
+	return recordResult(account)
+}
+
+// SearchAccounts handles the search_accounts tool
+func (h *QueryHandler) SearchAccounts(ctx context.Context, in KeywordPageInput) (interface{}, error) {
+	accounts, err := h.queryOps.SearchAccountsPage(ctx, in.Keyword, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search accounts: %w", err)
+	}
+	return pageResult(accounts, "accounts")
+}
+
+// GetAllAccounts handles the get_all_accounts tool
+func (h *QueryHandler) GetAllAccounts(ctx context.Context, in PageInput) (interface{}, error) {
+	accounts, err := h.queryOps.GetAllAccountsPage(ctx, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	return pageResult(accounts, "accounts")
+}
+
+// GetCategoryByID handles the get_category_by_id tool
+func (h *QueryHandler) GetCategoryByID(ctx context.Context, in CategoryIDInput) (interface{}, error) {
+	category, err := h.queryOps.GetCategoryByID(ctx, in.CategoryID)
+	if err != nil {
+		return nil, lookupError(err, "category %d", in.CategoryID)
+	}
+	return recordResult(category)
+}
+
+// GetCategoriesByType handles the get_categories_by_type tool
+func (h *QueryHandler) GetCategoriesByType(ctx context.Context, in CategoryTypePageInput) (interface{}, error) {
+	categories, err := h.queryOps.GetCategoriesByTypePage(ctx, in.CategoryType, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return pageResult(categories, "categories")
+}
+
+// SearchCategories handles the search_categories tool
+func (h *QueryHandler) SearchCategories(ctx context.Context, in KeywordPageInput) (interface{}, error) {
+	categories, err := h.queryOps.SearchCategoriesPage(ctx, in.Keyword, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search categories: %w", err)
+	}
+	return pageResult(categories, "categories")
+}
+
+// GetAllCategories handles the get_all_categories tool
+func (h *QueryHandler) GetAllCategories(ctx context.Context, in PageInput) (interface{}, error) {
+	categories, err := h.queryOps.GetAllCategoriesPage(ctx, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return pageResult(categories, "categories")
+}
+
+// GetTransactionByID handles the get_transaction_by_id tool
+func (h *QueryHandler) GetTransactionByID(ctx context.Context, in TransactionIDInput) (interface{}, error) {
+	transaction, err := h.queryOps.GetTransactionByID(ctx, in.TransactionID)
+	if err != nil {
+		return nil, lookupError(err, "transaction %d", in.TransactionID)
+	}
+	return recordResult(transaction)
+}
+
+// GetTransactionsByAccountID handles the get_transactions_by_account_id tool
+func (h *QueryHandler) GetTransactionsByAccountID(ctx context.Context, in AccountPageInput) (interface{}, error) {
+	transactions, err := h.queryOps.GetTransactionsByAccountIDPage(ctx, in.AccountID, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return pageResult(transactions, "transactions")
+}
+
+// GetTransactionsByDateRange handles the get_transactions_by_date_range tool
+func (h *QueryHandler) GetTransactionsByDateRange(ctx context.Context, in DateRangePageInput) (interface{}, error) {
+	transactions, err := h.queryOps.GetTransactionsByDateRangePage(ctx, in.StartDate.Time, in.EndDate.Time, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return pageResult(transactions, "transactions")
+}
+
+// GetTransactionsByAccountAndDateRange handles the get_transactions_by_account_and_date_range tool
+func (h *QueryHandler) GetTransactionsByAccountAndDateRange(ctx context.Context, in AccountDateRangePageInput) (interface{}, error) {
+	transactions, err := h.queryOps.GetTransactionsByAccountAndDateRangePage(ctx, in.AccountID, in.StartDate.Time, in.EndDate.Time, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return pageResult(transactions, "transactions")
+}
+
+// SearchTransactionsByDescription handles the search_transactions_by_description tool
+func (h *QueryHandler) SearchTransactionsByDescription(ctx context.Context, in KeywordPageInput) (interface{}, error) {
+	transactions, err := h.queryOps.SearchTransactionsByDescriptionPage(ctx, in.Keyword, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+	return pageResult(transactions, "transactions")
+}
+
+// SearchTransactions handles the search_transactions tool
+func (h *QueryHandler) SearchTransactions(ctx context.Context, in SearchTransactionsInput) (interface{}, error) {
+	transactions, err := h.queryOps.SearchTransactions(ctx, in.filter(), in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+	return pageResult(transactions, "transactions")
+}
+
+// ExportTransactions handles the export_transactions tool
+func (h *QueryHandler) ExportTransactions(ctx context.Context, in ExportTransactionsInput) (interface{}, error) {
+	format := ops.ExportFormat(in.Format)
+	var out exportBuffer
+	count, err := h.queryOps.ExportTransactions(ctx, in.filter(), format, &out)
+	if errors.Is(err, errExportTooLarge) {
+		return nil, InvalidInputError("the export is larger than %d MB, narrow the filter or use the export command",
+			maxExportSize/1_000_000)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export transactions: %w", err)
+	}
+
+	export := TransactionExport{Format: in.Format, Transactions: count, Bytes: out.Len(), URI: "export://transactions." + in.Format}
+	result := NewResult(export).
+		WithSummary("Exported %d transactions as %s, %d bytes.", count, strings.ToUpper(in.Format), out.Len()).
+		WithStructuredContent()
+	if format == ops.ExportXLSX {
+		result.WithBlobResource(export.URI, format.MediaType(), out.Bytes())
+	} else {
+		result.WithTextResource(export.URI, format.MediaType(), out.String())
+	}
+	return result.Build()
+}
+
+// CashFlowReport handles the cash_flow_report tool
+func (h *QueryHandler) CashFlowReport(ctx context.Context, in CashFlowReportInput) (interface{}, error) {
+	flows, err := h.queryOps.GetCashFlow(ctx, in.filter(),
+		repository.CashFlowInterval(in.Interval), repository.CashFlowGrouping(in.GroupBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cash flow report: %w", err)
+	}
+
+	var income, expenses money.Amount
+	for _, flow := range flows {
+		income += flow.Income
+		expenses += flow.Expenses
+	}
+	return NewResult(flows).
+		WithSummary("%d rows; income %s, expenses %s, net %s in total.", len(flows), income, expenses, income+expenses).
+		WithStructuredContent().
+		WithMarkdownTable().
+		Build()
+}
+
+// CategoryBreakdownReport handles the category_breakdown_report tool
+func (h *QueryHandler) CategoryBreakdownReport(ctx context.Context, in ReportFilterInput) (interface{}, error) {
+	breakdown, err := h.queryOps.GetCategoryBreakdown(ctx, in.filter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build category breakdown: %w", err)
+	}
+	return NewResult(breakdown).
+		WithSummary("%d income categories totalling %s, %d expense categories totalling %s, net %s.",
+			len(breakdown.Income), breakdown.TotalIncome, len(breakdown.Expenses), breakdown.TotalExpenses, breakdown.Net).
+		WithStructuredContent().
+		WithMarkdownTableOf(append(append([]plain.CategoryStats{}, breakdown.Income...), breakdown.Expenses...)).
+		Build()
+}
+
+// DetectSpendingAnomalies handles the detect_spending_anomalies tool
+func (h *QueryHandler) DetectSpendingAnomalies(ctx context.Context, in AnomalyInput) (interface{}, error) {
+	if in.EndDate.IsZero() {
+		in.EndDate = Date{Time: time.Now().UTC().Truncate(24 * time.Hour)}
+	}
+	if in.StartDate.IsZero() {
+		in.StartDate = Date{Time: in.EndDate.AddDate(0, -3, 0)}
+	}
+	report, err := h.queryOps.DetectAnomalies(ctx, in.filter(), ops.AnomalyOptions{
+		Threshold:      ops.SensitivityThresholds[in.Sensitivity],
+		BaselineMonths: in.BaselineMonths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect spending anomalies: %w", err)
+	}
+
+	return NewResult(report).
+		WithSummary("%d unusual transactions and %d unusual monthly totals from %s to %s.",
+			len(report.Transactions), len(report.MonthlyTotals), in.StartDate.Format(DateLayout), in.EndDate.Format(DateLayout)).
+		WithStructuredContent().
+		Build()
+}
+
+// GetBudgetStatus handles the get_budget_status tool
+func (h *QueryHandler) GetBudgetStatus(ctx context.Context, in BudgetStatusInput) (interface{}, error) {
+	date := in.Date.Time
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+	statuses, err := h.queryOps.GetBudgetStatus(ctx, date, in.CategoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget status: %w", err)
+	}
+
+	var over, projected int
+	result := make([]ops.BudgetStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.OverBudget {
+			over++
+		}
+		if status.ProjectedOver {
+			projected++
+		}
+		if !in.OverBudgetOnly || status.ProjectedOver {
+			result = append(result, status)
+		}
+	}
+	return NewResult(result).
+		WithSummary("%d budgets as of %s; %d over budget, %d projected over budget by the end of the period.",
+			len(statuses), date.Format(DateLayout), over, projected).
+		WithStructuredContent().
+		WithMarkdownTable().
+		Build()
+}
+
+// ListRecurringPayments handles the list_recurring_payments tool
+func (h *QueryHandler) ListRecurringPayments(ctx context.Context, in RecurringPaymentsInput) (interface{}, error) {
+	date := in.Date.Time
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+	statuses, err := h.queryOps.AnalyzeRecurringPayments(ctx, date, in.AccountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring payments: %w", err)
+	}
+
+	var missed, unusual int
+	result := make([]ops.RecurringPaymentStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.MissedOccurrences > 0 {
+			missed++
+		}
+		if len(status.UnusualOccurrences) > 0 {
+			unusual++
+		}
+		if !in.FlaggedOnly || status.Flagged() {
+			result = append(result, status)
+		}
+	}
+	return NewResult(result).
+		WithSummary("Found %d recurring payments as of %s; %d with missed occurrences, %d with unusually large amounts.",
+			len(statuses), date.Format(DateLayout), missed, unusual).
+		WithStructuredContent().
+		WithMarkdownTable().
+		Build()
+}
+
+// ListCategorizationRules handles the list_categorization_rules tool
+func (h *QueryHandler) ListCategorizationRules(ctx context.Context, _ NoInput) (interface{}, error) {
+	rules, err := h.queryOps.ListCategorizationRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categorization rules: %w", err)
+	}
+	return NewResult(rules).
+		WithSummary("Found %d categorization rules.", len(rules)).
+		WithStructuredContent().
+		WithMarkdownTable().
+		Build()
+}
+
+// GetAccountBalance handles the get_account_balance tool
+func (h *QueryHandler) GetAccountBalance(ctx context.Context, in AccountIDInput) (interface{}, error) {
+	balance, err := h.queryOps.GetAccountBalance(ctx, in.AccountID)
+	if err != nil {
+		return nil, lookupError(err, "account %d", in.AccountID)
+	}
+	return recordResult(AccountBalance{AccountID: in.AccountID, Balance: balance})
+}
+
+// GetBalanceAsOf handles the get_balance_as_of tool
+func (h *QueryHandler) GetBalanceAsOf(ctx context.Context, in BalanceAsOfInput) (interface{}, error) {
+	balance, err := h.queryOps.GetBalanceAsOf(ctx, in.AccountID, in.Date.Time)
+	if err != nil {
+		return nil, lookupError(err, "account %d", in.AccountID)
+	}
+	return recordResult(BalanceAsOf{AccountID: in.AccountID, Date: in.Date, Balance: balance})
+}
+
+// GetRunningBalances handles the get_running_balances tool
+func (h *QueryHandler) GetRunningBalances(ctx context.Context, in RunningBalancesInput) (interface{}, error) {
+	start, end := in.dates()
+	balances, err := h.queryOps.GetRunningBalancesPage(ctx, in.AccountID, start, end, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running balances: %w", err)
+	}
+	return pageResult(balances, "transactions")
+}
+
+// GetDailyBalances handles the get_daily_balances tool
+func (h *QueryHandler) GetDailyBalances(ctx context.Context, in DailyBalancesInput) (interface{}, error) {
+	series, err := h.queryOps.GetDailyBalances(ctx, in.AccountID, in.StartDate.Time, in.EndDate.Time)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily balances: %w", err)
+	}
+	days := make([]DailyBalance, len(series))
+	for i, day := range series {
+		days[i] = DailyBalance{Date: Date{Time: day.Date}, Balance: day.Balance}
+	}
+	return listResult(days, "days")
+}
+
+// GetTransactionCount handles the get_transaction_count tool
+func (h *QueryHandler) GetTransactionCount(ctx context.Context, in AccountIDInput) (interface{}, error) {
+	count, err := h.queryOps.GetTransactionCount(ctx, in.AccountID)
+	if err != nil {
+		return nil, lookupError(err, "account %d", in.AccountID)
+	}
+	return recordResult(TransactionCount{AccountID: in.AccountID, Count: count})
+}
+
+// GetLatestTransactions handles the get_latest_transactions tool
+func (h *QueryHandler) GetLatestTransactions(ctx context.Context, in LatestTransactionsInput) (interface{}, error) {
+	transactions, err := h.queryOps.GetLatestTransactions(ctx, in.AccountID, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest transactions: %w", err)
+	}
+	return listResult(transactions, "transactions")
+}
+
+// GetTransactionSummaryByCategory handles the get_transaction_summary_by_category tool
+func (h *QueryHandler) GetTransactionSummaryByCategory(ctx context.Context, in AccountIDInput) (interface{}, error) {
+	summaries, err := h.queryOps.GetTransactionSummaryByCategory(ctx, in.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction summary: %w", err)
+	}
+	return listResult(summaries, "categories")
+}
+
+// GetAccountBalancesInCurrency handles the get_account_balances_in_currency tool
+func (h *QueryHandler) GetAccountBalancesInCurrency(ctx context.Context, in ConversionInput) (interface{}, error) {
+	balances, err := h.queryOps.GetAccountBalancesIn(ctx, in.Currency, in.AccountIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get converted balances: %w", err)
+	}
+	var total money.Amount
+	for _, balance := range balances {
+		total += balance.ConvertedBalance
+	}
+	return NewResult(balances).
+		WithSummary("Found %d accounts, %s %s in total.", len(balances), total, in.Currency).
+		WithStructuredContent().
+		WithMarkdownTable().
+		Build()
+}
+
+// GetTransactionSummaryByCategoryInCurrency handles the get_transaction_summary_by_category_in_currency tool
+func (h *QueryHandler) GetTransactionSummaryByCategoryInCurrency(ctx context.Context, in ConversionInput) (interface{}, error) {
+	summaries, err := h.queryOps.GetTransactionSummaryByCategoryIn(ctx, in.Currency, in.AccountIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get converted transaction summary: %w", err)
+	}
+	return listResult(summaries, "categories")
+}
+
+// GetAllTransactions handles the get_all_transactions tool
+func (h *QueryHandler) GetAllTransactions(ctx context.Context, in PageInput) (interface{}, error) {
+	transactions, err := h.queryOps.GetAllTransactionsPage(ctx, in.request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return pageResult(transactions, "transactions")
+}
+
+// recordResult renders a single record as JSON, structured content and a Markdown table
+func recordResult(record interface{}) (interface{}, error) {
+	return NewResult(record).WithStructuredContent().WithMarkdownTable().Build()
+}
+
+// pageResult renders a page of records with a count summary.
+// The JSON and structured content hold the items and the next_cursor to pass back for the following page.
+func pageResult[T any](page *repository.Page[T], noun string) (interface{}, error) {
+	summary := fmt.Sprintf("Found %d %s.", len(page.Items), noun)
+	if page.NextCursor != "" {
+		summary += " More are available, call again with cursor set to next_cursor."
+	}
+	return NewResult(page).
+		WithSummary("%s", summary).
+		WithStructuredContent().
+		WithMarkdownTableOf(page.Items).
+		Build()
+}
+
+// listResult renders a list of records with a count summary
+func listResult[T any](records []T, noun string) (interface{}, error) {
+	if records == nil {
+		records = []T{}
+	}
+	return NewResult(records).
+		WithSummary("Found %d %s.", len(records), noun).
+		WithStructuredContent().
+		WithMarkdownTable().
+		Build()
+}
@@ -0,0 +1,790 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net"
+	"regexp"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"sample-mcp/ops"
+)
+
+func setupQueryRegistry(t *testing.T) (*Registry, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	queryOps, err := ops.NewQueryOps(ops.WithGormDB(gormDB))
+	require.NoError(t, err)
+
+	registry := NewRegistry()
+	require.NoError(t, NewQueryHandler(queryOps).Register(registry))
+	return registry, mock
+}
+
+func callTool(t *testing.T, registry *Registry, name string, params map[string]interface{}) (interface{}, error) {
+	handler, ok := registry.Handler(name)
+	require.True(t, ok, "tool %s should be registered", name)
+	return handler(context.Background(), server.ToolCallRequest{Name: name, Parameters: params})
+}
+
+func resultText(t *testing.T, response interface{}) string {
+	responseMap, ok := response.(map[string]interface{})
+	require.True(t, ok, "Response should be a map")
+
+	content, ok := responseMap["content"].([]map[string]interface{})
+	require.True(t, ok, "Response should have content array")
+	require.NotEmpty(t, content)
+
+	var texts []string
+	for _, item := range content {
+		text, ok := item["text"].(string)
+		require.True(t, ok, "Content item should have text field")
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// requireToolError asserts that the response is an error result of the given kind
+func requireToolError(t *testing.T, response interface{}, err error, kind ErrorKind, message string) {
+	require.NoError(t, err, "tool errors are reported as results, not protocol errors")
+
+	responseMap, ok := response.(map[string]interface{})
+	require.True(t, ok, "Response should be a map")
+	assert.Equal(t, true, responseMap["isError"])
+
+	structured := responseMap["structuredContent"].(map[string]interface{})["error"].(map[string]interface{})
+	assert.Equal(t, kind, structured["kind"])
+	assert.Contains(t, structured["message"], message)
+}
+
+func TestQueryHandler_Register(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, NewQueryHandler(nil).Register(registry))
+
+	names := make(map[string]bool)
+	for _, tool := range registry.Tools() {
+		assert.NotEmpty(t, tool.Description, "tool %s should have a description", tool.Name)
+		names[tool.Name] = true
+	}
+
+	assert.True(t, names["get_account_by_id"])
+	assert.True(t, names["search_accounts"])
+	assert.True(t, names["get_transactions_by_date_range"])
+	assert.True(t, names["get_account_balance"])
+	assert.True(t, names["get_transaction_summary_by_category"])
+	assert.True(t, names["get_account_balances_in_currency"])
+	assert.True(t, names["cash_flow_report"])
+	assert.True(t, names["category_breakdown_report"])
+	assert.True(t, names["get_budget_status"])
+	assert.True(t, names["list_recurring_payments"])
+	assert.True(t, names["detect_spending_anomalies"])
+	assert.True(t, names["get_balance_as_of"])
+	assert.True(t, names["get_running_balances"])
+	assert.True(t, names["get_daily_balances"])
+	assert.True(t, names["get_transaction_summary_by_category_in_currency"])
+	assert.True(t, names["export_transactions"])
+	assert.True(t, names["list_categorization_rules"])
+
+	mcpServer := server.NewMCPServer("test", "0.0.0", nil)
+	assert.NoError(t, registry.AddTo(context.Background(), mcpServer))
+}
+
+func TestQueryHandler_GetAccountByID(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 ORDER BY "accounts"."account_id" LIMIT $2`)).
+		WithArgs(uint(7), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "currency", "created_at", "updated_at"}).
+			AddRow(7, "Checking Account ****0007", "Checking", "EUR", time.Now(), time.Now()))
+
+	response, err := callTool(t, registry, "get_account_by_id", map[string]interface{}{"account_id": float64(7)})
+
+	assert.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, `"name": "Checking Account ****0007"`)
+	assert.Contains(t, text, "| account_id | name | account_type | currency | created_at | updated_at |")
+	assert.Contains(t, text, `"currency": "EUR"`)
+
+	structured := response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Equal(t, "Checking Account ****0007", structured["name"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAccountByID_NotFound(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 ORDER BY "accounts"."account_id" LIMIT $2`)).
+		WithArgs(uint(999), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "created_at", "updated_at"}))
+
+	response, err := callTool(t, registry, "get_account_by_id", map[string]interface{}{"account_id": float64(999)})
+
+	requireToolError(t, response, err, ErrorNotFound, "account 999 not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAllAccounts_DatabaseUnavailable(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts"`)).
+		WillReturnError(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED})
+
+	response, err := callTool(t, registry, "get_all_accounts", nil)
+
+	requireToolError(t, response, err, ErrorUnavailable, "database unavailable")
+}
+
+func TestQueryHandler_GetAllAccounts(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "created_at", "updated_at"}).
+			AddRow(1, "Checking Account ****0001", "Checking", time.Now(), time.Now()).
+			AddRow(2, "Savings | Joint", "Savings", time.Now(), time.Now()))
+
+	response, err := callTool(t, registry, "get_all_accounts", nil)
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Found 2 accounts.")
+	assert.Contains(t, text, `Savings \| Joint`)
+
+	structured := response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Len(t, structured["items"], 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAllTransactions_Paginated(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	columns := []string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "created_at", "updated_at"}
+	date := time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" ORDER BY transaction_date ASC,transaction_id ASC LIMIT $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 1, 1, -10.5, date, nil, time.Now(), time.Now()).
+			AddRow(2, 1, 1, -20.0, date, nil, time.Now(), time.Now()).
+			AddRow(3, 1, 1, -30.0, date, nil, time.Now(), time.Now()))
+
+	response, err := callTool(t, registry, "get_all_transactions", map[string]interface{}{"limit": float64(2)})
+
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, response), "Found 2 transactions. More are available")
+	structured := response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Len(t, structured["items"], 2)
+	cursor, ok := structured["next_cursor"].(string)
+	require.True(t, ok, "a full page should have a next_cursor")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transaction_date > $1 OR (transaction_date = $2 AND transaction_id > $3) ORDER BY transaction_date ASC,transaction_id ASC LIMIT $4`)).
+		WithArgs(date, date, uint64(2), 3).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(3, 1, 1, -30.0, date, nil, time.Now(), time.Now()))
+
+	response, err = callTool(t, registry, "get_all_transactions", map[string]interface{}{"limit": float64(2), "cursor": cursor})
+
+	require.NoError(t, err)
+	structured = response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Len(t, structured["items"], 1)
+	assert.NotContains(t, structured, "next_cursor")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAllTransactions_InvalidCursor(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "get_all_transactions", map[string]interface{}{"cursor": "not a cursor"})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "invalid 'cursor' parameter")
+}
+
+func TestQueryHandler_SearchTransactions(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) `+
+		`AND transactions.transaction_date >= $2 AND transactions.amount > 0 ORDER BY amount DESC,transaction_id DESC LIMIT $3`)).
+		WithArgs(uint(3), time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), 51).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date"}))
+
+	response, err := callTool(t, registry, "search_transactions", map[string]interface{}{
+		"account_ids": []interface{}{float64(3)},
+		"start_date":  "2023-06-01",
+		"sign":        "income",
+		"sort":        []interface{}{"-amount"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, response), "Found 0 transactions.")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_SearchTransactions_InvalidInput(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{name: "reversed amounts", params: map[string]interface{}{"min_amount": float64(10), "max_amount": float64(-10)}, want: "min_amount is greater than max_amount"},
+		{name: "unknown sign", params: map[string]interface{}{"sign": "refund"}, want: "'sign'"},
+		{name: "unknown sort field", params: map[string]interface{}{"sort": []interface{}{"description"}}, want: "'sort[0]'"},
+		{name: "duplicate sort field", params: map[string]interface{}{"sort": []interface{}{"amount", "-amount"}}, want: "amount is sorted by twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := callTool(t, registry, "search_transactions", tt.params)
+			requireToolError(t, response, err, ErrorInvalidInput, tt.want)
+		})
+	}
+}
+
+// exportedResource returns the resource embedded in the last content item of an export_transactions result
+func exportedResource(t *testing.T, response interface{}) map[string]interface{} {
+	content := response.(map[string]interface{})["content"].([]map[string]interface{})
+	last := content[len(content)-1]
+	require.Equal(t, "resource", last["type"])
+	return last["resource"].(map[string]interface{})
+}
+
+func TestQueryHandler_ExportTransactions(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+	date := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.category_id IN ($1) `+
+		`ORDER BY transaction_date ASC,transaction_id ASC LIMIT $2`)).
+		WithArgs(uint(4), 201).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description"}).
+			AddRow(9, 3, 4, "-42.00", date, "Hardware store"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "currency"}).AddRow(3, "Checking Account ****0003", "USD"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).AddRow(4, "Home Maintenance", "Expense"))
+
+	response, err := callTool(t, registry, "export_transactions", map[string]interface{}{
+		"category_ids": []interface{}{float64(4)},
+	})
+
+	require.NoError(t, err)
+	resource := exportedResource(t, response)
+	assert.Equal(t, "export://transactions.csv", resource["uri"])
+	assert.Equal(t, "text/csv", resource["mimeType"])
+	assert.Equal(t, "transaction_id,date,account_id,account,currency,category_id,category,category_type,amount,description,external_id\n"+
+		"9,2023-06-01,3,Checking Account ****0003,USD,4,Home Maintenance,Expense,-42.00,Hardware store,\n", resource["text"])
+	structured := response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Equal(t, float64(1), structured["transactions"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_ExportTransactions_XLSX(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) `+
+		`ORDER BY transaction_date ASC,transaction_id ASC LIMIT $2`)).
+		WithArgs(uint(3), 201).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+
+	response, err := callTool(t, registry, "export_transactions", map[string]interface{}{
+		"account_ids": []interface{}{float64(3)},
+		"format":      "xlsx",
+	})
+
+	require.NoError(t, err)
+	resource := exportedResource(t, response)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", resource["mimeType"])
+	data, err := base64.StdEncoding.DecodeString(resource["blob"].(string))
+	require.NoError(t, err)
+	_, err = zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err, "the blob should be an XLSX archive")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_ExportTransactions_InvalidFormat(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "export_transactions", map[string]interface{}{"format": "pdf"})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "'format'")
+}
+
+func TestQueryHandler_GetAccountByID_StringParameter(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 ORDER BY "accounts"."account_id" LIMIT $2`)).
+		WithArgs(uint(3), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "created_at", "updated_at"}).
+			AddRow(3, "Checking Account ****0003", "Checking", time.Now(), time.Now()))
+
+	_, err := callTool(t, registry, "get_account_by_id", map[string]interface{}{"account_id": "3"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAccountByID_InvalidParameter(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{name: "missing", params: map[string]interface{}{}},
+		{name: "negative", params: map[string]interface{}{"account_id": float64(-1)}},
+		{name: "fractional", params: map[string]interface{}{"account_id": 1.5}},
+		{name: "not a number", params: map[string]interface{}{"account_id": "abc"}},
+		{name: "wrong type", params: map[string]interface{}{"account_id": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := callTool(t, registry, "get_account_by_id", tt.params)
+			requireToolError(t, response, err, ErrorInvalidInput, "'account_id'")
+		})
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetTransactionsByDateRange(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transaction_date BETWEEN $1 AND $2 ORDER BY transaction_date ASC,transaction_id ASC LIMIT $3`)).
+		WithArgs(start, end, 51).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "created_at", "updated_at"}))
+
+	response, err := callTool(t, registry, "get_transactions_by_date_range", map[string]interface{}{
+		"start_date": "2023-01-01",
+		"end_date":   "2023-01-31",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetTransactionsByDateRange_InvalidRange(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{name: "bad format", params: map[string]interface{}{"start_date": "01/01/2023", "end_date": "2023-01-31"}, want: "'start_date'"},
+		{name: "missing end", params: map[string]interface{}{"start_date": "2023-01-01"}, want: "missing 'end_date'"},
+		{name: "reversed", params: map[string]interface{}{"start_date": "2023-02-01", "end_date": "2023-01-01"}, want: "end_date is before start_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := callTool(t, registry, "get_transactions_by_date_range", tt.params)
+			requireToolError(t, response, err, ErrorInvalidInput, tt.want)
+		})
+	}
+}
+
+func TestQueryHandler_GetAccountBalance(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccount)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1`)).
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(1250.5))
+
+	response, err := callTool(t, registry, "get_account_balance", map[string]interface{}{"account_id": float64(1)})
+
+	assert.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, `"account_id": 1`)
+	assert.Contains(t, text, `"balance": 1250.5`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetBalanceAsOf(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccount)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1 AND transaction_date <= $2`)).
+		WithArgs(uint(1), time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("1523.07"))
+
+	response, err := callTool(t, registry, "get_balance_as_of", map[string]interface{}{"account_id": float64(1), "date": "2021-06-30"})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, `"date": "2021-06-30"`)
+	assert.Contains(t, text, `"balance": 1523.07`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAccountBalance_NotFound(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccount)).
+		WithArgs(9, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+
+	response, err := callTool(t, registry, "get_account_balance", map[string]interface{}{"account_id": float64(9)})
+
+	requireToolError(t, response, err, ErrorNotFound, "account 9 not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetRunningBalances(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM (SELECT transactions.*, SUM(transactions.amount) OVER`)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "running_balance"}).
+			AddRow(10, 1, 2, "-20.00", day, "980.00"))
+
+	response, err := callTool(t, registry, "get_running_balances", map[string]interface{}{"account_id": float64(1)})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Found 1 transactions.")
+	assert.Contains(t, text, `"running_balance": 980.00`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetDailyBalances(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1 AND transaction_date < $2`)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("100.00"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transaction_date, SUM(amount) AS total FROM "transactions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_date", "total"}).
+			AddRow(time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC), "-40.00"))
+
+	response, err := callTool(t, registry, "get_daily_balances", map[string]interface{}{
+		"account_id": float64(1),
+		"start_date": "2021-06-29",
+		"end_date":   "2021-07-01",
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Found 3 days.")
+	assert.Contains(t, text, "| 2021-06-30 | 60.00 |")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetDailyBalances_RangeTooLong(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "get_daily_balances", map[string]interface{}{
+		"account_id": float64(1),
+		"start_date": "2020-01-01",
+		"end_date":   "2023-12-31",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "at most 1000 days")
+}
+
+func TestQueryHandler_CashFlowReport(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+	january := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT CAST(DATE_TRUNC('month', CAST(transactions.transaction_date AS TIMESTAMP)) AS DATE) AS period_start, SUM(`)).
+		WithArgs(january, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), 1001).
+		WillReturnRows(sqlmock.NewRows([]string{"period_start", "income", "expenses", "net", "count"}).
+			AddRow(january, "3000.00", "-1250.10", "1749.90", 12).
+			AddRow(january.AddDate(0, 1, 0), "0", "-980.00", "-980.00", 9))
+
+	response, err := callTool(t, registry, "cash_flow_report", map[string]interface{}{
+		"start_date": "2023-01-01",
+		"end_date":   "2023-12-31",
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "2 rows; income 3000.00, expenses -2230.10, net 769.90 in total.")
+	assert.Contains(t, text, `"period_start": "2023-02-01T00:00:00Z"`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_CashFlowReport_InvalidInterval(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "cash_flow_report", map[string]interface{}{"interval": "fortnight"})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "interval")
+}
+
+func TestQueryHandler_CategoryBreakdownReport(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT categories.category_id, categories.name AS category_name, categories.category_type, `)).
+		WithArgs("Expense").
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "category_name", "category_type", "income", "total", "count", "min_amount", "max_amount"}).
+			AddRow(3, "Groceries", "Expense", 0, "-120.40", 4, "-50.00", "-10.40"))
+
+	response, err := callTool(t, registry, "category_breakdown_report", map[string]interface{}{"category_type": "Expense"})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "0 income categories totalling 0.00, 1 expense categories totalling -120.40, net -120.40.")
+	assert.Contains(t, text, `"average": -30.10`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_CategoryBreakdownReport_ReversedRange(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "category_breakdown_report", map[string]interface{}{
+		"start_date": "2023-02-01",
+		"end_date":   "2023-01-01",
+	})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "end_date is before start_date")
+}
+
+func TestQueryHandler_GetBudgetStatus(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+	june := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "budgets" WHERE category_id IN ($1,$2) ORDER BY budget_id`)).
+		WithArgs(3, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"budget_id", "category_id", "period", "amount", "rollover", "start_date"}).
+			AddRow(1, 3, "month", "300.00", false, june).
+			AddRow(2, 5, "month", "100.00", false, june))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).
+			AddRow(3, "Groceries", "Expense").
+			AddRow(5, "Dining", "Expense"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT transactions.category_id, SUM(transactions.amount) AS total FROM "transactions"`)).
+		WithArgs(3, 5, june, time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "total"}).
+			AddRow(3, "-100.00").
+			AddRow(5, "-120.00"))
+
+	response, err := callTool(t, registry, "get_budget_status", map[string]interface{}{
+		"date":             "2024-06-15",
+		"category_ids":     []interface{}{float64(3), float64(5)},
+		"over_budget_only": true,
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "2 budgets as of 2024-06-15; 1 over budget, 1 projected over budget by the end of the period.")
+	assert.Contains(t, text, `"category_name": "Dining"`)
+	assert.NotContains(t, text, `"category_name": "Groceries"`)
+	assert.Contains(t, text, `"remaining": -20.00`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestQueryHandler_ListRecurringPayments analyzes a past day: the payment detected then replaces the row stored by a
+// later analysis, a payment first seen later is left out, and nothing is written
+func TestQueryHandler_ListRecurringPayments(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+	on := func(month time.Month) time.Time { return time.Date(2024, month, 1, 0, 0, 0, 0, time.UTC) }
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "transaction_date", "amount", "description"}).
+			AddRow(1, 2, 6, on(3), "-45.00", "Insurance Premium 3/2024").
+			AddRow(2, 2, 6, on(4), "-45.00", "Insurance Premium 4/2024").
+			AddRow(3, 2, 6, on(5), "-45.00", "Insurance Premium 5/2024"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(2, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(6, "Insurance"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "recurring_payments" WHERE recurring_payments.account_id IN ($1)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"recurring_payment_id", "account_id", "category_id", "description_key", "description",
+			"frequency", "typical_amount", "occurrences", "first_date", "last_date", "last_amount", "next_due_date"}).
+			AddRow(1, 2, 6, "insurance premium", "Insurance Premium 9/2024", "monthly", "-45.00", 7, on(3), on(9), "-45.00", on(10)).
+			AddRow(2, 2, 7, "gym", "Gym", "monthly", "-30.00", 3, on(7), on(9), "-30.00", on(10)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(2, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(6, "Insurance").AddRow(7, "Fitness"))
+
+	response, err := callTool(t, registry, "list_recurring_payments", map[string]interface{}{
+		"date":         "2024-06-15",
+		"account_ids":  []interface{}{float64(2)},
+		"flagged_only": true,
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Found 1 recurring payments as of 2024-06-15; 1 with missed occurrences, 0 with unusually large amounts.")
+	assert.Contains(t, text, `"recurring_payment_id": 1`)
+	assert.Contains(t, text, `"last_date": "2024-05-01T00:00:00Z"`)
+	assert.Contains(t, text, `"next_due_date": "2024-06-01T00:00:00Z"`)
+	assert.Contains(t, text, `"overdue": true`)
+	assert.NotContains(t, text, "Gym")
+	assert.NoError(t, mock.ExpectationsWereMet(), "listing must not write")
+}
+
+func TestQueryHandler_DetectSpendingAnomalies(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	// Six months of utility bills before a bill three times as large in the range checked
+	rows := sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "transaction_date", "amount"})
+	for i, amount := range []string{"-80.00", "-85.00", "-90.00", "-80.00", "-95.00", "-85.00"} {
+		rows.AddRow(i+1, 1, 7, time.Date(2023, time.Month(7+i), 10, 0, 0, 0, 0, time.UTC), amount)
+	}
+	rows.AddRow(7, 1, 7, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), "-255.00")
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) AND transactions.transaction_date >= $2`)).
+		WithArgs(1, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), 201).
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(7, "Utilities"))
+
+	response, err := callTool(t, registry, "detect_spending_anomalies", map[string]interface{}{
+		"start_date":      "2024-01-01",
+		"end_date":        "2024-01-31",
+		"account_ids":     []interface{}{float64(1)},
+		"baseline_months": float64(6),
+		"sensitivity":     "high",
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "1 unusual transactions and 1 unusual monthly totals from 2024-01-01 to 2024-01-31.")
+	assert.Contains(t, text, "-255.00 is 3.0 times the median -85.00 of the 6 earlier transactions in Utilities on Checking within 6 months")
+	assert.Contains(t, text, "The 2024-01 total of -255.00 in Utilities on Checking is 3.0 times the median -85.00 of the 6 months before")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_DetectSpendingAnomalies_InvalidSensitivity(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "detect_spending_anomalies", map[string]interface{}{"sensitivity": "extreme"})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "sensitivity")
+}
+
+func TestQueryHandler_GetAccountBalancesInCurrency(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE account_id IN ($1) ORDER BY account_id`)).
+		WithArgs(uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "currency"}).
+			AddRow(2, "Girokonto", "Checking", "EUR"))
+	mock.ExpectQuery(`SELECT transactions.transaction_date, .* FROM "transactions"`).
+		WithArgs(uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_date", "account_id", "currency", "category_name", "total_amount", "count"}).
+			AddRow(day, 2, "EUR", "Salary", "100.00", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base_currency", "quote_currency", "rate"}).
+			AddRow(day, "EUR", "USD", "1.0956"))
+
+	response, err := callTool(t, registry, "get_account_balances_in_currency", map[string]interface{}{
+		"currency":    "USD",
+		"account_ids": []interface{}{float64(2)},
+	})
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Found 1 accounts, 109.56 USD in total.")
+	assert.Contains(t, text, `"balance": 100.00`)
+	assert.Contains(t, text, `"converted_balance": 109.56`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetTransactionSummaryByCategoryInCurrency_NoRate(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(`SELECT transactions.transaction_date, .* FROM "transactions"`).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_date", "account_id", "currency", "category_name", "total_amount", "count"}).
+			AddRow(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), 2, "EUR", "Salary", "100.00", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base_currency", "quote_currency", "rate"}))
+
+	response, err := callTool(t, registry, "get_transaction_summary_by_category_in_currency", map[string]interface{}{"currency": "USD"})
+
+	requireToolError(t, response, err, ErrorNotFound, "no exchange rate from EUR to USD on 2024-01-02")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetAccountBalancesInCurrency_InvalidCurrency(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "get_account_balances_in_currency", map[string]interface{}{"currency": "DOLLARS"})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "currency")
+}
+
+func TestQueryHandler_GetLatestTransactions_DefaultLimit(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE account_id = $1 ORDER BY transaction_date DESC LIMIT $2`)).
+		WithArgs(uint(2), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "created_at", "updated_at"}))
+
+	_, err := callTool(t, registry, "get_latest_transactions", map[string]interface{}{"account_id": float64(2)})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryHandler_GetLatestTransactions_InvalidLimit(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "get_latest_transactions", map[string]interface{}{"account_id": float64(2), "limit": float64(0)})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "invalid 'limit' parameter: must be at least 1")
+}
+
+func TestQueryHandler_GetCategoriesByType_InvalidType(t *testing.T) {
+	registry, _ := setupQueryRegistry(t)
+
+	response, err := callTool(t, registry, "get_categories_by_type", map[string]interface{}{"category_type": "Transfer"})
+
+	requireToolError(t, response, err, ErrorInvalidInput, "must be one of Income, Expense")
+}
+
+func TestQueryHandler_ListCategorizationRules(t *testing.T) {
+	registry, mock := setupQueryRegistry(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules" ORDER BY priority DESC, rule_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "name", "priority", "category_id", "counterparty", "max_amount"}).
+			AddRow(2, "Large streaming", 5, 4, "Netflix", "-50.00").
+			AddRow(1, "Streaming", 0, 3, "Netflix", nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" IN ($1,$2)`)).
+		WithArgs(4, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Streaming").AddRow(4, "Entertainment"))
+
+	response, err := callTool(t, registry, "list_categorization_rules", nil)
+
+	require.NoError(t, err)
+	text := resultText(t, response)
+	assert.Contains(t, text, "Found 2 categorization rules.")
+	assert.Contains(t, text, "Large streaming")
+
+	structured := response.(map[string]interface{})["structuredContent"].(map[string]interface{})
+	assert.Len(t, structured["items"], 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/FreePeak/cortex/pkg/types"
+	"github.com/go-playground/validator/v10"
+
+	"sample-mcp/pkg/money"
+)
+
+// Struct tags read by the registry when deriving a tool schema from an input struct:
+//
+//	json:"account_id"             parameter name
+//	description:"The account ID"  parameter description
+//	validate:"required,min=1"     validation rules; required, min/max/gte/lte/len and oneof are also published in the schema
+//	default:"10"                  value used when the parameter is omitted
+const (
+	tagDescription = "description"
+	tagValidate    = "validate"
+	tagDefault     = "default"
+)
+
+// NoInput is the input type of tools that take no parameters
+type NoInput struct{}
+
+// Validatable is implemented by input structs that need checks spanning several fields
+type Validatable interface {
+	Validate() error
+}
+
+// TypedHandler handles a tool call whose parameters were decoded into In
+type TypedHandler[In any] func(ctx context.Context, input In) (interface{}, error)
+
+// Registry holds MCP tools and their handlers.
+// Tools registered through Register get their schema derived from a typed input struct,
+// and their parameters decoded and validated before the handler is called.
+type Registry struct {
+	validate *validator.Validate
+	tools    []*types.Tool
+	handlers map[string]server.ToolHandler
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return parameterName(field)
+	})
+	// Dates are validated as their YYYY-MM-DD string so that rules such as required apply
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(Date).String()
+	}, Date{})
+	// Amounts are validated as their decimal value, so that min and max rules are in currency units
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(money.Amount).Float64()
+	}, money.Amount(0))
+
+	return &Registry{
+		validate: validate,
+		handlers: make(map[string]server.ToolHandler),
+	}
+}
+
+// Register adds a tool whose parameters are described by the fields of the In struct
+func Register[In any](r *Registry, name, description string, handler TypedHandler[In]) error {
+	inputType := reflect.TypeOf((*In)(nil)).Elem()
+	if inputType.Kind() != reflect.Struct {
+		return fmt.Errorf("tool %s: input type %s must be a struct", name, inputType)
+	}
+
+	fields, err := inputFields(inputType)
+	if err != nil {
+		return fmt.Errorf("tool %s: %w", name, err)
+	}
+
+	tool := &types.Tool{
+		Name:        name,
+		Description: description,
+		Parameters:  make([]types.ToolParameter, 0, len(fields)),
+	}
+	for _, f := range fields {
+		tool.Parameters = append(tool.Parameters, f.parameter())
+	}
+
+	return r.Add(tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		log.Printf("Handling %s tool call", request.Name)
+
+		var input In
+		if err := r.decode(request.Parameters, fields, &input); err != nil {
+			return nil, err
+		}
+		return handler(ctx, input)
+	})
+}
+
+// Add registers a tool with a hand-written schema and handler.
+// The handler only sees the accounts granted to the caller (see withPrincipalScope),
+// and the errors it returns are reported to the client as error results (see ErrorResult).
+func (r *Registry) Add(tool *types.Tool, handler server.ToolHandler) error {
+	if tool == nil || tool.Name == "" {
+		return fmt.Errorf("tool must have a name")
+	}
+	if handler == nil {
+		return fmt.Errorf("tool %s: handler cannot be nil", tool.Name)
+	}
+	if _, exists := r.handlers[tool.Name]; exists {
+		return fmt.Errorf("tool %s is already registered", tool.Name)
+	}
+
+	r.tools = append(r.tools, tool)
+	r.handlers[tool.Name] = func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		result, err := handler(withPrincipalScope(ctx), request)
+		if err != nil {
+			log.Printf("Tool %s failed: %v", request.Name, err)
+			return ErrorResult(err), nil
+		}
+		return result, nil
+	}
+	return nil
+}
+
+// Tools returns the registered tools in registration order
+func (r *Registry) Tools() []*types.Tool {
+	return append([]*types.Tool(nil), r.tools...)
+}
+
+// Handler returns the handler of a registered tool
+func (r *Registry) Handler(name string) (server.ToolHandler, bool) {
+	handler, ok := r.handlers[name]
+	return handler, ok
+}
+
+// AddTo adds every registered tool to the MCP server
+func (r *Registry) AddTo(ctx context.Context, mcpServer *server.MCPServer) error {
+	for _, tool := range r.tools {
+		if err := mcpServer.AddTool(ctx, tool, r.handlers[tool.Name]); err != nil {
+			return fmt.Errorf("failed to add tool %s: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
+
+// decode converts raw tool parameters into the input struct and validates it.
+// Fields are decoded one at a time so that errors can name the offending parameter.
+func (r *Registry) decode(params map[string]interface{}, fields []inputField, input interface{}) error {
+	target := reflect.ValueOf(input).Elem()
+	for _, f := range fields {
+		value, ok := params[f.name]
+		if !ok || value == nil {
+			if f.defaultValue == "" {
+				continue
+			}
+			value = f.defaultValue
+		}
+
+		data, err := json.Marshal(f.coerce(value))
+		if err != nil {
+			return InvalidInputError("invalid '%s' parameter: %v", f.name, err)
+		}
+
+		if err := json.Unmarshal(data, target.FieldByIndex(f.index).Addr().Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return InvalidInputError("invalid '%s' parameter: expected %s", f.name, f.expected())
+			}
+			return InvalidInputError("invalid '%s' parameter: %v", f.name, err)
+		}
+	}
+
+	if err := r.validate.Struct(input); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) && len(validationErrs) > 0 {
+			return InvalidInputError("%s", validationMessage(validationErrs[0]))
+		}
+		return InvalidInputError("invalid parameters: %v", err)
+	}
+
+	if v, ok := input.(Validatable); ok {
+		if err := v.Validate(); err != nil {
+			return InvalidInputError("%v", err)
+		}
+	}
+	return nil
+}
+
+// inputField describes one parameter derived from an input struct field
+type inputField struct {
+	index        []int
+	name         string
+	description  string
+	schemaType   string
+	itemsType    string
+	required     bool
+	defaultValue string
+	rules        string
+	date         bool
+	amount       bool
+	goKind       string
+}
+
+func (f inputField) parameter() types.ToolParameter {
+	param := types.ToolParameter{
+		Name:        f.name,
+		Description: f.describe(),
+		Type:        f.schemaType,
+		Required:    f.required,
+	}
+	if f.itemsType != "" {
+		param.Items = map[string]interface{}{"type": f.itemsType}
+	}
+	return param
+}
+
+// describe appends the constraints that cannot be expressed in the cortex schema to the description
+func (f inputField) describe() string {
+	var constraints []string
+	for _, rule := range strings.Split(f.rules, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "oneof":
+			constraints = append(constraints, "one of: "+strings.Join(strings.Fields(value), ", "))
+		case "min", "gte":
+			constraints = append(constraints, f.bound("minimum", value))
+		case "max", "lte":
+			constraints = append(constraints, f.bound("maximum", value))
+		case "len":
+			constraints = append(constraints, f.bound("exactly", value))
+		}
+	}
+	if f.date {
+		constraints = append(constraints, "format YYYY-MM-DD")
+	}
+	if f.defaultValue != "" {
+		constraints = append(constraints, "default "+f.defaultValue)
+	}
+
+	if len(constraints) == 0 {
+		return f.description
+	}
+	if f.description == "" {
+		return strings.Join(constraints, "; ")
+	}
+	return fmt.Sprintf("%s (%s)", f.description, strings.Join(constraints, "; "))
+}
+
+func (f inputField) bound(label, value string) string {
+	switch f.schemaType {
+	case "string":
+		return fmt.Sprintf("%s length %s", label, value)
+	case "array":
+		return fmt.Sprintf("%s %s items", label, value)
+	default:
+		return fmt.Sprintf("%s %s", label, value)
+	}
+}
+
+// expected describes the JSON type the field accepts
+func (f inputField) expected() string {
+	switch {
+	case f.date:
+		return "a date in YYYY-MM-DD format"
+	case f.schemaType == "integer":
+		if strings.HasPrefix(f.goKind, "uint") {
+			return "a non-negative integer"
+		}
+		return "an integer"
+	case f.schemaType == "array" || f.schemaType == "object":
+		return "an " + f.schemaType
+	default:
+		return "a " + f.schemaType
+	}
+}
+
+// coerce converts string values into the JSON type expected by the field,
+// since clients frequently quote numbers and booleans
+func (f inputField) coerce(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	s = strings.TrimSpace(s)
+	if f.amount {
+		// Amounts parse decimal strings exactly, so they are not converted to a float on the way
+		return s
+	}
+	switch f.schemaType {
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+var (
+	dateType   = reflect.TypeOf(Date{})
+	amountType = reflect.TypeOf(money.Amount(0))
+)
+
+// inputFields derives the parameters of a struct type, flattening embedded structs
+func inputFields(t reflect.Type) ([]inputField, error) {
+	return collectFields(t, nil)
+}
+
+func collectFields(t reflect.Type, parentIndex []int) ([]inputField, error) {
+	var fields []inputField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int(nil), parentIndex...), i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			embedded, err := collectFields(field.Type, index)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := parameterName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		schema := schemaType(fieldType)
+		if schema == "" {
+			return nil, fmt.Errorf("field %s has unsupported type %s", field.Name, field.Type)
+		}
+
+		rules := field.Tag.Get(tagValidate)
+		f := inputField{
+			index:        index,
+			name:         name,
+			description:  field.Tag.Get(tagDescription),
+			schemaType:   schema,
+			required:     hasRule(rules, "required"),
+			defaultValue: field.Tag.Get(tagDefault),
+			rules:        rules,
+			date:         fieldType == dateType,
+			amount:       fieldType == amountType,
+			goKind:       fieldType.Kind().String(),
+		}
+		if schema == "array" {
+			f.itemsType = schemaType(fieldType.Elem())
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parameterName returns the JSON name of a struct field
+func parameterName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// schemaType maps a Go type to its JSON schema type
+func schemaType(t reflect.Type) string {
+	if t == dateType {
+		return "string"
+	}
+	if t == amountType {
+		return "number"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaType(t.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+func hasRule(rules, rule string) bool {
+	for _, r := range strings.Split(rules, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// validationMessage renders a validator error in terms of the tool parameter
+func validationMessage(fe validator.FieldError) string {
+	name := fe.Field()
+	isString := fe.Kind() == reflect.String
+
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("missing '%s' parameter", name)
+	case "min", "gte":
+		if isString {
+			return fmt.Sprintf("invalid '%s' parameter: must be at least %s characters", name, fe.Param())
+		}
+		return fmt.Sprintf("invalid '%s' parameter: must be at least %s", name, fe.Param())
+	case "max", "lte":
+		if isString {
+			return fmt.Sprintf("invalid '%s' parameter: must be at most %s characters", name, fe.Param())
+		}
+		return fmt.Sprintf("invalid '%s' parameter: must be at most %s", name, fe.Param())
+	case "oneof":
+		return fmt.Sprintf("invalid '%s' parameter: must be one of %s", name, strings.Join(strings.Fields(fe.Param()), ", "))
+	default:
+		return fmt.Sprintf("invalid '%s' parameter: failed '%s' validation", name, fe.Tag())
+	}
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/FreePeak/cortex/pkg/tools"
+	"github.com/FreePeak/cortex/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPeriod struct {
+	From Date  `json:"from" description:"First day" validate:"required"`
+	To   *Date `json:"to" description:"Last day"`
+}
+
+type testInput struct {
+	testPeriod
+	AccountID uint     `json:"account_id" description:"The account" validate:"required"`
+	Kind      string   `json:"kind" description:"The kind" validate:"required,oneof=Income Expense"`
+	Limit     int      `json:"limit" validate:"min=1,max=50" default:"5"`
+	Ratio     float64  `json:"ratio"`
+	Verbose   bool     `json:"verbose"`
+	Tags      []string `json:"tags" validate:"max=3"`
+	internal  string
+}
+
+func (in testInput) Validate() error {
+	if in.To != nil && in.To.Before(in.From.Time) {
+		return errors.New("to must not be before from")
+	}
+	return nil
+}
+
+func findParameter(t *testing.T, tool *types.Tool, name string) types.ToolParameter {
+	for _, param := range tool.Parameters {
+		if param.Name == name {
+			return param
+		}
+	}
+	t.Fatalf("parameter %s not found", name)
+	return types.ToolParameter{}
+}
+
+func TestRegister_DerivesSchema(t *testing.T) {
+	registry := NewRegistry()
+	err := Register(registry, "test_tool", "A test tool", func(ctx context.Context, in testInput) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	tool := registry.Tools()[0]
+	assert.Equal(t, "test_tool", tool.Name)
+	assert.Equal(t, "A test tool", tool.Description)
+	assert.Len(t, tool.Parameters, 8)
+
+	from := findParameter(t, tool, "from")
+	assert.Equal(t, "string", from.Type)
+	assert.True(t, from.Required)
+	assert.Equal(t, "First day (format YYYY-MM-DD)", from.Description)
+
+	to := findParameter(t, tool, "to")
+	assert.Equal(t, "string", to.Type)
+	assert.False(t, to.Required)
+
+	accountID := findParameter(t, tool, "account_id")
+	assert.Equal(t, "integer", accountID.Type)
+	assert.True(t, accountID.Required)
+
+	kind := findParameter(t, tool, "kind")
+	assert.Equal(t, "string", kind.Type)
+	assert.Equal(t, "The kind (one of: Income, Expense)", kind.Description)
+
+	limit := findParameter(t, tool, "limit")
+	assert.Equal(t, "integer", limit.Type)
+	assert.False(t, limit.Required)
+	assert.Equal(t, "minimum 1; maximum 50; default 5", limit.Description)
+
+	assert.Equal(t, "number", findParameter(t, tool, "ratio").Type)
+	assert.Equal(t, "boolean", findParameter(t, tool, "verbose").Type)
+
+	tags := findParameter(t, tool, "tags")
+	assert.Equal(t, "array", tags.Type)
+	assert.Equal(t, map[string]interface{}{"type": "string"}, tags.Items)
+	assert.Equal(t, "maximum 3 items", tags.Description)
+}
+
+func TestRegister_DecodesAndValidates(t *testing.T) {
+	registry := NewRegistry()
+
+	var received testInput
+	err := Register(registry, "test_tool", "A test tool", func(ctx context.Context, in testInput) (interface{}, error) {
+		received = in
+		return "ok", nil
+	})
+	require.NoError(t, err)
+
+	handler, ok := registry.Handler("test_tool")
+	require.True(t, ok)
+
+	response, err := handler(context.Background(), server.ToolCallRequest{
+		Name: "test_tool",
+		Parameters: map[string]interface{}{
+			"from":       "2023-01-01",
+			"to":         "2023-01-31",
+			"account_id": "12",
+			"kind":       "Expense",
+			"ratio":      0.5,
+			"verbose":    "true",
+			"tags":       []interface{}{"a", "b"},
+			"unknown":    "ignored",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", response)
+
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), received.From.Time)
+	require.NotNil(t, received.To)
+	assert.Equal(t, time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), received.To.Time)
+	assert.Equal(t, uint(12), received.AccountID)
+	assert.Equal(t, "Expense", received.Kind)
+	assert.Equal(t, 5, received.Limit)
+	assert.Equal(t, 0.5, received.Ratio)
+	assert.True(t, received.Verbose)
+	assert.Equal(t, []string{"a", "b"}, received.Tags)
+}
+
+func TestRegister_InvalidParameters(t *testing.T) {
+	registry := NewRegistry()
+	called := false
+	err := Register(registry, "test_tool", "A test tool", func(ctx context.Context, in testInput) (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+	require.NoError(t, err)
+	handler, _ := registry.Handler("test_tool")
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{"from": "2023-01-01", "account_id": float64(1), "kind": "Income"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(map[string]interface{})
+		want   string
+	}{
+		{name: "missing required", modify: func(p map[string]interface{}) { delete(p, "account_id") }, want: "missing 'account_id' parameter"},
+		{name: "missing date", modify: func(p map[string]interface{}) { delete(p, "from") }, want: "missing 'from' parameter"},
+		{name: "bad date", modify: func(p map[string]interface{}) { p["from"] = "yesterday" }, want: "invalid 'from' parameter"},
+		{name: "negative id", modify: func(p map[string]interface{}) { p["account_id"] = float64(-4) }, want: "invalid 'account_id' parameter: expected a non-negative integer"},
+		{name: "enum", modify: func(p map[string]interface{}) { p["kind"] = "Other" }, want: "invalid 'kind' parameter: must be one of Income, Expense"},
+		{name: "below min", modify: func(p map[string]interface{}) { p["limit"] = float64(0) }, want: "invalid 'limit' parameter: must be at least 1"},
+		{name: "above max", modify: func(p map[string]interface{}) { p["limit"] = float64(51) }, want: "invalid 'limit' parameter: must be at most 50"},
+		{name: "wrong type", modify: func(p map[string]interface{}) { p["verbose"] = "maybe" }, want: "invalid 'verbose' parameter: expected a boolean"},
+		{name: "cross field", modify: func(p map[string]interface{}) { p["to"] = "2022-12-31" }, want: "to must not be before from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid()
+			tt.modify(params)
+
+			response, err := handler(context.Background(), server.ToolCallRequest{Name: "test_tool", Parameters: params})
+			requireToolError(t, response, err, ErrorInvalidInput, tt.want)
+		})
+	}
+
+	assert.False(t, called, "handler must not be called with invalid parameters")
+}
+
+func TestRegister_RejectsNonStructInput(t *testing.T) {
+	registry := NewRegistry()
+	err := Register(registry, "bad_tool", "A bad tool", func(ctx context.Context, in string) (interface{}, error) {
+		return nil, nil
+	})
+	assert.Error(t, err)
+}
+
+func TestRegistry_Add(t *testing.T) {
+	registry := NewRegistry()
+	echoTool := tools.NewTool("echo", tools.WithDescription("Echoes back the input message"))
+
+	require.NoError(t, registry.Add(echoTool, HandleEcho))
+	assert.Error(t, registry.Add(echoTool, HandleEcho), "duplicate tool names must be rejected")
+	assert.Error(t, registry.Add(tools.NewTool("nil_handler"), nil))
+
+	_, ok := registry.Handler("echo")
+	assert.True(t, ok)
+	_, ok = registry.Handler("missing")
+	assert.False(t, ok)
+}
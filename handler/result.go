@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+func textContent(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "text",
+		"text": text,
+	}
+}
+
+// Result builds a successful tool result.
+// The data is always returned as JSON text using the json tags of the entities,
+// optionally accompanied by an MCP structuredContent block, a Markdown table and an embedded resource.
+type Result struct {
+	data       interface{}
+	structured bool
+	markdown   bool
+	table      interface{}
+	summary    string
+	resource   map[string]interface{}
+}
+
+// NewResult creates a Result for the given data
+func NewResult(data interface{}) *Result {
+	return &Result{data: data}
+}
+
+// WithStructuredContent adds the data as an MCP structuredContent block.
+// Lists are wrapped in an object under "items", since structuredContent must be a JSON object.
+func (r *Result) WithStructuredContent() *Result {
+	r.structured = true
+	return r
+}
+
+// WithMarkdownTable adds a human-readable Markdown table of the data
+func (r *Result) WithMarkdownTable() *Result {
+	r.markdown = true
+	return r
+}
+
+// WithMarkdownTableOf adds a Markdown table of rows in place of the data,
+// for data wrapping a list such as a page
+func (r *Result) WithMarkdownTableOf(rows interface{}) *Result {
+	r.markdown = true
+	r.table = rows
+	return r
+}
+
+// WithSummary adds a short sentence describing the data before the JSON content
+func (r *Result) WithSummary(format string, args ...interface{}) *Result {
+	r.summary = fmt.Sprintf(format, args...)
+	return r
+}
+
+// WithTextResource embeds a text file, such as a CSV export, as an MCP resource
+func (r *Result) WithTextResource(uri, mimeType, text string) *Result {
+	r.resource = map[string]interface{}{"uri": uri, "mimeType": mimeType, "text": text}
+	return r
+}
+
+// WithBlobResource embeds a binary file, such as a spreadsheet, as an MCP resource encoded in base64
+func (r *Result) WithBlobResource(uri, mimeType string, data []byte) *Result {
+	r.resource = map[string]interface{}{"uri": uri, "mimeType": mimeType, "blob": base64.StdEncoding.EncodeToString(data)}
+	return r
+}
+
+// Build renders the result in the format expected by the MCP server
+func (r *Result) Build() (map[string]interface{}, error) {
+	data, err := json.MarshalIndent(r.data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	var content []map[string]interface{}
+	if r.summary != "" {
+		content = append(content, textContent(r.summary))
+	}
+	content = append(content, textContent(string(data)))
+	if r.markdown {
+		tableData := r.data
+		if r.table != nil {
+			tableData = r.table
+		}
+		if table := MarkdownTable(tableData); table != "" {
+			content = append(content, textContent(table))
+		}
+	}
+	if r.resource != nil {
+		content = append(content, map[string]interface{}{"type": "resource", "resource": r.resource})
+	}
+
+	result := map[string]interface{}{
+		"content": content,
+	}
+	if r.structured {
+		var structured interface{}
+		if err := json.Unmarshal(data, &structured); err != nil {
+			return nil, fmt.Errorf("failed to encode structured result: %w", err)
+		}
+		if _, isObject := structured.(map[string]interface{}); !isObject {
+			structured = map[string]interface{}{"items": structured}
+		}
+		result["structuredContent"] = structured
+	}
+	return result, nil
+}
+
+// MarkdownTable renders a struct, a slice of structs or a map as a Markdown table.
+// Columns follow the json tags of the struct fields; nested entities are rendered by their name.
+func MarkdownTable(data interface{}) string {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	var rows []reflect.Value
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return "_No results._"
+		}
+		for i := 0; i < v.Len(); i++ {
+			rows = append(rows, indirect(v.Index(i)))
+		}
+	case reflect.Struct:
+		rows = append(rows, v)
+	case reflect.Map:
+		return markdownMap(v)
+	default:
+		return ""
+	}
+
+	if rows[0].Kind() != reflect.Struct {
+		return ""
+	}
+	columns := tableColumns(rows[0].Type())
+	if len(columns) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	headers := make([]string, len(columns))
+	separators := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.name
+		separators[i] = "---"
+	}
+	writeRow(&sb, headers)
+	writeRow(&sb, separators)
+
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		if row.IsValid() {
+			for i, c := range columns {
+				cells[i] = formatCell(row.FieldByIndex(c.index))
+			}
+		}
+		writeRow(&sb, cells)
+	}
+	return sb.String()
+}
+
+type tableColumn struct {
+	name  string
+	index []int
+}
+
+// tableColumns lists the JSON-visible fields of a struct, flattening embedded structs
+func tableColumns(t reflect.Type) []tableColumn {
+	var columns []tableColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for _, c := range tableColumns(field.Type) {
+				c.index = append([]int{i}, c.index...)
+				columns = append(columns, c)
+			}
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, tableColumn{name: name, index: []int{i}})
+	}
+	return columns
+}
+
+func markdownMap(v reflect.Value) string {
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]string, v.Len())
+	for _, key := range v.MapKeys() {
+		k := fmt.Sprint(key.Interface())
+		keys = append(keys, k)
+		values[k] = formatCell(v.MapIndex(key))
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	writeRow(&sb, []string{"field", "value"})
+	writeRow(&sb, []string{"---", "---"})
+	for _, k := range keys {
+		writeRow(&sb, []string{k, values[k]})
+	}
+	return sb.String()
+}
+
+func writeRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("| ")
+	sb.WriteString(strings.Join(cells, " | "))
+	sb.WriteString(" |\n")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// formatCell renders a single value for a Markdown table cell
+func formatCell(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			return t.Format(DateLayout)
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	if marshaler, ok := v.Interface().(json.Marshaler); ok {
+		if data, err := marshaler.MarshalJSON(); err == nil {
+			return escapeCell(strings.Trim(string(data), `"`))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// Nested entities such as a transaction's account are shown by name
+		if name := v.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+			return escapeCell(name.String())
+		}
+		return ""
+	case reflect.Slice, reflect.Array, reflect.Map:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return escapeCell(string(data))
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%.2f", v.Float())
+	default:
+		return escapeCell(fmt.Sprint(v.Interface()))
+	}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func TestResult_Build(t *testing.T) {
+	accounts := []entity.Account{
+		{AccountID: 1, Name: "Checking Account ****0001", AccountType: "Checking"},
+		{AccountID: 2, Name: "Savings Account ****0002", AccountType: "Savings"},
+	}
+
+	result, err := NewResult(accounts).WithStructuredContent().WithSummary("Found %d accounts.", len(accounts)).Build()
+	require.NoError(t, err)
+
+	content := result["content"].([]map[string]interface{})
+	require.Len(t, content, 2)
+	assert.Equal(t, "Found 2 accounts.", content[0]["text"])
+
+	var decoded []entity.Account
+	require.NoError(t, json.Unmarshal([]byte(content[1]["text"].(string)), &decoded))
+	assert.Equal(t, accounts[1].Name, decoded[1].Name)
+
+	structured := result["structuredContent"].(map[string]interface{})
+	items := structured["items"].([]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, float64(1), items[0].(map[string]interface{})["account_id"])
+}
+
+func TestResult_BuildObject(t *testing.T) {
+	result, err := NewResult(AccountBalance{AccountID: 3, Balance: money.MustParse("12.5")}).WithStructuredContent().Build()
+	require.NoError(t, err)
+
+	content := result["content"].([]map[string]interface{})
+	require.Len(t, content, 1)
+	assert.Equal(t, map[string]interface{}{"account_id": float64(3), "balance": 12.5}, result["structuredContent"])
+}
+
+func TestResult_BuildWithoutOptions(t *testing.T) {
+	result, err := NewResult("ok").Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]interface{}{textContent(`"ok"`)}, result["content"])
+	assert.NotContains(t, result, "structuredContent")
+}
+
+func TestResult_BuildWithResource(t *testing.T) {
+	result, err := NewResult("ok").WithBlobResource("export://transactions.xlsx", "application/zip", []byte{0x50, 0x4b}).Build()
+	require.NoError(t, err)
+
+	content := result["content"].([]map[string]interface{})
+	require.Len(t, content, 2)
+	assert.Equal(t, map[string]interface{}{
+		"type":     "resource",
+		"resource": map[string]interface{}{"uri": "export://transactions.xlsx", "mimeType": "application/zip", "blob": "UEs="},
+	}, content[1])
+}
+
+func TestMarkdownTable(t *testing.T) {
+	description := "Coffee | snacks"
+	transactions := []entity.Transaction{
+		{
+			TransactionID:   10,
+			AccountID:       1,
+			CategoryID:      4,
+			Amount:          money.MustParse("-3.5"),
+			TransactionDate: time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC),
+			Description:     &description,
+			CreatedAt:       time.Date(2023, 3, 14, 9, 30, 0, 0, time.UTC),
+			Account:         &entity.Account{Name: "Checking Account ****0001"},
+		},
+	}
+
+	table := MarkdownTable(transactions)
+
+	assert.Equal(t, "| transaction_id | account_id | category_id | amount | transaction_date | description | external_id | created_at | updated_at | account | category |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| 10 | 1 | 4 | -3.50 | 2023-03-14 | Coffee \\| snacks |  | 2023-03-14T09:30:00Z |  | Checking Account ****0001 |  |\n", table)
+}
+
+func TestMarkdownTable_EmptyAndUnsupported(t *testing.T) {
+	assert.Equal(t, "_No results._", MarkdownTable([]entity.Account{}))
+	assert.Equal(t, "", MarkdownTable("plain text"))
+	assert.Equal(t, "", MarkdownTable((*entity.Account)(nil)))
+}
+
+func TestMarkdownTable_Map(t *testing.T) {
+	table := MarkdownTable(map[string]interface{}{"b": 2.0, "a": "x"})
+
+	assert.Equal(t, "| field | value |\n| --- | --- |\n| a | x |\n| b | 2.00 |\n", table)
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"context"
+
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/auth"
+)
+
+// withPrincipalScope restricts the repository queries of a tool call to the accounts granted to its principal.
+// Calls without a principal, such as those over stdio, are not restricted.
+func withPrincipalScope(ctx context.Context) context.Context {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.Grant.AllAccounts {
+		return ctx
+	}
+	return repository.WithScope(ctx, &repository.Scope{
+		AccountIDs:   principal.Grant.AccountIDs,
+		AccountTypes: principal.Grant.AccountTypes,
+	})
+}
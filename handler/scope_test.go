@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/auth"
+)
+
+func TestWithPrincipalScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		scope     *repository.Scope
+	}{
+		{name: "no principal"},
+		{name: "all accounts", principal: &auth.Principal{Subject: "admin", Grant: auth.Grant{AllAccounts: true}}},
+		{
+			name:      "granted accounts",
+			principal: &auth.Principal{Subject: "alice", Grant: auth.Grant{AccountIDs: []uint{1, 2}, AccountTypes: []string{"Savings"}}},
+			scope:     &repository.Scope{AccountIDs: []uint{1, 2}, AccountTypes: []string{"Savings"}},
+		},
+		{name: "no grant", principal: &auth.Principal{Subject: "guest"}, scope: &repository.Scope{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.NewContext(ctx, tt.principal)
+			}
+
+			scope, ok := repository.ScopeFromContext(withPrincipalScope(ctx))
+			if tt.scope == nil {
+				assert.False(t, ok, "the call must not be restricted")
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.scope, scope)
+		})
+	}
+}
+
+func TestRegistry_AppliesPrincipalScope(t *testing.T) {
+	registry := NewRegistry()
+	var seen *repository.Scope
+	err := Register(registry, "scoped", "Captures the scope", func(ctx context.Context, in NoInput) (interface{}, error) {
+		seen, _ = repository.ScopeFromContext(ctx)
+		return NewResult("ok").Build()
+	})
+	require.NoError(t, err)
+
+	handler, ok := registry.Handler("scoped")
+	require.True(t, ok)
+
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "alice", Grant: auth.Grant{AccountIDs: []uint{7}}})
+	_, err = handler(ctx, server.ToolCallRequest{Name: "scoped"})
+	require.NoError(t, err)
+	assert.Equal(t, &repository.Scope{AccountIDs: []uint{7}}, seen)
+}
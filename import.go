@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"sample-mcp/importer"
+)
+
+// runImport imports the bank statement files named on the command line:
+//
+//	sample-mcp import -mapping bank.yml -account Checking -category Uncategorized statement.csv
+//	sample-mcp import -format ofx -accounts 000123456789=Checking -category Uncategorized statement.ofx
+//
+// The command runs with the database owner's rights, so the write policy of the server does not apply.
+func runImport(pool *gorm.DB, args []string, logger *log.Logger) error {
+	var accounts []string
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "csv", "format of the files: csv, ofx, qfx, qif or camt053")
+	mappingFile := flags.String("mapping", "", "YAML file describing the columns of the CSV files")
+	account := flags.String("account", "", "account of lines that do not name their own")
+	category := flags.String("category", "", "category of lines that neither name their own nor match a categorization rule")
+	flags.Func("accounts", "number=name pair mapping a statement account to an account, repeatable", func(pair string) error {
+		accounts = append(accounts, pair)
+		return nil
+	})
+	dayFirst := flags.Bool("day-first", false, "QIF dates are written day first, like 31/12/2024")
+	decimalComma := flags.Bool("decimal-comma", false, "QIF amounts are written like 1.234,56")
+	dryRun := flags.Bool("dry-run", false, "report what would be imported without saving anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("no files to import")
+	}
+
+	accountMap, err := importer.ParseAccountMap(accounts)
+	if err != nil {
+		return err
+	}
+	parse := func(in io.Reader) ([]importer.Statement, error) {
+		return importer.ParseStatements(*format, in, importer.QIFFormat{DayFirst: *dayFirst, DecimalComma: *decimalComma})
+	}
+	if strings.EqualFold(*format, "csv") {
+		var mapping importer.CSVMapping
+		if *mappingFile != "" {
+			data, err := os.ReadFile(*mappingFile)
+			if err != nil {
+				return fmt.Errorf("failed to read the mapping: %w", err)
+			}
+			if err := yaml.Unmarshal(data, &mapping); err != nil {
+				return fmt.Errorf("failed to parse the mapping: %w", err)
+			}
+		}
+		parse = func(in io.Reader) ([]importer.Statement, error) {
+			lines, err := importer.ParseCSV(in, mapping)
+			return []importer.Statement{{Lines: lines}}, err
+		}
+	}
+
+	imp := importer.New(pool)
+	options := importer.Options{Account: *account, Category: *category, Accounts: accountMap, DryRun: *dryRun}
+	for _, path := range flags.Args() {
+		statements, err := parseFile(path, parse)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		result, err := imp.ImportStatements(context.Background(), statements, options)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if result.DryRun {
+			logger.Printf("%s: would import %d of %d lines and skip %d duplicates", path, result.Imported, result.Lines, result.Duplicates)
+		} else {
+			logger.Printf("%s: imported %d of %d lines, skipped %d duplicates", path, result.Imported, result.Lines, result.Duplicates)
+		}
+		for _, balance := range result.Balances {
+			logger.Printf("%s: statement balance of %s on %s is %s, recorded transactions add up to %s",
+				path, balance.Account, balance.Date.Format(time.DateOnly), balance.Statement, balance.Recorded)
+		}
+	}
+	return nil
+}
+
+// parseFile opens a file and parses its statements
+func parseFile(path string, parse func(io.Reader) ([]importer.Statement, error)) ([]importer.Statement, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parse(file)
+}
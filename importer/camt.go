@@ -0,0 +1,314 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"sample-mcp/pkg/money"
+)
+
+// camtAmount is an amount with its currency; its sign is given by a separate credit/debit indicator
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtDate is a date, or a date time of which the day is taken
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtAccount identifies the account of a statement by IBAN or another ID
+type camtAccount struct {
+	IBAN     string `xml:"Id>IBAN"`
+	Other    string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+}
+
+// camtBalance is a balance of a statement, such as the opening booked balance OPBD or the closing booked one CLBD
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+// camtStatus is the status of an entry, a plain code up to version 2 of camt.053 and a Cd element from version 8 on
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+// camtParty is a debtor or creditor, named directly up to version 2 and through a Pty element from version 8 on
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+// camtTransaction holds the details of one of the transactions an entry books
+type camtTransaction struct {
+	Reference string `xml:"Refs>AcctSvcrRef"`
+	// Amount is given from version 8 on, DetailAmount in the amount details of older versions
+	Amount       *camtAmount `xml:"Amt"`
+	DetailAmount *camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	Indicator    string      `xml:"CdtDbtInd"`
+	Debtor       camtParty   `xml:"RltdPties>Dbtr"`
+	Creditor     camtParty   `xml:"RltdPties>Cdtr"`
+	Unstructured []string    `xml:"RmtInf>Ustrd"`
+	References   []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	Info         string      `xml:"AddtlTxInf"`
+}
+
+// camtEntry is an entry of a statement, booking a single transaction or a batch of them
+type camtEntry struct {
+	Amount       camtAmount        `xml:"Amt"`
+	Indicator    string            `xml:"CdtDbtInd"`
+	Status       camtStatus        `xml:"Sts"`
+	BookingDate  camtDate          `xml:"BookgDt"`
+	Reference    string            `xml:"AcctSvcrRef"`
+	Transactions []camtTransaction `xml:"NtryDtls>TxDtls"`
+	Info         string            `xml:"AddtlNtryInf"`
+}
+
+// ParseCAMT053 reads the statements of an ISO 20022 camt.053 bank to customer statement, of any version.
+// Each statement is named by the IBAN, or other ID, of its account and has a line per booked entry, or per
+// transaction of a batch entry that details their amounts, dated on the booking date. The counterparty and the
+// remittance information become the description and the account servicer's reference the external ID.
+// The opening and closing booked balances become the balances of the statement; pending entries are skipped.
+func ParseCAMT053(in io.Reader) ([]Statement, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	decoder := xml.NewDecoder(strings.NewReader(decodeText(data)))
+	// decodeText has already converted the document to UTF-8, whatever encoding its declaration names
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	var statements []Statement
+	depth, statementDepth := 0, 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case statementDepth == 0 && element.Name.Local == "Stmt":
+				statements = append(statements, Statement{})
+				statementDepth = depth
+			case statementDepth > 0 && depth == statementDepth+1:
+				row, _ := decoder.InputPos()
+				decoded, err := camtStatementElement(decoder, element, row, &statements[len(statements)-1])
+				if err != nil {
+					return nil, err
+				}
+				if decoded {
+					// DecodeElement consumed the end element
+					depth--
+				}
+			}
+		case xml.EndElement:
+			if depth == statementDepth {
+				statementDepth = 0
+			}
+			depth--
+		}
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%w: the file holds no camt.053 statement", ErrInvalidStatement)
+	}
+	return statements, nil
+}
+
+// camtStatementElement decodes the account, a balance or an entry of a statement into it, reporting whether the
+// element was one of them
+func camtStatementElement(decoder *xml.Decoder, element xml.StartElement, row int, statement *Statement) (bool, error) {
+	switch element.Name.Local {
+	case "Acct":
+		var account camtAccount
+		if err := decoder.DecodeElement(&account, &element); err != nil {
+			return true, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, row, err)
+		}
+		statement.Account = strings.ReplaceAll(firstNonEmpty(account.IBAN, account.Other), " ", "")
+		statement.Currency = strings.ToUpper(strings.TrimSpace(account.Currency))
+	case "Bal":
+		var balance camtBalance
+		if err := decoder.DecodeElement(&balance, &element); err != nil {
+			return true, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, row, err)
+		}
+		if err := camtAddBalance(statement, balance, row); err != nil {
+			return true, fmt.Errorf("%w: line %d: balance %v", ErrInvalidStatement, row, err)
+		}
+	case "Ntry":
+		var entry camtEntry
+		if err := decoder.DecodeElement(&entry, &element); err != nil {
+			return true, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, row, err)
+		}
+		lines, err := camtLines(entry, row)
+		if err != nil {
+			return true, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, row, err)
+		}
+		statement.Lines = append(statement.Lines, lines...)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// camtAddBalance adds the booked balances to the statement as balances at the end of a day: the closing balance CLBD
+// and the previously closed one PRCD are those of their date, the opening balance OPBD that of the day before.
+// Available and interim balances are skipped, as are balances of a day the statement already has.
+func camtAddBalance(statement *Statement, balance camtBalance, row int) error {
+	code := strings.ToUpper(strings.TrimSpace(balance.Code))
+	if code != "OPBD" && code != "PRCD" && code != "CLBD" {
+		return nil
+	}
+	amount, err := camtSignedAmount(balance.Amount, balance.Indicator)
+	if err != nil {
+		return err
+	}
+	date, err := balance.Date.day()
+	if err != nil {
+		return err
+	}
+	if code == "OPBD" {
+		date = date.AddDate(0, 0, -1)
+	}
+	if statement.Currency == "" {
+		statement.Currency = strings.ToUpper(strings.TrimSpace(balance.Amount.Currency))
+	}
+
+	for _, stated := range statement.Balances {
+		if stated.Date.Equal(date) {
+			return nil
+		}
+	}
+	statement.Balances = append(statement.Balances, Balance{Row: row, Date: date, Amount: amount})
+	return nil
+}
+
+// camtLines converts a booked entry into lines: one per transaction of a batch whose transactions all have their
+// amount, one for the whole entry otherwise. The lines of a batch without their own reference get the entry's,
+// numbered.
+func camtLines(entry camtEntry, row int) ([]Line, error) {
+	status := strings.ToUpper(strings.TrimSpace(firstNonEmpty(entry.Status.Code, entry.Status.Text)))
+	if status != "" && status != "BOOK" {
+		return nil, nil
+	}
+	date, err := entry.BookingDate.day()
+	if err != nil {
+		return nil, fmt.Errorf("booking %v", err)
+	}
+
+	if len(entry.Transactions) > 1 && entry.detailsAmounts() {
+		lines := make([]Line, 0, len(entry.Transactions))
+		for n, transaction := range entry.Transactions {
+			amount := transaction.Amount
+			if amount == nil {
+				amount = transaction.DetailAmount
+			}
+			signed, err := camtSignedAmount(*amount, firstNonEmpty(transaction.Indicator, entry.Indicator))
+			if err != nil {
+				return nil, err
+			}
+			externalID := transaction.Reference
+			if externalID == "" && entry.Reference != "" {
+				externalID = entry.Reference + "/" + strconv.Itoa(n+1)
+			}
+			lines = append(lines, Line{
+				Row:         row,
+				Date:        date,
+				Amount:      signed,
+				Description: transaction.description(signed, entry.Info),
+				ExternalID:  externalID,
+			})
+		}
+		return lines, nil
+	}
+
+	amount, err := camtSignedAmount(entry.Amount, entry.Indicator)
+	if err != nil {
+		return nil, err
+	}
+	line := Line{Row: row, Date: date, Amount: amount, Description: entry.Info, ExternalID: entry.Reference}
+	if len(entry.Transactions) == 1 {
+		line.Description = entry.Transactions[0].description(amount, entry.Info)
+		line.ExternalID = firstNonEmpty(entry.Reference, entry.Transactions[0].Reference)
+	}
+	return []Line{line}, nil
+}
+
+// detailsAmounts reports whether every transaction of the entry states its amount
+func (e camtEntry) detailsAmounts() bool {
+	for _, transaction := range e.Transactions {
+		if transaction.Amount == nil && transaction.DetailAmount == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// description joins the counterparty, the creditor of money leaving the account and the debtor of money entering it,
+// and the remittance information, falling back on the additional information of the transaction or of its entry
+func (t camtTransaction) description(amount money.Amount, entryInfo string) string {
+	party := t.Debtor
+	if amount < 0 {
+		party = t.Creditor
+	}
+	remittance := strings.Join(t.Unstructured, " ")
+	if remittance == "" {
+		remittance = strings.Join(t.References, " ")
+	}
+	if remittance == "" {
+		remittance = firstNonEmpty(t.Info, entryInfo)
+	}
+
+	var parts []string
+	for _, part := range []string{firstNonEmpty(party.Name, party.PartyName), remittance} {
+		if part = strings.Join(strings.Fields(part), " "); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// camtSignedAmount reads an amount, negative when the indicator says it is debited (DBIT) from the account
+func camtSignedAmount(amount camtAmount, indicator string) (money.Amount, error) {
+	value, err := parseAmount(amount.Value, false)
+	if err != nil {
+		return 0, err
+	}
+	switch strings.ToUpper(strings.TrimSpace(indicator)) {
+	case "CRDT":
+		return value, nil
+	case "DBIT":
+		return -value, nil
+	default:
+		return 0, fmt.Errorf("credit/debit indicator %q is neither CRDT nor DBIT", indicator)
+	}
+}
+
+// day reads the day of the date or date time, such as 2024-03-05 or 2024-03-05T10:30:00+01:00
+func (d camtDate) day() (time.Time, error) {
+	s := strings.TrimSpace(firstNonEmpty(d.Date, d.DateTime))
+	if len(s) < len(time.DateOnly) {
+		return time.Time{}, fmt.Errorf("date %q is not an ISO 8601 date", s)
+	}
+	date, err := time.Parse(time.DateOnly, s[:len(time.DateOnly)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is not an ISO 8601 date", s)
+	}
+	return date, nil
+}
@@ -0,0 +1,105 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"sample-mcp/pkg/money"
+)
+
+// TestParseCAMT053 verifies a version 2 statement in ISO-8859-1 with single, batch and pending entries
+func TestParseCAMT053(t *testing.T) {
+	statements := parseFixture(t, "statement.camt053.xml", func(f *os.File) ([]Statement, error) { return ParseCAMT053(f) })
+
+	if len(statements) != 1 {
+		t.Fatalf("Expected one statement, got %+v", statements)
+	}
+	statement := statements[0]
+	if statement.Account != "DE89370400440532013000" || statement.Currency != "EUR" {
+		t.Errorf("Unexpected statement account %q in %q", statement.Account, statement.Currency)
+	}
+	want := []Line{
+		{Row: 53, Date: on(2024, 3, 1), Amount: money.MustParse("2500"), Description: "ACME GmbH Gehalt März 2024", ExternalID: "2024030100001"},
+		{Row: 84, Date: on(2024, 3, 4), Amount: money.MustParse("-12.50"), Description: "Bäckerei Müller & Söhne Rechnung 4711", ExternalID: "2024030400002"},
+		{Row: 108, Date: on(2024, 3, 5), Amount: money.MustParse("-100"), Description: "Stadtwerke RF18539007547034", ExternalID: "2024030500003/1"},
+		{Row: 108, Date: on(2024, 3, 5), Amount: money.MustParse("-50"), Description: "Telekom Mobilfunk", ExternalID: "2024030500003/2"},
+	}
+	if len(statement.Lines) != len(want) {
+		t.Fatalf("Expected %d lines, got %+v", len(want), statement.Lines)
+	}
+	for i, line := range statement.Lines {
+		if line != want[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], line)
+		}
+	}
+
+	// The opening balance is that of the end of the day before, the available balance is skipped
+	wantBalances := []Balance{
+		{Row: 17, Date: on(2024, 2, 29), Amount: money.MustParse("1000")},
+		{Row: 29, Date: on(2024, 3, 5), Amount: money.MustParse("3337.50")},
+	}
+	if len(statement.Balances) != len(wantBalances) {
+		t.Fatalf("Expected %d balances, got %+v", len(wantBalances), statement.Balances)
+	}
+	for i, balance := range statement.Balances {
+		if balance != wantBalances[i] {
+			t.Errorf("Balance %d: expected %+v, got %+v", i, wantBalances[i], balance)
+		}
+	}
+}
+
+// TestParseCAMT053_Version8 verifies the party names, status codes and date times of later versions
+func TestParseCAMT053_Version8(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"><BkToCstmrStmt><Stmt>
+<Acct><Id><Othr><Id>0532013000</Id></Othr></Id></Acct>
+<Bal><Tp><CdOrPrtry><Cd>PRCD</Cd></CdOrPrtry></Tp><Amt Ccy="CHF">20.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Dt><DtTm>2024-03-04T23:59:59+01:00</DtTm></Dt></Bal>
+<Ntry><Amt Ccy="CHF">5.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts><BookgDt><DtTm>2024-03-05T08:15:00+01:00</DtTm></BookgDt>
+<NtryDtls><TxDtls><Amt Ccy="CHF">5.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><RltdPties><Cdtr><Pty><Nm>Coffee  Bar</Nm></Pty></Cdtr></RltdPties></TxDtls></NtryDtls></Ntry>
+<Ntry><Amt Ccy="CHF">1.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts><BookgDt><Dt>2024-03-05</Dt></BookgDt><AddtlNtryInf>Account fee</AddtlNtryInf></Ntry>
+</Stmt></BkToCstmrStmt></Document>`
+
+	statements, err := ParseCAMT053(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Error parsing camt.053: %v", err)
+	}
+	statement := statements[0]
+	if statement.Account != "0532013000" || statement.Currency != "CHF" {
+		t.Errorf("Unexpected statement account %q in %q", statement.Account, statement.Currency)
+	}
+	if len(statement.Balances) != 1 || statement.Balances[0].Amount != money.MustParse("-20") ||
+		!statement.Balances[0].Date.Equal(on(2024, 3, 4)) {
+		t.Errorf("Unexpected balances %+v", statement.Balances)
+	}
+	if len(statement.Lines) != 2 {
+		t.Fatalf("Expected two lines, got %+v", statement.Lines)
+	}
+	if line := statement.Lines[0]; line.Description != "Coffee Bar" || line.Amount != money.MustParse("-5") ||
+		!line.Date.Equal(on(2024, 3, 5)) || line.ExternalID != "" {
+		t.Errorf("Unexpected line %+v", line)
+	}
+	if line := statement.Lines[1]; line.Description != "Account fee" || line.Amount != money.MustParse("-1.50") {
+		t.Errorf("Unexpected line %+v", line)
+	}
+}
+
+func TestParseCAMT053_Errors(t *testing.T) {
+	entry := func(amount, indicator, date string) string {
+		return "<Document><BkToCstmrStmt><Stmt>\n<Ntry><Amt>" + amount + "</Amt><CdtDbtInd>" + indicator +
+			"</CdtDbtInd><BookgDt><Dt>" + date + "</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>"
+	}
+	for content, message := range map[string]string{
+		"<Document><BkToCstmrStmt><GrpHdr/></BkToCstmrStmt></Document>": "the file holds no camt.053 statement",
+		"<Document><BkToCstmrStmt><Stmt></BkToCstmrStmt>":               "element <Stmt> closed by </BkToCstmrStmt>",
+		entry("1.00", "CRDT", "05.03.2024"):                             `line 2: booking date "05.03.2024" is not an ISO 8601 date`,
+		entry("1.00", "CR", "2024-03-05"):                               `line 2: credit/debit indicator "CR" is neither CRDT nor DBIT`,
+		entry("one", "CRDT", "2024-03-05"):                              `line 2: amount "one" is not a number`,
+	} {
+		_, err := ParseCAMT053(strings.NewReader(content))
+		if !errors.Is(err, ErrInvalidStatement) || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q, got %v", message, err)
+		}
+	}
+}
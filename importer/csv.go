@@ -0,0 +1,344 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"sample-mcp/pkg/money"
+)
+
+// DefaultDateFormat is the date format of CSV files whose mapping sets none
+const DefaultDateFormat = "YYYY-MM-DD"
+
+// CSVMapping describes the layout of a bank's CSV export.
+//
+// Columns are referenced by their header, compared case-insensitively, or by their 1-based number.
+// The signed amount is taken from the first of these conventions the mapping sets:
+//   - Debit and Credit columns: money leaving the account in one, money arriving in the other, both unsigned
+//   - an Amount column with an Indicator column: the amount leaves the account when the indicator is one of
+//     DebitIndicators, such as "D", "DBIT" or "S", and arrives otherwise
+//   - an Amount column alone: negative amounts leave the account, or positive ones with Invert,
+//     as in the exports of many credit cards
+type CSVMapping struct {
+	// Delimiter separates the fields, "," when empty
+	Delimiter string `yaml:"delimiter"`
+	// NoHeader is set when the first row holds data instead of column names
+	NoHeader bool `yaml:"noHeader"`
+	// SkipLines is the number of rows before the header, such as an account summary some banks put there;
+	// empty lines do not count
+	SkipLines int `yaml:"skipLines"`
+
+	Date string `yaml:"date"`
+	// DateFormat spells the dates with YYYY, YY, MM and DD, e.g. DD.MM.YYYY; DefaultDateFormat when empty
+	DateFormat      string   `yaml:"dateFormat"`
+	Amount          string   `yaml:"amount"`
+	Debit           string   `yaml:"debit"`
+	Credit          string   `yaml:"credit"`
+	Indicator       string   `yaml:"indicator"`
+	DebitIndicators []string `yaml:"debitIndicators"`
+	Invert          bool     `yaml:"invert"`
+	// DecimalComma is set for amounts written like 1.234,56
+	DecimalComma bool `yaml:"decimalComma"`
+	// Description lists the columns joined into the description, such as a payee and a reference
+	Description []string `yaml:"description"`
+	// Account and Category are optional columns naming the account and category of each line
+	Account  string `yaml:"account"`
+	Category string `yaml:"category"`
+}
+
+// validate checks that the mapping locates a date and an amount
+func (m CSVMapping) validate() error {
+	if m.Date == "" {
+		return fmt.Errorf("%w: the date column is not mapped", ErrInvalidStatement)
+	}
+	split := m.Debit != "" || m.Credit != ""
+	switch {
+	case split && (m.Debit == "" || m.Credit == ""):
+		return fmt.Errorf("%w: debit and credit columns must be mapped together", ErrInvalidStatement)
+	case !split && m.Amount == "":
+		return fmt.Errorf("%w: neither an amount column nor debit and credit columns are mapped", ErrInvalidStatement)
+	case m.Indicator != "" && len(m.DebitIndicators) == 0:
+		return fmt.Errorf("%w: an indicator column needs the indicators of debits", ErrInvalidStatement)
+	}
+	if utf8.RuneCountInString(m.Delimiter) > 1 {
+		return fmt.Errorf("%w: the delimiter must be a single character", ErrInvalidStatement)
+	}
+	if m.SkipLines < 0 {
+		return fmt.Errorf("%w: skipped lines must not be negative", ErrInvalidStatement)
+	}
+	return nil
+}
+
+// columns resolves the column references of a mapping against the header of a file
+type columns struct {
+	header map[string]int
+}
+
+// index returns the 0-based index of a referenced column, -1 when the reference is empty
+func (c columns) index(ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, nil
+	}
+	if n, err := strconv.Atoi(ref); err == nil && n > 0 {
+		return n - 1, nil
+	}
+	if i, ok := c.header[strings.ToLower(ref)]; ok {
+		return i, nil
+	}
+	if c.header == nil {
+		return 0, fmt.Errorf("%w: column %q must be a number in a file without header", ErrInvalidStatement, ref)
+	}
+	return 0, fmt.Errorf("%w: there is no column %q", ErrInvalidStatement, ref)
+}
+
+// csvLayout holds the column indexes of a mapping, -1 for unmapped columns
+type csvLayout struct {
+	date, amount, debit, credit, indicator, account, category int
+	description                                               []int
+}
+
+func (m CSVMapping) layout(c columns) (csvLayout, error) {
+	var (
+		l   csvLayout
+		err error
+	)
+	for _, column := range []struct {
+		ref   string
+		index *int
+	}{
+		{m.Date, &l.date}, {m.Amount, &l.amount}, {m.Debit, &l.debit}, {m.Credit, &l.credit},
+		{m.Indicator, &l.indicator}, {m.Account, &l.account}, {m.Category, &l.category},
+	} {
+		if *column.index, err = c.index(column.ref); err != nil {
+			return csvLayout{}, err
+		}
+	}
+	for _, ref := range m.Description {
+		i, err := c.index(ref)
+		if err != nil {
+			return csvLayout{}, err
+		}
+		if i >= 0 {
+			l.description = append(l.description, i)
+		}
+	}
+	return l, nil
+}
+
+// ParseCSV reads the lines of a bank's CSV export laid out as the mapping describes.
+// Rows whose cells are all empty are skipped. Any invalid row fails the whole file with ErrInvalidStatement.
+func ParseCSV(in io.Reader, mapping CSVMapping) ([]Line, error) {
+	if err := mapping.validate(); err != nil {
+		return nil, err
+	}
+	layout := dateLayout(mapping.DateFormat)
+
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	if mapping.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	}
+
+	var (
+		lines  []Line
+		fields *csvLayout
+	)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+		}
+		if row <= mapping.SkipLines || blank(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		if fields == nil {
+			c := columns{}
+			if !mapping.NoHeader {
+				c.header = make(map[string]int, len(record))
+				for i, name := range record {
+					c.header[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+				}
+			}
+			l, err := mapping.layout(c)
+			if err != nil {
+				return nil, err
+			}
+			fields = &l
+			if !mapping.NoHeader {
+				continue
+			}
+		}
+
+		parsed, err := mapping.parseRecord(record, *fields, layout)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, line, err)
+		}
+		parsed.Row = line
+		lines = append(lines, parsed)
+	}
+	return lines, nil
+}
+
+// parseRecord converts a row into a Line
+func (m CSVMapping) parseRecord(record []string, fields csvLayout, layout string) (Line, error) {
+	cell := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	date, err := time.Parse(layout, cell(fields.date))
+	if err != nil {
+		return Line{}, fmt.Errorf("date %q does not match the format %s", cell(fields.date), m.dateFormat())
+	}
+
+	var amount money.Amount
+	switch {
+	case fields.debit >= 0:
+		// Some banks fill the unused one of the two cells with zero
+		var debit, credit money.Amount
+		if cell(fields.debit) != "" {
+			if debit, err = parseAmount(cell(fields.debit), m.DecimalComma); err != nil {
+				return Line{}, err
+			}
+		}
+		if cell(fields.credit) != "" {
+			if credit, err = parseAmount(cell(fields.credit), m.DecimalComma); err != nil {
+				return Line{}, err
+			}
+		}
+		if debit != 0 && credit != 0 {
+			return Line{}, fmt.Errorf("both the debit %s and the credit %s are filled", debit, credit)
+		}
+		amount = credit.Abs() - debit.Abs()
+	default:
+		if amount, err = parseAmount(cell(fields.amount), m.DecimalComma); err != nil {
+			return Line{}, err
+		}
+		switch {
+		case fields.indicator >= 0:
+			amount = amount.Abs()
+			if m.isDebit(cell(fields.indicator)) {
+				amount = -amount
+			}
+		case m.Invert:
+			amount = -amount
+		}
+	}
+
+	var parts []string
+	for _, i := range fields.description {
+		if part := cell(i); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return Line{
+		Account:     cell(fields.account),
+		Category:    cell(fields.category),
+		Date:        date,
+		Amount:      amount,
+		Description: strings.Join(parts, " "),
+	}, nil
+}
+
+func (m CSVMapping) isDebit(indicator string) bool {
+	for _, debit := range m.DebitIndicators {
+		if strings.EqualFold(strings.TrimSpace(debit), indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m CSVMapping) dateFormat() string {
+	if m.DateFormat == "" {
+		return DefaultDateFormat
+	}
+	return m.DateFormat
+}
+
+// dateLayout converts a date format spelled with YYYY, YY, MM and DD into a time layout
+func dateLayout(format string) string {
+	if format == "" {
+		format = DefaultDateFormat
+	}
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(format)
+}
+
+// parseAmount reads an amount as banks write them: with thousands separators, a currency symbol or code,
+// and the sign in front, behind or as parentheses, e.g. "-1,234.56", "1.234,56-" or "(12.00) EUR"
+func parseAmount(s string, decimalComma bool) (money.Amount, error) {
+	original := s
+	s = trimToNumber(s, true)
+	negative := strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+	if negative {
+		s = trimToNumber(s[1:len(s)-1], false)
+	}
+	if strings.HasSuffix(s, "-") {
+		negative, s = true, strings.TrimSuffix(s, "-")
+	} else if strings.HasPrefix(s, "-") {
+		negative, s = true, strings.TrimPrefix(s, "-")
+	}
+	s = strings.TrimPrefix(s, "+")
+
+	thousands, decimal := ",", "."
+	if decimalComma {
+		thousands, decimal = ".", ","
+	}
+	s = strings.NewReplacer(thousands, "", "'", "", " ", "", "\u00a0", "", decimal, ".").Replace(s)
+	if s == "" {
+		return 0, fmt.Errorf("amount %q is not a number", original)
+	}
+
+	amount, err := money.Parse(s)
+	if errors.Is(err, money.ErrExcessPrecision) {
+		return 0, fmt.Errorf("amount %q has %w", original, money.ErrExcessPrecision)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a number", original)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// trimToNumber trims everything around a number, such as spaces and currency symbols and codes
+func trimToNumber(s string, parentheses bool) string {
+	return strings.TrimFunc(s, func(r rune) bool {
+		switch {
+		case unicode.IsDigit(r), strings.ContainsRune("+-.,", r):
+			return false
+		case r == '(' || r == ')':
+			return !parentheses
+		default:
+			return true
+		}
+	})
+}
+
+func blank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
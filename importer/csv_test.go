@@ -0,0 +1,191 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sample-mcp/pkg/money"
+)
+
+// TestParseCSV verifies the sign conventions, date formats and column references of the mappings
+func TestParseCSV(t *testing.T) {
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		content string
+		mapping CSVMapping
+		amounts []string
+	}{
+		{
+			name:    "signed amount",
+			content: "Date,Amount,Payee\n2024-03-05,-12.50,Grocer\n2024-03-05,\"1,000.00\",Salary\n",
+			mapping: CSVMapping{Date: "Date", Amount: "Amount", Description: []string{"Payee"}},
+			amounts: []string{"-12.50", "1000.00"},
+		},
+		{
+			name:    "inverted credit card export",
+			content: "date;amount;payee\n05/03/2024;12,50;Grocer\n05/03/2024;-1.000,00;Payment\n",
+			mapping: CSVMapping{Delimiter: ";", Date: "DATE", DateFormat: "DD/MM/YYYY", Amount: "Amount",
+				Invert: true, DecimalComma: true, Description: []string{"payee"}},
+			amounts: []string{"-12.50", "1000.00"},
+		},
+		{
+			name:    "debit and credit columns",
+			content: "Date,Payee,Debit,Credit\n2024-03-05,Grocer,12.50,0.00\n2024-03-05,Salary,,1000\n",
+			mapping: CSVMapping{Date: "Date", Debit: "Debit", Credit: "Credit", Description: []string{"Payee"}},
+			amounts: []string{"-12.50", "1000.00"},
+		},
+		{
+			name:    "indicator column",
+			content: "Date,Amount,D/C,Payee\n2024-03-05,12.50,D,Grocer\n2024-03-05,1000,C,Salary\n",
+			mapping: CSVMapping{Date: "Date", Amount: "Amount", Indicator: "D/C", DebitIndicators: []string{"d"},
+				Description: []string{"Payee"}},
+			amounts: []string{"-12.50", "1000.00"},
+		},
+		{
+			name:    "columns by number after an account summary",
+			content: "Account 1234\n\n03.05.24|(12.50) EUR|Grocer\n03.05.24|1'000.00|Salary\n",
+			mapping: CSVMapping{Delimiter: "|", NoHeader: true, SkipLines: 1, Date: "1", DateFormat: "MM.DD.YY",
+				Amount: "2", Description: []string{"3"}},
+			amounts: []string{"-12.50", "1000.00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := ParseCSV(strings.NewReader(tt.content), tt.mapping)
+			if err != nil {
+				t.Fatalf("Error parsing CSV: %v", err)
+			}
+			if len(lines) != len(tt.amounts) {
+				t.Fatalf("Expected %d lines, got %+v", len(tt.amounts), lines)
+			}
+			for i, line := range lines {
+				if line.Amount != money.MustParse(tt.amounts[i]) {
+					t.Errorf("Line %d: expected amount %s, got %s", i, tt.amounts[i], line.Amount)
+				}
+				if !line.Date.Equal(date) {
+					t.Errorf("Line %d: expected date %s, got %s", i, date, line.Date)
+				}
+			}
+			if lines[0].Description != "Grocer" {
+				t.Errorf("Expected description Grocer, got %q", lines[0].Description)
+			}
+		})
+	}
+}
+
+// TestParseCSV_LineColumns verifies the rows, descriptions and per-line accounts and categories
+func TestParseCSV_LineColumns(t *testing.T) {
+	content := "\ufeffBooked,Account,Category,Payee,Reference,Amount\n" +
+		"2024-03-05,Checking,Groceries,Grocer,,-12.50\n" +
+		",,,,,\n" +
+		"2024-03-06,Savings,Interest,Bank,\"March, 2024\",0.42\n"
+	mapping := CSVMapping{Date: "booked", Amount: "amount", Account: "account", Category: "category",
+		Description: []string{"Payee", "Reference"}}
+
+	lines, err := ParseCSV(strings.NewReader(content), mapping)
+	if err != nil {
+		t.Fatalf("Error parsing CSV: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("Expected the blank row to be skipped, got %+v", lines)
+	}
+	if lines[0].Row != 2 || lines[1].Row != 4 {
+		t.Errorf("Expected rows 2 and 4, got %d and %d", lines[0].Row, lines[1].Row)
+	}
+	if lines[1].Account != "Savings" || lines[1].Category != "Interest" || lines[1].Description != "Bank March, 2024" {
+		t.Errorf("Unexpected line %+v", lines[1])
+	}
+}
+
+func TestParseCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		mapping CSVMapping
+		message string
+	}{
+		{
+			name:    "no amount mapped",
+			content: "Date\n2024-03-05\n",
+			mapping: CSVMapping{Date: "Date"},
+			message: "neither an amount column nor debit and credit columns are mapped",
+		},
+		{
+			name:    "debit without credit",
+			content: "Date,Debit\n2024-03-05,1\n",
+			mapping: CSVMapping{Date: "Date", Debit: "Debit"},
+			message: "debit and credit columns must be mapped together",
+		},
+		{
+			name:    "unknown column",
+			content: "Date,Amount\n2024-03-05,1\n",
+			mapping: CSVMapping{Date: "Date", Amount: "Betrag"},
+			message: `there is no column "Betrag"`,
+		},
+		{
+			name:    "column name without header",
+			content: "2024-03-05,1\n",
+			mapping: CSVMapping{NoHeader: true, Date: "Date", Amount: "2"},
+			message: `column "Date" must be a number in a file without header`,
+		},
+		{
+			name:    "wrong date format",
+			content: "Date,Amount\n2024-03-05,1\n05.03.2024,1\n",
+			mapping: CSVMapping{Date: "Date", Amount: "Amount"},
+			message: `line 3: date "05.03.2024" does not match the format YYYY-MM-DD`,
+		},
+		{
+			name:    "invalid amount",
+			content: "Date,Amount\n2024-03-05,twelve\n",
+			mapping: CSVMapping{Date: "Date", Amount: "Amount"},
+			message: `line 2: amount "twelve" is not a number`,
+		},
+		{
+			name:    "debit and credit filled",
+			content: "Date,Debit,Credit\n2024-03-05,1,2\n",
+			mapping: CSVMapping{Date: "Date", Debit: "Debit", Credit: "Credit"},
+			message: "both the debit 1.00 and the credit 2.00 are filled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.content), tt.mapping)
+			if !errors.Is(err, ErrInvalidStatement) {
+				t.Fatalf("Expected ErrInvalidStatement, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected %q in the error, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for in, want := range map[string]string{
+		"12.5":        "12.50",
+		"+12.50":      "12.50",
+		"-1,234.56":   "-1234.56",
+		"1,234.56-":   "-1234.56",
+		"(12.00) EUR": "-12.00",
+		"$ 1 234.00":  "1234.00",
+		"CHF 1'234":   "1234.00",
+	} {
+		got, err := parseAmount(in, false)
+		if err != nil || got != money.MustParse(want) {
+			t.Errorf("parseAmount(%q) = %s, %v, want %s", in, got, err, want)
+		}
+	}
+
+	got, err := parseAmount("-1.234,56 €", true)
+	if err != nil || got != money.MustParse("-1234.56") {
+		t.Errorf("Expected -1234.56 with a decimal comma, got %s, %v", got, err)
+	}
+	if _, err := parseAmount("-10.005", false); err == nil || err.Error() != `amount "-10.005" has more than two decimals` {
+		t.Errorf("Expected an amount with three decimals to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,553 @@
+// Package importer loads bank statements into the transactions table. Parsers turn each file format into Lines,
+// grouped into a Statement per account by the formats that have several; the Importer resolves their accounts by name
+// and their categories by name or by the categorization rules, skips the lines imported before and inserts the rest
+// of a file in one database transaction, so that a file is imported either completely or not at all.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"sample-mcp/categorize"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/calendar"
+	"sample-mcp/pkg/money"
+)
+
+// ErrInvalidStatement is returned for files that cannot be parsed or whose lines cannot be imported
+var ErrInvalidStatement = errors.New("invalid statement")
+
+// The statement formats ParseStatements reads. CSV files have no fixed layout and are read by ParseCSV.
+const (
+	FormatOFX = "ofx"
+	FormatQFX = "qfx"
+	FormatQIF = "qif"
+	// FormatCAMT053 is the ISO 20022 bank to customer statement
+	FormatCAMT053 = "camt053"
+)
+
+// maxAmount is the exclusive bound of transaction amounts, given by the NUMERIC(10, 2) column
+var maxAmount = money.FromCents(1e10)
+
+// Line is a statement line to import as a transaction
+type Line struct {
+	// Row is the line of the file the statement line starts on, for error messages
+	Row int
+	// Account and Category name the account and category of the line; empty for the account of the Options and
+	// the category of the first categorization rule the line matches, else of the Options
+	Account     string
+	Category    string
+	Date        time.Time
+	Amount      money.Amount
+	Description string
+	// ExternalID is the bank's ID of the line, such as the FITID of an OFX statement; empty when the format has none
+	ExternalID string
+}
+
+// Statement holds the lines of one account of a file and the ledger balances the bank states for it
+type Statement struct {
+	// Account identifies the account in the file, such as the account number of an OFX statement or the account name
+	// of a QIF export; empty when the file does not say
+	Account string
+	// Currency is the ISO 4217 code of the amounts, empty when the file does not say
+	Currency string
+	Lines    []Line
+	// Balances are the ledger balances at the end of the days the file states them for, such as the closing balance
+	// of the statement period
+	Balances []Balance
+}
+
+// Balance is the ledger balance of an account at the end of Date
+type Balance struct {
+	Row    int
+	Date   time.Time
+	Amount money.Amount
+}
+
+// Options names the account of lines that do not name their own, and the category of those that neither name
+// their own nor match a categorization rule
+type Options struct {
+	Account  string
+	Category string
+	// Accounts maps the accounts of the statements of a file, such as OFX account numbers, to account names
+	Accounts map[string]string
+	// DryRun reports what would be imported without saving anything
+	DryRun bool
+}
+
+// Result counts the lines of a file imported and skipped as duplicates
+type Result struct {
+	DryRun     bool `json:"dry_run"`
+	Lines      int  `json:"lines"`
+	Imported   int  `json:"imported"`
+	Duplicates int  `json:"duplicates"`
+	// Categorized counts the lines categorized by a categorization rule
+	Categorized int `json:"categorized"`
+	// DuplicateRows are the file lines of the duplicates
+	DuplicateRows []int `json:"duplicate_rows"`
+	// Balances compare the ledger balances of the statements with the recorded transactions
+	Balances []BalanceCheck `json:"balances,omitempty"`
+}
+
+// BalanceCheck compares the ledger balance of a statement with the sum of the transactions recorded for the account
+// up to the balance date, the lines of the statement included. The two agree once the whole history of the account is recorded.
+type BalanceCheck struct {
+	AccountID  uint         `json:"account_id"`
+	Account    string       `json:"account"`
+	Date       time.Time    `json:"date"`
+	Statement  money.Amount `json:"statement_balance"`
+	Recorded   money.Amount `json:"recorded_balance"`
+	Difference money.Amount `json:"difference"`
+}
+
+// ParseStatements reads a file in one of the statement formats; the QIF format applies to QIF files only
+func ParseStatements(format string, in io.Reader, qif QIFFormat) ([]Statement, error) {
+	switch strings.ToLower(format) {
+	case FormatOFX, FormatQFX:
+		return ParseOFX(in)
+	case FormatQIF:
+		return ParseQIF(in, qif)
+	case FormatCAMT053:
+		return ParseCAMT053(in)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidStatement, format)
+	}
+}
+
+// ParseAccountMap reads the statement accounts of Options.Accounts from number=name pairs,
+// such as 000123456789=Checking
+func ParseAccountMap(pairs []string) (map[string]string, error) {
+	accounts := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		number, name, ok := strings.Cut(pair, "=")
+		number, name = strings.TrimSpace(number), strings.TrimSpace(name)
+		if !ok || number == "" || name == "" {
+			return nil, fmt.Errorf("%w: account mapping %q is not of the form number=name", ErrInvalidStatement, pair)
+		}
+		accounts[number] = name
+	}
+	return accounts, nil
+}
+
+// Importer imports statement lines into a database
+type Importer struct {
+	db *gorm.DB
+}
+
+// New creates an Importer writing to the database
+func New(db *gorm.DB) *Importer {
+	return &Importer{db: db}
+}
+
+// Import inserts the lines as transactions in one database transaction, see ImportStatements
+func (i *Importer) Import(ctx context.Context, lines []Line, options Options) (*Result, error) {
+	return i.ImportStatements(ctx, []Statement{{Lines: lines}}, options)
+}
+
+// ImportStatements inserts the lines of the statements of a file as transactions in one database transaction.
+//
+// A line with an external ID is a duplicate when a transaction of its account has that ID. Other lines, and lines
+// whose external ID is new but which were recorded before without one, are duplicates of a transaction with the same
+// fingerprint of account, date, amount and description; a file listing two identical lines imports both unless two
+// such transactions exist. Either way, re-importing a file or an overlapping one adds only the lines that are new.
+//
+// The account of a statement is the one Options.Accounts maps it to, else Options.Account when the file holds
+// a single statement, else the account named like the statement's. Accounts outside the caller's scope are reported
+// as not existing. A line that does not name its category gets that of the first categorization rule it matches,
+// else Options.Category. The ledger balances of the statements are stored and compared with the recorded transactions.
+func (i *Importer) ImportStatements(ctx context.Context, statements []Statement, options Options) (*Result, error) {
+	var lines []Line
+	for _, statement := range statements {
+		account := options.accountOf(statement, len(statements))
+		for _, line := range statement.Lines {
+			line.Account = firstNonEmpty(line.Account, account)
+			lines = append(lines, line)
+		}
+	}
+
+	result := &Result{DryRun: options.DryRun, Lines: len(lines), DuplicateRows: []int{}}
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		s := newSession(tx)
+		if err := s.checkCurrencies(ctx, statements, options); err != nil {
+			return err
+		}
+		transactions, err := s.resolve(ctx, lines, options, result)
+		if err != nil {
+			return err
+		}
+		fresh, err := s.dedup(ctx, lines, transactions, result)
+		if err != nil {
+			return err
+		}
+		result.Imported = len(fresh)
+		if result.Balances, err = s.checkBalances(ctx, statements, options, fresh); err != nil {
+			return err
+		}
+		if options.DryRun {
+			return nil
+		}
+		if err := s.transactions.CreateAll(ctx, fresh); err != nil {
+			return fmt.Errorf("failed to import transactions: %w", err)
+		}
+		return s.saveBalances(ctx, result.Balances)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// accountOf returns the name of the account of a statement, empty when the options do not give one
+func (o Options) accountOf(statement Statement, statements int) string {
+	if name := o.Accounts[statement.Account]; name != "" {
+		return name
+	}
+	if statements == 1 || statement.Account == "" {
+		return firstNonEmpty(o.Account, statement.Account)
+	}
+	return statement.Account
+}
+
+// session resolves and inserts the lines of one file within its database transaction
+type session struct {
+	accounts       *repository.AccountRepository
+	categories     *repository.CategoryRepository
+	transactions   *repository.TransactionRepository
+	balances       *repository.StatementBalanceRepository
+	rules          *repository.CategorizationRuleRepository
+	accountsByName map[string]*entity.Account
+	categoryIDs    map[string]uint
+	compiledRules  *categorize.Rules
+}
+
+func newSession(tx *gorm.DB) *session {
+	return &session{
+		accounts:       repository.NewAccountRepository(tx),
+		categories:     repository.NewCategoryRepository(tx),
+		transactions:   repository.NewTransactionRepository(tx),
+		balances:       repository.NewStatementBalanceRepository(tx),
+		rules:          repository.NewCategorizationRuleRepository(tx),
+		accountsByName: make(map[string]*entity.Account),
+		categoryIDs:    make(map[string]uint),
+	}
+}
+
+// resolve converts the lines into transactions, looking their accounts and categories up by name and categorizing
+// the lines that name no category by the rules, counting them in the result
+func (s *session) resolve(ctx context.Context, lines []Line, options Options, result *Result) ([]entity.Transaction, error) {
+	transactions := make([]entity.Transaction, len(lines))
+	for i, line := range lines {
+		if line.Amount == 0 {
+			return nil, fmt.Errorf("%w: line %d: amount must not be zero", ErrInvalidStatement, line.Row)
+		}
+		if line.Amount.Abs() >= maxAmount {
+			return nil, fmt.Errorf("%w: line %d: amount must be less than %s", ErrInvalidStatement, line.Row, maxAmount)
+		}
+
+		account, err := s.account(ctx, firstNonEmpty(line.Account, options.Account), line.Row)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions[i] = entity.Transaction{
+			AccountID:       account.AccountID,
+			Amount:          line.Amount,
+			TransactionDate: calendar.Day(line.Date),
+		}
+		if description := strings.TrimSpace(line.Description); description != "" {
+			transactions[i].Description = &description
+		}
+		if externalID := strings.TrimSpace(line.ExternalID); externalID != "" {
+			transactions[i].ExternalID = &externalID
+		}
+
+		if strings.TrimSpace(line.Category) == "" {
+			rules, err := s.categorizationRules(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if rule := rules.Match(transactions[i]); rule != nil {
+				transactions[i].CategoryID = rule.CategoryID
+				result.Categorized++
+				continue
+			}
+		}
+		if transactions[i].CategoryID, err = s.categoryID(ctx, firstNonEmpty(line.Category, options.Category), line.Row); err != nil {
+			return nil, err
+		}
+	}
+	return transactions, nil
+}
+
+// categorizationRules loads and compiles the categorization rules the first time a line needs them
+func (s *session) categorizationRules(ctx context.Context) (*categorize.Rules, error) {
+	if s.compiledRules != nil {
+		return s.compiledRules, nil
+	}
+	stored, err := s.rules.FindAllByPriority(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.compiledRules, err = categorize.Compile(stored); err != nil {
+		return nil, err
+	}
+	return s.compiledRules, nil
+}
+
+func (s *session) account(ctx context.Context, name string, row int) (*entity.Account, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: line %d: no account given", ErrInvalidStatement, row)
+	}
+	if account, ok := s.accountsByName[name]; ok {
+		return account, nil
+	}
+	account, err := s.accounts.FindByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: line %d: account %q does not exist", ErrInvalidStatement, row, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.accountsByName[name] = account
+	return account, nil
+}
+
+// statementRow is the first line of a statement, for error messages
+func statementRow(statement Statement) int {
+	switch {
+	case len(statement.Lines) > 0:
+		return statement.Lines[0].Row
+	case len(statement.Balances) > 0:
+		return statement.Balances[0].Row
+	}
+	return 0
+}
+
+// checkCurrencies rejects statements in another currency than their account
+func (s *session) checkCurrencies(ctx context.Context, statements []Statement, options Options) error {
+	for _, statement := range statements {
+		name := options.accountOf(statement, len(statements))
+		if statement.Currency == "" || name == "" {
+			continue
+		}
+		account, err := s.account(ctx, name, statementRow(statement))
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(statement.Currency, account.Currency) {
+			return fmt.Errorf("%w: the statement of %q is in %s, but account %q is in %s",
+				ErrInvalidStatement, firstNonEmpty(statement.Account, name), statement.Currency, name, account.Currency)
+		}
+	}
+	return nil
+}
+
+// checkBalances compares the ledger balances of the statements with the transactions recorded before
+// and the fresh ones about to be, those dated on or before the balance date
+func (s *session) checkBalances(ctx context.Context, statements []Statement, options Options, fresh []entity.Transaction) ([]BalanceCheck, error) {
+	var checks []BalanceCheck
+	for _, statement := range statements {
+		name := options.accountOf(statement, len(statements))
+		for _, balance := range statement.Balances {
+			account, err := s.account(ctx, name, balance.Row)
+			if err != nil {
+				return nil, err
+			}
+			date := calendar.Day(balance.Date)
+			recorded, err := s.transactions.SumByAccountIDAsOf(ctx, account.AccountID, date)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range fresh {
+				if t.AccountID == account.AccountID && !t.TransactionDate.After(date) {
+					recorded += t.Amount
+				}
+			}
+			checks = append(checks, BalanceCheck{
+				AccountID:  account.AccountID,
+				Account:    account.Name,
+				Date:       date,
+				Statement:  balance.Amount,
+				Recorded:   recorded,
+				Difference: balance.Amount - recorded,
+			})
+		}
+	}
+	return checks, nil
+}
+
+// saveBalances stores the ledger balances, the last one of each account and day when a file states several
+func (s *session) saveBalances(ctx context.Context, checks []BalanceCheck) error {
+	type key struct {
+		accountID uint
+		date      time.Time
+	}
+	var balances []entity.StatementBalance
+	index := make(map[key]int)
+	for _, check := range checks {
+		balance := entity.StatementBalance{AccountID: check.AccountID, BalanceDate: check.Date, Balance: check.Statement}
+		if i, ok := index[key{check.AccountID, check.Date}]; ok {
+			balances[i] = balance
+			continue
+		}
+		index[key{check.AccountID, check.Date}] = len(balances)
+		balances = append(balances, balance)
+	}
+	if err := s.balances.Upsert(ctx, balances); err != nil {
+		return fmt.Errorf("failed to save statement balances: %w", err)
+	}
+	return nil
+}
+
+func (s *session) categoryID(ctx context.Context, name string, row int) (uint, error) {
+	if name == "" {
+		return 0, fmt.Errorf("%w: line %d: no category given and no categorization rule matches", ErrInvalidStatement, row)
+	}
+	if id, ok := s.categoryIDs[name]; ok {
+		return id, nil
+	}
+	category, err := s.categories.FindByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("%w: line %d: category %q does not exist", ErrInvalidStatement, row, name)
+	}
+	if err != nil {
+		return 0, err
+	}
+	s.categoryIDs[name] = category.CategoryID
+	return category.CategoryID, nil
+}
+
+// fingerprint identifies the transactions a bank statement line may duplicate
+type fingerprint struct {
+	accountID   uint
+	date        string
+	amount      money.Amount
+	description string
+}
+
+// fingerprintOf compares descriptions case-insensitively and ignoring runs of spaces,
+// which differ between the exports of the same bank over time
+func fingerprintOf(t entity.Transaction) fingerprint {
+	var description string
+	if t.Description != nil {
+		description = strings.Join(strings.Fields(strings.ToLower(*t.Description)), " ")
+	}
+	return fingerprint{t.AccountID, calendar.Day(t.TransactionDate).Format(time.DateOnly), t.Amount, description}
+}
+
+// recordedMatches counts the recorded transactions sharing a fingerprint, by whether they have an external ID
+type recordedMatches struct {
+	plain, external int
+}
+
+// externalKey identifies a transaction by the external ID of its account
+type externalKey struct {
+	accountID  uint
+	externalID string
+}
+
+// dedup drops the transactions recorded before and counts them in the result. Each recorded transaction matches
+// one line at most: by its external ID, else by its fingerprint.
+func (s *session) dedup(ctx context.Context, lines []Line, transactions []entity.Transaction, result *Result) ([]entity.Transaction, error) {
+	type dateRange struct{ start, end time.Time }
+	ranges := make(map[uint]*dateRange)
+	externalIDs := make(map[uint][]string)
+	var accountIDs []uint
+	for _, t := range transactions {
+		if t.ExternalID != nil {
+			externalIDs[t.AccountID] = append(externalIDs[t.AccountID], *t.ExternalID)
+		}
+		r, ok := ranges[t.AccountID]
+		if !ok {
+			ranges[t.AccountID] = &dateRange{t.TransactionDate, t.TransactionDate}
+			accountIDs = append(accountIDs, t.AccountID)
+			continue
+		}
+		if t.TransactionDate.Before(r.start) {
+			r.start = t.TransactionDate
+		}
+		if t.TransactionDate.After(r.end) {
+			r.end = t.TransactionDate
+		}
+	}
+
+	seen := make(map[externalKey]bool)
+	recorded := make(map[fingerprint]*recordedMatches)
+	for _, accountID := range accountIDs {
+		found, err := s.transactions.FindExternalIDs(ctx, accountID, externalIDs[accountID])
+		if err != nil {
+			return nil, err
+		}
+		for _, externalID := range found {
+			seen[externalKey{accountID, externalID}] = true
+		}
+
+		r := ranges[accountID]
+		existing, err := s.transactions.FindByAccountAndDateRange(ctx, accountID, r.start, r.end)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range existing {
+			key := fingerprintOf(t)
+			if recorded[key] == nil {
+				recorded[key] = &recordedMatches{}
+			}
+			if t.ExternalID != nil {
+				recorded[key].external++
+			} else {
+				recorded[key].plain++
+			}
+		}
+	}
+
+	fresh := make([]entity.Transaction, 0, len(transactions))
+	for i, t := range transactions {
+		if !duplicate(t, seen, recorded) {
+			fresh = append(fresh, t)
+			continue
+		}
+		result.Duplicates++
+		result.DuplicateRows = append(result.DuplicateRows, lines[i].Row)
+	}
+	return fresh, nil
+}
+
+// duplicate reports whether a transaction was recorded before or earlier in the file, consuming the recorded
+// transaction its fingerprint matches
+func duplicate(t entity.Transaction, seen map[externalKey]bool, recorded map[fingerprint]*recordedMatches) bool {
+	if t.ExternalID != nil {
+		key := externalKey{t.AccountID, *t.ExternalID}
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+	}
+	matches := recorded[fingerprintOf(t)]
+	switch {
+	case matches == nil:
+		return false
+	case matches.plain > 0:
+		matches.plain--
+		return true
+	case matches.external > 0 && t.ExternalID == nil:
+		// A transaction imported with an external ID before, now listed in a file without one
+		matches.external--
+		return true
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
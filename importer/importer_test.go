@@ -0,0 +1,416 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"sample-mcp/pkg/money"
+)
+
+const (
+	selectAccountByName  = `SELECT * FROM "accounts" WHERE name = $1 ORDER BY "accounts"."account_id" LIMIT $2`
+	selectCategoryByName = `SELECT * FROM "categories" WHERE name = $1 ORDER BY "categories"."category_id" LIMIT $2`
+	selectExisting       = `SELECT * FROM "transactions" WHERE account_id = $1 AND transaction_date BETWEEN $2 AND $3 ORDER BY transaction_date DESC`
+	selectRules          = `SELECT * FROM "categorization_rules" ORDER BY priority DESC, rule_id`
+)
+
+func setupMockImporter(t *testing.T) (*Importer, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm connection: %v", err)
+	}
+	return New(gormDB), mock
+}
+
+func statementLine(row int, date time.Time, amount, description string) Line {
+	return Line{Row: row, Date: date, Amount: money.MustParse(amount), Description: description}
+}
+
+// expectNames expects the lookups of the Checking account and, as no categorization rule exists,
+// the Groceries category
+func expectNames(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccountByName)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectRules)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(selectCategoryByName)).
+		WithArgs("Groceries", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+}
+
+// TestImporter_Import verifies that lines recorded before are skipped, each recorded transaction once,
+// and the others inserted
+func TestImporter_Import(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+	first := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	lines := []Line{
+		statementLine(2, first, "-12.50", "GROCER  #12"),
+		statementLine(3, first, "-12.50", "Grocer #12"),
+		statementLine(4, last, "-30.00", "Market"),
+	}
+
+	// Expectations
+	mock.ExpectBegin()
+	expectNames(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(selectExisting)).
+		WithArgs(1, first, last).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description"}).
+			AddRow(17, 1, 3, "-12.50", first, "Grocer #12"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions" ("account_id","category_id","amount","transaction_date","description","external_id") VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12) RETURNING "created_at","updated_at","transaction_id"`)).
+		WithArgs(1, 3, "-12.50", first, "Grocer #12", nil, 1, 3, "-30.00", last, "Market", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "transaction_id"}).
+			AddRow(time.Now(), time.Now(), 18).
+			AddRow(time.Now(), time.Now(), 19))
+	mock.ExpectCommit()
+
+	// Test
+	result, err := imp.Import(context.Background(), lines, Options{Account: "Checking", Category: "Groceries"})
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+	if result.Lines != 3 || result.Imported != 2 || result.Duplicates != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(result.DuplicateRows) != 1 || result.DuplicateRows[0] != 2 {
+		t.Errorf("Expected line 2 to be the duplicate, got %v", result.DuplicateRows)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestImporter_Import_DryRun verifies that a dry run reports the lines to import without inserting them
+func TestImporter_Import_DryRun(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectBegin()
+	expectNames(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(selectExisting)).
+		WithArgs(1, date, date).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+	mock.ExpectCommit()
+
+	// Test
+	result, err := imp.Import(context.Background(), []Line{statementLine(2, date, "-12.50", "Grocer")},
+		Options{Account: "Checking", Category: "Groceries", DryRun: true})
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+	if !result.DryRun || result.Imported != 1 || result.Duplicates != 0 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestImporter_Import_CategorizationRules verifies that lines without a category of their own take that of the
+// first matching categorization rule, else that of the options
+func TestImporter_Import_CategorizationRules(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	lines := []Line{
+		statementLine(2, date, "-15.99", "NETFLIX.COM 866-579-7172"),
+		statementLine(3, date, "-30.00", "Market"),
+		{Row: 4, Category: "Gifts", Date: date, Amount: money.MustParse("-25.00"), Description: "Netflix gift card"},
+	}
+
+	// Expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccountByName)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectRules)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "name", "priority", "category_id", "counterparty"}).
+			AddRow(1, "Streaming", 0, 7, "Netflix"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(7, "Streaming"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectCategoryByName)).
+		WithArgs("Groceries", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectCategoryByName)).
+		WithArgs("Gifts", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(8, "Gifts"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectExisting)).
+		WithArgs(1, date, date).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions" ("account_id","category_id","amount","transaction_date","description","external_id") `+
+		`VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12),($13,$14,$15,$16,$17,$18) RETURNING "created_at","updated_at","transaction_id"`)).
+		WithArgs(1, 7, "-15.99", date, "NETFLIX.COM 866-579-7172", nil,
+			1, 3, "-30.00", date, "Market", nil,
+			1, 8, "-25.00", date, "Netflix gift card", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "transaction_id"}).
+			AddRow(time.Now(), time.Now(), 18).
+			AddRow(time.Now(), time.Now(), 19).
+			AddRow(time.Now(), time.Now(), 20))
+	mock.ExpectCommit()
+
+	// Test
+	result, err := imp.Import(context.Background(), lines, Options{Account: "Checking", Category: "Groceries"})
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+	if result.Imported != 3 || result.Categorized != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestImporter_Import_NoCategory verifies that a line no rule matches needs the category of the options
+func TestImporter_Import_NoCategory(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccountByName)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectRules)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id"}))
+	mock.ExpectRollback()
+
+	// Test
+	_, err := imp.Import(context.Background(), []Line{statementLine(2, date, "-30.00", "Market")}, Options{Account: "Checking"})
+	if !errors.Is(err, ErrInvalidStatement) ||
+		err.Error() != "invalid statement: line 2: no category given and no categorization rule matches" {
+		t.Errorf("Expected the missing category to be reported, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestImporter_Import_UnknownAccount verifies that a file naming an unknown account is rolled back
+// without inserting any of its lines
+func TestImporter_Import_UnknownAccount(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	lines := []Line{
+		statementLine(2, date, "-12.50", "Grocer"),
+		{Row: 3, Account: "Brokerage", Date: date, Amount: money.MustParse("-1"), Description: "Fee"},
+	}
+
+	// Expectations
+	mock.ExpectBegin()
+	expectNames(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccountByName)).
+		WithArgs("Brokerage", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}))
+	mock.ExpectRollback()
+
+	// Test
+	_, err := imp.Import(context.Background(), lines, Options{Account: "Checking", Category: "Groceries"})
+	if !errors.Is(err, ErrInvalidStatement) || err.Error() != `invalid statement: line 3: account "Brokerage" does not exist` {
+		t.Errorf("Expected the unknown account to be reported, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestImporter_Import_InvalidAmounts(t *testing.T) {
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	for amount, message := range map[string]string{
+		"0":            "invalid statement: line 2: amount must not be zero",
+		"-100000000.0": "invalid statement: line 2: amount must be less than 100000000.00",
+	} {
+		imp, mock := setupMockImporter(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		_, err := imp.Import(context.Background(), []Line{statementLine(2, date, amount, "")},
+			Options{Account: "Checking", Category: "Groceries"})
+		if err == nil || err.Error() != message {
+			t.Errorf("Expected %q for %s, got %v", message, amount, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	}
+}
+
+// TestImporter_ImportStatements verifies that lines are skipped by their external ID, by their fingerprint when
+// recorded before without one, and when listed twice, and that the ledger balance is compared and stored
+func TestImporter_ImportStatements(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+	first := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	line := func(row int, date time.Time, amount, description, externalID string) Line {
+		l := statementLine(row, date, amount, description)
+		l.ExternalID = externalID
+		return l
+	}
+	statement := Statement{
+		Account:  "000123456789",
+		Currency: "USD",
+		Lines: []Line{
+			line(10, first, "-12.50", "Cafe", "A"),
+			line(17, first, "-30.00", "Market", "B"),
+			line(24, last, "-5.00", "Fee", "C"),
+			line(31, last, "-5.00", "Fee", "C"),
+		},
+		Balances: []Balance{{Row: 40, Date: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Amount: money.MustParse("60.00")}},
+	}
+
+	// Expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccountByName)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "currency"}).AddRow(1, "Checking", "USD"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectRules)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(selectCategoryByName)).
+		WithArgs("Groceries", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "external_id" FROM "transactions" WHERE account_id = $1 AND external_id IN ($2,$3,$4,$5)`)).
+		WithArgs(1, "A", "B", "C", "C").
+		WillReturnRows(sqlmock.NewRows([]string{"external_id"}).AddRow("A"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectExisting)).
+		WithArgs(1, first, last).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "external_id"}).
+			AddRow(17, 1, 3, "-12.50", first, "Cafe", "A").
+			AddRow(18, 1, 3, "-30.00", first, "Market", nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "transactions" WHERE account_id = $1 AND transaction_date <= $2`)).
+		WithArgs(1, statement.Balances[0].Date).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow("57.50"))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions" ("account_id","category_id","amount","transaction_date","description","external_id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "created_at","updated_at","transaction_id"`)).
+		WithArgs(1, 3, "-5.00", last, "Fee", "C").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "transaction_id"}).AddRow(time.Now(), time.Now(), 19))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "statement_balances" ("account_id","balance_date","balance") VALUES ($1,$2,$3) `+
+		`ON CONFLICT ("account_id","balance_date") DO UPDATE SET "balance"="excluded"."balance","updated_at"="excluded"."updated_at" RETURNING "created_at","updated_at"`)).
+		WithArgs(1, statement.Balances[0].Date, "60.00").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+	mock.ExpectCommit()
+
+	// Test
+	result, err := imp.ImportStatements(context.Background(), []Statement{statement}, Options{
+		Accounts: map[string]string{"000123456789": "Checking"},
+		Category: "Groceries",
+	})
+	if err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+	if result.Imported != 1 || result.Duplicates != 3 || len(result.DuplicateRows) != 3 || result.DuplicateRows[2] != 31 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(result.Balances) != 1 {
+		t.Fatalf("Expected one balance check, got %+v", result.Balances)
+	}
+	check := result.Balances[0]
+	if check.Account != "Checking" || check.Recorded != money.MustParse("52.50") || check.Difference != money.MustParse("7.50") {
+		t.Errorf("Unexpected balance check %+v", check)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestImporter_ImportStatements_CurrencyMismatch verifies that a statement is not imported into an account
+// in another currency
+func TestImporter_ImportStatements_CurrencyMismatch(t *testing.T) {
+	// Setup
+	imp, mock := setupMockImporter(t)
+
+	// Expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectAccountByName)).
+		WithArgs("Checking", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "currency"}).AddRow(1, "Checking", "USD"))
+	mock.ExpectRollback()
+
+	// Test
+	statement := Statement{Account: "DE89370400440532013000", Currency: "EUR",
+		Lines: []Line{statementLine(9, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), "-1", "Fee")}}
+	_, err := imp.ImportStatements(context.Background(), []Statement{statement}, Options{Account: "Checking", Category: "Fees"})
+	if !errors.Is(err, ErrInvalidStatement) ||
+		err.Error() != `invalid statement: the statement of "DE89370400440532013000" is in EUR, but account "Checking" is in USD` {
+		t.Errorf("Expected the currency mismatch to be reported, got %v", err)
+	}
+
+	// Verify expectations
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestOptions_AccountOf(t *testing.T) {
+	options := Options{Account: "Checking", Accounts: map[string]string{"4111": "Visa"}}
+	tests := []struct {
+		account    string
+		statements int
+		want       string
+	}{
+		{account: "4111", statements: 2, want: "Visa"},
+		{account: "0001", statements: 1, want: "Checking"},
+		{account: "Savings", statements: 2, want: "Savings"},
+		{account: "", statements: 2, want: "Checking"},
+	}
+	for _, tt := range tests {
+		if got := options.accountOf(Statement{Account: tt.account}, tt.statements); got != tt.want {
+			t.Errorf("accountOf(%q) of %d statements = %q, want %q", tt.account, tt.statements, got, tt.want)
+		}
+	}
+}
+
+func TestParseAccountMap(t *testing.T) {
+	accounts, err := ParseAccountMap([]string{"000123456789=Checking", " 4111 = Visa Card "})
+	if err != nil || accounts["000123456789"] != "Checking" || accounts["4111"] != "Visa Card" {
+		t.Errorf("Unexpected accounts %v, %v", accounts, err)
+	}
+	if _, err := ParseAccountMap([]string{"Checking"}); !errors.Is(err, ErrInvalidStatement) {
+		t.Errorf("Expected a pair without = to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package importer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sample-mcp/pkg/money"
+)
+
+// ofxElement is an element of an OFX document: an aggregate holding other elements, or a leaf holding a value
+type ofxElement struct {
+	name     string
+	value    string
+	row      int
+	children []*ofxElement
+}
+
+// child returns the first child element with the name, nil when there is none
+func (e *ofxElement) child(name string) *ofxElement {
+	if e == nil {
+		return nil
+	}
+	for _, c := range e.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// text returns the value of the leaf at the path of child names, empty when there is none
+func (e *ofxElement) text(path ...string) string {
+	for _, name := range path {
+		e = e.child(name)
+	}
+	if e == nil {
+		return ""
+	}
+	return e.value
+}
+
+// findAll returns the elements with one of the names in document order, not looking inside them
+func (e *ofxElement) findAll(names ...string) []*ofxElement {
+	var found []*ofxElement
+	for _, c := range e.children {
+		matched := false
+		for _, name := range names {
+			matched = matched || c.name == name
+		}
+		if matched {
+			found = append(found, c)
+			continue
+		}
+		found = append(found, c.findAll(names...)...)
+	}
+	return found
+}
+
+// parseOFXTree reads the elements of an OFX document. OFX 1 is SGML, whose leaf elements have no end tags,
+// and OFX 2 is XML; both are read alike by taking an element followed by text as a leaf and any other as an
+// aggregate ending at the matching end tag. The headers before the <OFX> element are skipped.
+func parseOFXTree(data string) (*ofxElement, error) {
+	start := strings.Index(strings.ToUpper(data), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: no <OFX> element found", ErrInvalidStatement)
+	}
+
+	root := &ofxElement{}
+	stack := []*ofxElement{root}
+	row, counted := 1, 0
+	for pos := start; pos < len(data); {
+		open := strings.IndexByte(data[pos:], '<')
+		if open < 0 {
+			break
+		}
+		open += pos
+		end := strings.IndexByte(data[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated tag at the end of the file", ErrInvalidStatement)
+		}
+		end += open
+		next := strings.IndexByte(data[end:], '<')
+		if next < 0 {
+			next = len(data)
+		} else {
+			next += end
+		}
+		row += strings.Count(data[counted:open], "\n")
+		counted, pos = open, next
+
+		tag := strings.TrimSpace(data[open+1 : end])
+		switch {
+		case tag == "" || tag[0] == '?' || tag[0] == '!':
+			// Processing instructions such as the OFX 2 header, and comments
+		case tag[0] == '/':
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			// End tags of leaves, optional in OFX 1, match no open aggregate and are skipped
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			selfClosing := strings.HasSuffix(tag, "/")
+			element := &ofxElement{
+				name:  strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(tag, "/"))),
+				value: html.UnescapeString(strings.TrimSpace(data[end+1 : next])),
+				row:   row,
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, element)
+			if element.value == "" && !selfClosing {
+				stack = append(stack, element)
+			}
+		}
+	}
+	return root, nil
+}
+
+// ParseOFX reads the bank and credit card statements of an OFX or QFX file, version 1 (SGML) or 2 (XML).
+// Each statement has its lines, with the FITID as external ID and the name and memo as description,
+// and its ledger balance. Files that are not valid UTF-8 are read as Windows-1252, the usual charset of OFX 1.
+func ParseOFX(in io.Reader) ([]Statement, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	root, err := parseOFXTree(decodeText(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []Statement
+	for _, element := range root.findAll("STMTRS", "CCSTMTRS") {
+		statement, err := ofxStatement(element)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%w: the file holds no bank or credit card statement", ErrInvalidStatement)
+	}
+	return statements, nil
+}
+
+// ofxStatement converts a STMTRS or CCSTMTRS aggregate into a Statement
+func ofxStatement(element *ofxElement) (Statement, error) {
+	account := element.child("BANKACCTFROM")
+	if account == nil {
+		account = element.child("CCACCTFROM")
+	}
+	statement := Statement{
+		Account:  account.text("ACCTID"),
+		Currency: strings.ToUpper(element.text("CURDEF")),
+	}
+
+	if list := element.child("BANKTRANLIST"); list != nil {
+		for _, transaction := range list.children {
+			if transaction.name != "STMTTRN" {
+				continue
+			}
+			line, err := ofxLine(transaction)
+			if err != nil {
+				return Statement{}, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, transaction.row, err)
+			}
+			statement.Lines = append(statement.Lines, line)
+		}
+	}
+
+	if ledger := element.child("LEDGERBAL"); ledger != nil {
+		amount, err := ofxAmount(ledger.text("BALAMT"))
+		if err != nil {
+			return Statement{}, fmt.Errorf("%w: line %d: ledger balance %v", ErrInvalidStatement, ledger.row, err)
+		}
+		date, err := ofxDate(ledger.text("DTASOF"))
+		if err != nil {
+			return Statement{}, fmt.Errorf("%w: line %d: ledger balance %v", ErrInvalidStatement, ledger.row, err)
+		}
+		statement.Balances = []Balance{{Row: ledger.row, Date: date, Amount: amount}}
+	}
+	return statement, nil
+}
+
+// ofxLine converts a STMTTRN aggregate into a Line
+func ofxLine(transaction *ofxElement) (Line, error) {
+	date, err := ofxDate(transaction.text("DTPOSTED"))
+	if err != nil {
+		return Line{}, err
+	}
+	amount, err := ofxAmount(transaction.text("TRNAMT"))
+	if err != nil {
+		return Line{}, err
+	}
+
+	name := firstNonEmpty(transaction.text("NAME"), transaction.text("PAYEE", "NAME"))
+	description := name
+	if memo := transaction.text("MEMO"); memo != "" && !strings.EqualFold(memo, name) {
+		description = strings.TrimSpace(name + " " + memo)
+	}
+	return Line{
+		Row:         transaction.row,
+		Date:        date,
+		Amount:      amount,
+		Description: description,
+		ExternalID:  transaction.text("FITID"),
+	}, nil
+}
+
+// ofxDate reads the day of an OFX date time such as 20240305, 20240305120000 or 20240305120000.000[-5:EST],
+// the day the bank booked it on whatever its time zone
+func ofxDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("date %q is not an OFX date", s)
+	}
+	date, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is not an OFX date", s)
+	}
+	return date, nil
+}
+
+// ofxAmount reads an OFX amount, which has no thousands separators but may have a decimal comma
+func ofxAmount(s string) (money.Amount, error) {
+	return parseAmount(strings.Replace(s, ",", ".", 1), false)
+}
+
+// windows1252 maps the bytes 0x80 to 0x9F of Windows-1252 to runes; the other bytes are those of Latin-1
+var windows1252 = [32]rune{
+	'€', '\ufffd', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\ufffd', 'Ž', '\ufffd',
+	'\ufffd', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\ufffd', 'ž', 'Ÿ',
+}
+
+// decodeText returns UTF-8 data without its byte order mark, and decodes other data as Windows-1252
+func decodeText(data []byte) string {
+	if utf8.Valid(data) {
+		return strings.TrimPrefix(string(data), "\ufeff")
+	}
+	var b strings.Builder
+	b.Grow(len(data))
+	for _, c := range data {
+		if c >= 0x80 && c < 0xa0 {
+			b.WriteRune(windows1252[c-0x80])
+			continue
+		}
+		b.WriteRune(rune(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"sample-mcp/pkg/money"
+)
+
+func parseFixture(t *testing.T, name string, parse func(*os.File) ([]Statement, error)) []Statement {
+	t.Helper()
+	file, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", name, err)
+	}
+	defer file.Close()
+	statements, err := parse(file)
+	if err != nil {
+		t.Fatalf("Error parsing %s: %v", name, err)
+	}
+	return statements
+}
+
+func on(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TestParseOFX_SGML verifies an OFX 1 file in Windows-1252 without end tags for its leaves
+func TestParseOFX_SGML(t *testing.T) {
+	statements := parseFixture(t, "checking.ofx", func(f *os.File) ([]Statement, error) { return ParseOFX(f) })
+
+	if len(statements) != 1 {
+		t.Fatalf("Expected one statement, got %+v", statements)
+	}
+	statement := statements[0]
+	if statement.Account != "000123456789" || statement.Currency != "USD" {
+		t.Errorf("Unexpected statement account %q in %q", statement.Account, statement.Currency)
+	}
+	want := []Line{
+		{Row: 39, Date: on(2024, 3, 5), Amount: money.MustParse("-12.50"), Description: "Café Central POS PURCHASE", ExternalID: "202403050001"},
+		{Row: 47, Date: on(2024, 3, 15), Amount: money.MustParse("2500"), Description: "ACME Corp Payroll", ExternalID: "202403150001"},
+		{Row: 54, Date: on(2024, 3, 20), Amount: money.MustParse("-1200"), Description: "Smith & Sons Rent", ExternalID: "202403200001"},
+	}
+	if len(statement.Lines) != len(want) {
+		t.Fatalf("Expected %d lines, got %+v", len(want), statement.Lines)
+	}
+	for i, line := range statement.Lines {
+		if line != want[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], line)
+		}
+	}
+	if len(statement.Balances) != 1 || statement.Balances[0].Amount != money.MustParse("3287.50") ||
+		!statement.Balances[0].Date.Equal(on(2024, 3, 31)) {
+		t.Errorf("Unexpected ledger balance %+v", statement.Balances)
+	}
+}
+
+// TestParseOFX_XML verifies an OFX 2 file with a bank and a credit card statement
+func TestParseOFX_XML(t *testing.T) {
+	statements := parseFixture(t, "accounts.qfx", func(f *os.File) ([]Statement, error) { return ParseOFX(f) })
+
+	if len(statements) != 2 {
+		t.Fatalf("Expected two statements, got %+v", statements)
+	}
+	savings, card := statements[0], statements[1]
+	if savings.Account != "000987654321" || len(savings.Lines) != 1 || savings.Lines[0].Description != "Interest Paid" {
+		t.Errorf("Unexpected savings statement %+v", savings)
+	}
+	if len(savings.Balances) != 1 || savings.Balances[0].Amount != money.MustParse("10004.17") {
+		t.Errorf("Unexpected savings balance %+v", savings.Balances)
+	}
+	if card.Account != "4111111111111111" || len(card.Lines) != 2 {
+		t.Fatalf("Unexpected credit card statement %+v", card)
+	}
+	if card.Lines[0].Description != "Fresh Market" || card.Lines[0].ExternalID != "CC-88213" ||
+		card.Lines[0].Amount != money.MustParse("-54.20") {
+		t.Errorf("Unexpected credit card line %+v", card.Lines[0])
+	}
+	if len(card.Balances) != 1 || card.Balances[0].Amount != money.MustParse("-412.35") {
+		t.Errorf("Unexpected credit card balance %+v", card.Balances)
+	}
+}
+
+func TestParseOFX_Errors(t *testing.T) {
+	for content, message := range map[string]string{
+		"Date,Amount\n2024-03-05,1\n":                  "no <OFX> element found",
+		"<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>": "the file holds no bank or credit card statement",
+		"<OFX><STMTRS><BANKTRANLIST><STMTTRN><DTPOSTED>2024<TRNAMT>1</STMTTRN></BANKTRANLIST></STMTRS></OFX>":       `line 1: date "2024" is not an OFX date`,
+		"<OFX><STMTRS><BANKTRANLIST><STMTTRN><DTPOSTED>20240305<TRNAMT>N/A</STMTTRN></BANKTRANLIST></STMTRS></OFX>": `line 1: amount "N/A" is not a number`,
+	} {
+		_, err := ParseOFX(strings.NewReader(content))
+		if !errors.Is(err, ErrInvalidStatement) || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q, got %v", message, err)
+		}
+	}
+}
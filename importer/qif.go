@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QIFFormat describes the conventions of a QIF file, which does not declare them
+type QIFFormat struct {
+	// DayFirst is set for dates like 31/12/2024 instead of 12/31/2024
+	DayFirst bool `yaml:"dayFirst"`
+	// DecimalComma is set for amounts written like 1.234,56
+	DecimalComma bool `yaml:"decimalComma"`
+}
+
+// qifTransactionTypes are the QIF sections listing the transactions of bank, cash, credit card, asset and
+// liability accounts. The other sections, such as category lists, memorized transactions and investment accounts,
+// are skipped.
+var qifTransactionTypes = map[string]bool{"bank": true, "cash": true, "ccard": true, "oth a": true, "oth l": true}
+
+// qifRecord collects the fields of a QIF transaction up to its ^ terminator
+type qifRecord struct {
+	row    int
+	fields map[byte]string
+}
+
+// ParseQIF reads the transactions of a QIF file. A file exported from one account has a single statement;
+// a file exported from several has a statement per !Account block, named like the account. The payee and memo
+// become the description; QIF has no IDs for its transactions and no balances, and its categories are not mapped.
+func ParseQIF(in io.Reader, format QIFFormat) ([]Statement, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(decodeText(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		statements []Statement
+		current    *Statement
+		section    string
+		account    string
+		record     = qifRecord{fields: make(map[byte]string)}
+	)
+	finish := func() error {
+		if len(record.fields) == 0 {
+			return nil
+		}
+		line, err := format.line(record)
+		if err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, record.row, err)
+		}
+		current.Lines = append(current.Lines, line)
+		record = qifRecord{fields: make(map[byte]string)}
+		return nil
+	}
+
+	for row := 1; scanner.Scan(); row++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if text[0] == '!' {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			header := strings.ToLower(strings.TrimSpace(text[1:]))
+			switch {
+			case header == "account":
+				section = "account"
+			case strings.HasPrefix(header, "type:"):
+				section = ""
+				if qifTransactionTypes[strings.TrimSpace(strings.TrimPrefix(header, "type:"))] {
+					section = "transactions"
+					if current == nil || current.Account != account {
+						statements = append(statements, Statement{Account: account})
+						current = &statements[len(statements)-1]
+					}
+				}
+			}
+			// Options such as !Option:AutoSwitch keep the section
+			continue
+		}
+
+		code, value := text[0], strings.TrimSpace(text[1:])
+		switch section {
+		case "account":
+			if code == 'N' {
+				account = value
+			}
+		case "transactions":
+			if code == '^' {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if len(record.fields) == 0 {
+				record.row = row
+			}
+			if _, ok := record.fields[code]; !ok {
+				record.fields[code] = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%w: the file holds no bank, cash or credit card transactions", ErrInvalidStatement)
+	}
+	return statements, nil
+}
+
+// line converts a QIF record into a Line; the total T, or U in files without it, holds the amount of split
+// transactions too
+func (f QIFFormat) line(record qifRecord) (Line, error) {
+	date, err := f.parseDate(record.fields['D'])
+	if err != nil {
+		return Line{}, err
+	}
+	amount, err := parseAmount(firstNonEmpty(record.fields['T'], record.fields['U']), f.DecimalComma)
+	if err != nil {
+		return Line{}, err
+	}
+
+	description := record.fields['P']
+	if memo := record.fields['M']; memo != "" && !strings.EqualFold(memo, description) {
+		description = strings.TrimSpace(description + " " + memo)
+	}
+	return Line{Row: record.row, Date: date, Amount: amount, Description: description}, nil
+}
+
+// parseDate reads the dates QIF files are written with, such as 12/31/2024, 12/31/24, 12/31'24 and 1/ 5' 4
+// (an apostrophe marks years from 2000) or 2024-12-31, with the day first for DayFirst
+func (f QIFFormat) parseDate(s string) (time.Time, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return strings.ContainsRune("/.-' ", r) })
+	if len(fields) != 3 {
+		return time.Time{}, fmt.Errorf("date %q is not a QIF date", s)
+	}
+	var numbers [3]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("date %q is not a QIF date", s)
+		}
+		numbers[i] = n
+	}
+
+	var year, month, day int
+	switch {
+	case len(fields[0]) == 4:
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	case f.DayFirst:
+		day, month, year = numbers[0], numbers[1], numbers[2]
+	default:
+		month, day, year = numbers[0], numbers[1], numbers[2]
+	}
+	if len(fields[0]) != 4 && len(fields[2]) <= 2 {
+		switch {
+		case strings.Contains(s, "'"), year < 70:
+			year += 2000
+		default:
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("date %q does not exist", s)
+	}
+	return date, nil
+}
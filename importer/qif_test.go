@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"sample-mcp/pkg/money"
+)
+
+// TestParseQIF verifies a QIF export of several accounts, skipping its category list and reading split
+// transactions by their total
+func TestParseQIF(t *testing.T) {
+	statements := parseFixture(t, "accounts.qif", func(f *os.File) ([]Statement, error) { return ParseQIF(f, QIFFormat{}) })
+
+	if len(statements) != 2 {
+		t.Fatalf("Expected two statements, got %+v", statements)
+	}
+	checking, visa := statements[0], statements[1]
+	if checking.Account != "Checking" || visa.Account != "Visa" {
+		t.Errorf("Unexpected accounts %q and %q", checking.Account, visa.Account)
+	}
+	want := []Line{
+		{Row: 20, Date: on(2024, 3, 5), Amount: money.MustParse("-12.50"), Description: "Café Central POS purchase"},
+		{Row: 26, Date: on(2024, 3, 15), Amount: money.MustParse("2500"), Description: "ACME Corp Payroll"},
+	}
+	if len(checking.Lines) != len(want) {
+		t.Fatalf("Expected %d lines, got %+v", len(want), checking.Lines)
+	}
+	for i, line := range checking.Lines {
+		if line != want[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], line)
+		}
+	}
+	if len(visa.Lines) != 1 || visa.Lines[0].Amount != money.MustParse("-54.20") || visa.Balances != nil {
+		t.Errorf("Unexpected Visa statement %+v", visa)
+	}
+}
+
+// TestParseQIF_SingleAccount verifies a QIF file exported from one account, with European conventions
+// and without a terminator after its last transaction
+func TestParseQIF_SingleAccount(t *testing.T) {
+	content := "!Type:Bank\nD05.03.2024\nT-1.234,50\nPRent\n^\nD31.03.2024\nT0,42\nPInterest\n"
+
+	statements, err := ParseQIF(strings.NewReader(content), QIFFormat{DayFirst: true, DecimalComma: true})
+	if err != nil {
+		t.Fatalf("Error parsing QIF: %v", err)
+	}
+	if len(statements) != 1 || statements[0].Account != "" || len(statements[0].Lines) != 2 {
+		t.Fatalf("Unexpected statements %+v", statements)
+	}
+	lines := statements[0].Lines
+	if lines[0].Amount != money.MustParse("-1234.50") || !lines[0].Date.Equal(on(2024, 3, 5)) {
+		t.Errorf("Unexpected line %+v", lines[0])
+	}
+	if lines[1].Amount != money.MustParse("0.42") || !lines[1].Date.Equal(on(2024, 3, 31)) {
+		t.Errorf("Unexpected line %+v", lines[1])
+	}
+}
+
+func TestQIFFormat_ParseDate(t *testing.T) {
+	for in, want := range map[string]time.Time{
+		"12/31/2024": on(2024, 12, 31),
+		"12/31/98":   on(1998, 12, 31),
+		"1/ 5' 4":    on(2004, 1, 5),
+		"1/5'24":     on(2024, 1, 5),
+		"2024-03-05": on(2024, 3, 5),
+	} {
+		got, err := QIFFormat{}.parseDate(in)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseDate(%q) = %s, %v, want %s", in, got, err, want)
+		}
+	}
+
+	if _, err := (QIFFormat{}).parseDate("31/12/2024"); err == nil {
+		t.Error("Expected month 31 to be rejected")
+	}
+	if got, err := (QIFFormat{DayFirst: true}).parseDate("31/12/2024"); err != nil || !got.Equal(on(2024, 12, 31)) {
+		t.Errorf("Expected the day first, got %s, %v", got, err)
+	}
+}
+
+func TestParseQIF_Errors(t *testing.T) {
+	for content, message := range map[string]string{
+		"!Type:Cat\nNGroceries\n^\n":              "the file holds no bank, cash or credit card transactions",
+		"!Type:Bank\nD3/5/2024\nTabc\nPShop\n^\n": `line 2: amount "abc" is not a number`,
+		"!Type:Bank\nDyesterday\nT1\n^\n":         `line 2: date "yesterday" is not a QIF date`,
+	} {
+		_, err := ParseQIF(strings.NewReader(content), QIFFormat{})
+		if !errors.Is(err, ErrInvalidStatement) || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q, got %v", message, err)
+		}
+	}
+}
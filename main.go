@@ -4,41 +4,72 @@ import (
 	"context"
 	"github.com/FreePeak/cortex/pkg/server"
 	"github.com/FreePeak/cortex/pkg/tools"
+	"gorm.io/gorm"
 	"log"
 	"os"
-	//"sample-mcp/config"
-	//"sample-mcp/db"
+	"os/signal"
+	"sample-mcp/config"
+	"sample-mcp/db"
+	"sample-mcp/db/repository"
 	"sample-mcp/handler"
-	//"sample-mcp/ops"
+	"sample-mcp/ops"
+	"sample-mcp/pkg/auth"
+	pkgdb "sample-mcp/pkg/db"
+	"sample-mcp/transport"
+	"syscall"
+)
+
+const (
+	serverName    = "Cortex Stdio Server"
+	serverVersion = "1.0.0"
 )
 
 func main() {
 	logger := log.New(os.Stderr, "[cortex-stdio] ", log.LstdFlags)
 
-	//cfg, err := config.LoadConfig()
-	//if err != nil {
-	//	logger.Fatalf("Failed to load configuration: %v", err)
-	//}
-	//logger.Printf("Database configuration loaded: Type=%s, Host=%s, Port=%d, Database=%s",
-	//	cfg.Database.DbType, cfg.Database.Host, cfg.Database.Port, cfg.Database.DbName)
-	//
-	//dbConfig := cfg.Database
-	//pool, err := dbConfig.Pool()
-	//if err != nil {
-	//	logger.Fatalf("Failed to load database: %v", err)
-	//}
-	//
-	//err = db.RunMigrations(pool)
-	//if err != nil {
-	//	logger.Fatalf("Failed to run migration: %v", err)
-	//}
-	//
-	//_, err = ops.NewQueryOps(ops.WithGormDB(pool))
-	//if err != nil {
-	//	logger.Fatalf("Failed to initiate query ops: %v", err)
-	//}
-
-	mcpServer := server.NewMCPServer("Cortex Stdio Server", "1.0.0", logger)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Fatalf("Failed to load configuration: %v", err)
+	}
+	logger.Printf("Database configuration loaded: Type=%s, Host=%s, Port=%d, Database=%s",
+		cfg.Database.DbType, cfg.Database.Host, cfg.Database.Port, cfg.Database.DbName)
+
+	dbConfig := cfg.Database
+	pool, err := dbConfig.Pool()
+	if err != nil {
+		logger.Fatalf("Failed to load database: %v", err)
+	}
+
+	err = db.RunMigrations(pool, dbConfig.DbType)
+	if err != nil {
+		logger.Fatalf("Failed to run migration: %v", err)
+	}
+
+	if file := cfg.ExchangeRates.File; file != "" {
+		count, err := loadExchangeRates(pool, file)
+		if err != nil {
+			logger.Fatalf("Failed to load exchange rates from %s: %v", file, err)
+		}
+		logger.Printf("Loaded %d exchange rates from %s", count, file)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(pool, os.Args[2:], logger); err != nil {
+			logger.Fatalf("Import failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(pool, os.Args[2:], logger); err != nil {
+			logger.Fatalf("Export failed: %v", err)
+		}
+		return
+	}
+
+	queryOps, err := ops.NewQueryOps(ops.WithGormDB(pool))
+	if err != nil {
+		logger.Fatalf("Failed to initiate query ops: %v", err)
+	}
 
 	echoTool := tools.NewTool("echo",
 		tools.WithDescription("Echoes back the input message"),
@@ -48,19 +79,97 @@ func main() {
 		),
 	)
 
-	var err error
-
-	ctx := context.Background()
-	err = mcpServer.AddTool(ctx, echoTool, handler.HandleEcho)
+	registry := handler.NewRegistry()
+	err = registry.Add(echoTool, handler.HandleEcho)
 	if err != nil {
 		logger.Fatalf("Error adding echo tool: %v", err)
 	}
 
+	err = handler.NewQueryHandler(queryOps).Register(registry)
+	if err != nil {
+		logger.Fatalf("Error adding query tools: %v", err)
+	}
+
+	if cfg.Writes.Enabled {
+		policy := ops.WritePolicy{Enabled: cfg.Writes.Enabled, AllowDelete: cfg.Writes.AllowDelete}
+		commandOps, err := ops.NewCommandOps(ops.WithCommandGormDB(pool), ops.WithWritePolicy(policy))
+		if err != nil {
+			logger.Fatalf("Failed to initiate command ops: %v", err)
+		}
+		err = handler.NewCommandHandler(commandOps).Register(registry)
+		if err != nil {
+			logger.Fatalf("Error adding write tools: %v", err)
+		}
+	} else {
+		logger.Printf("Writes are disabled, the server is read-only")
+	}
+
 	logger.Printf("Server ready. The following tools are available:\n")
-	logger.Printf("- echo\n")
+	for _, tool := range registry.Tools() {
+		logger.Printf("- %s\n", tool.Name)
+	}
+
+	switch cfg.Server.Transport {
+	case config.TransportHTTP:
+		serveHTTP(cfg.Server, cfg.Auth, registry, pool, logger)
+	case config.TransportStdio, "":
+		serveStdio(registry, logger)
+	default:
+		logger.Fatalf("Unsupported transport: %s", cfg.Server.Transport)
+	}
+}
+
+// loadExchangeRates upserts the exchange rates of a CSV file into the exchange_rates table
+func loadExchangeRates(pool *gorm.DB, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return repository.NewExchangeRateRepository(pool).LoadCSV(context.Background(), file)
+}
+
+// serveStdio serves the tools to a single client over stdin/stdout
+func serveStdio(registry *handler.Registry, logger *log.Logger) {
+	mcpServer := server.NewMCPServer(serverName, serverVersion, logger)
+
+	err := registry.AddTo(context.Background(), mcpServer)
+	if err != nil {
+		logger.Fatalf("Error registering tools: %v", err)
+	}
 
 	if err := mcpServer.ServeStdio(); err != nil {
 		logger.Printf("Error serving stdio: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// serveHTTP serves the tools over HTTP until SIGINT or SIGTERM is received
+func serveHTTP(serverConfig *config.ServerConfig, authConfig *auth.Config, registry *handler.Registry, pool *gorm.DB, logger *log.Logger) {
+	authenticator, err := auth.NewAuthenticator(authConfig)
+	if err != nil {
+		logger.Fatalf("Failed to configure authentication: %v", err)
+	}
+	if authenticator == nil {
+		logger.Printf("WARNING: authentication is disabled, every client on the network can call the tools")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpServer := transport.NewHTTPServer(serverName, serverVersion, registry,
+		transport.WithLogger(logger),
+		transport.WithMiddleware(auth.Middleware(authenticator)),
+		transport.WithAllowedOrigins(serverConfig.AllowedOrigins...),
+		transport.WithHealthCheck(func(ctx context.Context) error {
+			return pkgdb.Ping(ctx, pool)
+		}),
+	)
+
+	logger.Printf("Serving MCP over HTTP on %s (endpoint /mcp, SSE /sse, health /healthz)", serverConfig.Address)
+	if err := httpServer.ListenAndServe(ctx, serverConfig.Address, serverConfig.ShutdownTimeout); err != nil {
+		logger.Printf("Error serving HTTP: %v\n", err)
+		os.Exit(1)
+	}
+	logger.Printf("HTTP server stopped")
+}
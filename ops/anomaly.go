@@ -0,0 +1,301 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/calendar"
+	"sample-mcp/pkg/money"
+)
+
+const (
+	// DefaultBaselineMonths is the length of the trailing window the baselines are computed over
+	DefaultBaselineMonths = 12
+	// minBaselineTransactions is the number of earlier transactions it takes to judge a transaction
+	minBaselineTransactions = 5
+	// minBaselineMonths is the number of earlier months it takes to judge a monthly total
+	minBaselineMonths = 3
+)
+
+// SensitivityThresholds maps each sensitivity to the robust z-score an amount must reach to be anomalous.
+// 3.5 is the customary cut-off for the modified z-score; higher sensitivities flag more.
+var SensitivityThresholds = map[string]float64{
+	"low":    5,
+	"medium": 3.5,
+	"high":   2.5,
+}
+
+// AnomalyOptions tunes the anomaly detection
+type AnomalyOptions struct {
+	// Threshold is the robust z-score an amount must reach, the medium sensitivity when zero
+	Threshold float64
+	// BaselineMonths is the trailing window before each transaction or month, DefaultBaselineMonths when zero
+	BaselineMonths int
+}
+
+func (o AnomalyOptions) withDefaults() AnomalyOptions {
+	if o.Threshold <= 0 {
+		o.Threshold = SensitivityThresholds["medium"]
+	}
+	if o.BaselineMonths <= 0 {
+		o.BaselineMonths = DefaultBaselineMonths
+	}
+	return o
+}
+
+// TransactionAnomaly is a transaction whose amount is far larger than the earlier ones of its account and category
+type TransactionAnomaly struct {
+	TransactionID uint         `json:"transaction_id"`
+	Date          time.Time    `json:"date"`
+	AccountID     uint         `json:"account_id"`
+	AccountName   string       `json:"account_name"`
+	CategoryID    uint         `json:"category_id"`
+	CategoryName  string       `json:"category_name"`
+	Description   *string      `json:"description,omitempty"`
+	Amount        money.Amount `json:"amount"`
+	Median        money.Amount `json:"baseline_median"`
+	BaselineSize  int          `json:"baseline_transactions"`
+	Score         float64      `json:"score"`
+	Explanation   string       `json:"explanation"`
+}
+
+// MonthlyAnomaly is a month whose total in a category of an account is far larger than in the months before
+type MonthlyAnomaly struct {
+	Month        time.Time    `json:"month"`
+	AccountID    uint         `json:"account_id"`
+	AccountName  string       `json:"account_name"`
+	CategoryID   uint         `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Total        money.Amount `json:"total"`
+	Median       money.Amount `json:"baseline_median"`
+	BaselineSize int          `json:"baseline_months"`
+	Score        float64      `json:"score"`
+	Explanation  string       `json:"explanation"`
+}
+
+// AnomalyReport lists the anomalous transactions and monthly totals, oldest first
+type AnomalyReport struct {
+	Transactions  []TransactionAnomaly `json:"transactions"`
+	MonthlyTotals []MonthlyAnomaly     `json:"monthly_totals"`
+}
+
+// anomalyKey groups the transactions sharing a baseline
+type anomalyKey struct {
+	accountID  uint
+	categoryID uint
+}
+
+// DetectAnomalies flags the transactions matching the filter, and the monthly totals per account and category of
+// the months it spans, that are unusually large compared with the same account and category over the trailing
+// baseline window. The baseline is the median and the median absolute deviation of the earlier amounts, which
+// a few outliers in the history do not skew the way they skew a mean and standard deviation. Only amounts further
+// from zero than the median are flagged, as a larger charge or deposit, and a month still in progress is judged
+// by its total so far. The filter must have a date range.
+func (q *QueryOps) DetectAnomalies(ctx context.Context, filter repository.TransactionFilter, options AnomalyOptions) (*AnomalyReport, error) {
+	if filter.StartDate == nil || filter.EndDate == nil {
+		return nil, fmt.Errorf("%w: anomaly detection needs a start and end date", repository.ErrInvalidFilter)
+	}
+	if filter.EndDate.Before(*filter.StartDate) {
+		return nil, fmt.Errorf("%w: end date is before start date", repository.ErrInvalidFilter)
+	}
+	options = options.withDefaults()
+	start, end := calendar.Day(*filter.StartDate), calendar.Day(*filter.EndDate)
+
+	// Load the baseline window of the first month too, oldest first
+	from := periodStart(start, string(repository.IntervalMonth)).AddDate(0, -options.BaselineMonths, 0)
+	filter.StartDate, filter.EndDate, filter.Sort = &from, &end, nil
+	transactions, err := searchAll(ctx, q.transactionRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[anomalyKey][]entity.Transaction)
+	var keys []anomalyKey
+	for _, transaction := range transactions {
+		key := anomalyKey{transaction.AccountID, transaction.CategoryID}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], transaction)
+	}
+
+	report := &AnomalyReport{Transactions: []TransactionAnomaly{}, MonthlyTotals: []MonthlyAnomaly{}}
+	for _, key := range keys {
+		report.Transactions = append(report.Transactions, transactionAnomalies(groups[key], start, options)...)
+		report.MonthlyTotals = append(report.MonthlyTotals, monthlyAnomalies(groups[key], start, end, options)...)
+	}
+	sort.Slice(report.Transactions, func(i, j int) bool {
+		a, b := report.Transactions[i], report.Transactions[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.TransactionID < b.TransactionID
+	})
+	sort.Slice(report.MonthlyTotals, func(i, j int) bool {
+		a, b := report.MonthlyTotals[i], report.MonthlyTotals[j]
+		if !a.Month.Equal(b.Month) {
+			return a.Month.Before(b.Month)
+		}
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		return a.CategoryID < b.CategoryID
+	})
+	return report, nil
+}
+
+// transactionAnomalies judges each transaction of a group from start on against the group's transactions
+// of the baseline window before its day. The group must be ordered by date.
+func transactionAnomalies(group []entity.Transaction, start time.Time, options AnomalyOptions) []TransactionAnomaly {
+	var anomalies []TransactionAnomaly
+	first := 0
+	for i, transaction := range group {
+		date := calendar.Day(transaction.TransactionDate)
+		if date.Before(start) {
+			continue
+		}
+		windowStart := date.AddDate(0, -options.BaselineMonths, 0)
+		for first < i && calendar.Day(group[first].TransactionDate).Before(windowStart) {
+			first++
+		}
+		last := i
+		for last > first && !calendar.Day(group[last-1].TransactionDate).Before(date) {
+			last--
+		}
+		if last-first < minBaselineTransactions {
+			continue
+		}
+
+		baseline := make([]money.Amount, 0, last-first)
+		for _, earlier := range group[first:last] {
+			baseline = append(baseline, earlier.Amount)
+		}
+		score, median, ok := robustScore(transaction.Amount, baseline)
+		if !ok || !unusual(transaction.Amount, median, score, options.Threshold) {
+			continue
+		}
+
+		account, category := names(transaction)
+		anomalies = append(anomalies, TransactionAnomaly{
+			TransactionID: transaction.TransactionID,
+			Date:          date,
+			AccountID:     transaction.AccountID,
+			AccountName:   account,
+			CategoryID:    transaction.CategoryID,
+			CategoryName:  category,
+			Description:   transaction.Description,
+			Amount:        transaction.Amount,
+			Median:        median,
+			BaselineSize:  len(baseline),
+			Score:         math.Round(score*100) / 100,
+			Explanation: fmt.Sprintf("%s is %s of the %d earlier transactions in %s on %s within %d months (robust z-score %.1f)",
+				transaction.Amount, comparedWithMedian(transaction.Amount, median), len(baseline), category, account,
+				options.BaselineMonths, score),
+		})
+	}
+	return anomalies
+}
+
+// monthlyAnomalies judges the monthly totals of a group from the month of start to the month of end against
+// the totals of the baseline window before each month. Months without transactions count as zero once the group
+// has its first transaction, so that a category used every other month has a baseline of its own.
+func monthlyAnomalies(group []entity.Transaction, start, end time.Time, options AnomalyOptions) []MonthlyAnomaly {
+	const month = string(repository.IntervalMonth)
+	if len(group) == 0 {
+		return nil
+	}
+	totals := make(map[time.Time]money.Amount)
+	for _, transaction := range group {
+		totals[periodStart(transaction.TransactionDate, month)] += transaction.Amount
+	}
+	firstMonth := periodStart(group[0].TransactionDate, month)
+	account, category := names(group[0])
+
+	var anomalies []MonthlyAnomaly
+	for m := periodStart(start, month); !m.After(end); m = nextPeriod(m, month) {
+		total, ok := totals[m]
+		if !ok {
+			continue
+		}
+		var baseline []money.Amount
+		for b := m.AddDate(0, -options.BaselineMonths, 0); b.Before(m); b = nextPeriod(b, month) {
+			if !b.Before(firstMonth) {
+				baseline = append(baseline, totals[b])
+			}
+		}
+		if len(baseline) < minBaselineMonths {
+			continue
+		}
+		score, median, ok := robustScore(total, baseline)
+		if !ok || !unusual(total, median, score, options.Threshold) {
+			continue
+		}
+
+		anomalies = append(anomalies, MonthlyAnomaly{
+			Month:        m,
+			AccountID:    group[0].AccountID,
+			AccountName:  account,
+			CategoryID:   group[0].CategoryID,
+			CategoryName: category,
+			Total:        total,
+			Median:       median,
+			BaselineSize: len(baseline),
+			Score:        math.Round(score*100) / 100,
+			Explanation: fmt.Sprintf("The %s total of %s in %s on %s is %s of the %d months before (robust z-score %.1f)",
+				m.Format("2006-01"), total, category, account, comparedWithMedian(total, median), len(baseline), score),
+		})
+	}
+	return anomalies
+}
+
+// robustScore is the modified z-score of the amount against the baseline, 0.6745 (x - median) / MAD.
+// When more than half the baseline equals its median the MAD is zero, and the mean absolute deviation
+// scaled by 1.2533 stands in for it; a baseline of identical amounts cannot score anything.
+// The score does not depend on the unit, so the amounts are compared in cents.
+func robustScore(amount money.Amount, baseline []money.Amount) (float64, money.Amount, bool) {
+	median := medianAmount(baseline)
+	deviations := make([]float64, len(baseline))
+	var sum float64
+	for i, b := range baseline {
+		deviations[i] = math.Abs(float64(b.Cents() - median.Cents()))
+		sum += deviations[i]
+	}
+	diff := float64(amount.Cents() - median.Cents())
+	if mad := medianOf(deviations); mad > 0 {
+		return 0.6745 * diff / mad, median, true
+	}
+	if mean := sum / float64(len(deviations)); mean > 0 {
+		return diff / (1.2533 * mean), median, true
+	}
+	return 0, median, false
+}
+
+// unusual reports whether the score reaches the threshold with the amount further from zero than the median
+func unusual(amount, median money.Amount, score, threshold float64) bool {
+	return math.Abs(score) >= threshold && amount.Abs() > median.Abs()
+}
+
+// comparedWithMedian describes an amount relative to the median of its baseline
+func comparedWithMedian(amount, median money.Amount) string {
+	if median == 0 || (amount < 0) != (median < 0) {
+		return fmt.Sprintf("far from the median %s", median)
+	}
+	return fmt.Sprintf("%.1f times the median %s", amount.Float64()/median.Float64(), median)
+}
+
+// names returns the account and category names of a transaction, empty when they were not loaded
+func names(transaction entity.Transaction) (string, string) {
+	var account, category string
+	if transaction.Account != nil {
+		account = transaction.Account.Name
+	}
+	if transaction.Category != nil {
+		category = transaction.Category.Name
+	}
+	return account, category
+}
@@ -0,0 +1,121 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// weeklyGroceries is a year of weekly grocery shopping in 2023 followed by two purchases in January 2024,
+// the first of them far larger than usual
+func weeklyGroceries() []entity.Transaction {
+	account := &entity.Account{AccountID: 1, Name: "Checking"}
+	category := &entity.Category{CategoryID: 2, Name: "Groceries"}
+	amounts := []string{"-90", "-100", "-110", "-95", "-105"}
+
+	var group []entity.Transaction
+	add := func(date time.Time, amount string) {
+		group = append(group, entity.Transaction{
+			TransactionID:   uint(len(group) + 1),
+			AccountID:       1,
+			CategoryID:      2,
+			TransactionDate: date,
+			Amount:          money.MustParse(amount),
+			Account:         account,
+			Category:        category,
+		})
+	}
+	for date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC); date.Year() == 2023; date = date.AddDate(0, 0, 7) {
+		add(date, amounts[len(group)%len(amounts)])
+	}
+	add(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), "-900")
+	add(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "-100")
+	return group
+}
+
+func TestTransactionAnomalies(t *testing.T) {
+	group := weeklyGroceries()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	anomalies := transactionAnomalies(group, start, AnomalyOptions{}.withDefaults())
+	if len(anomalies) != 1 {
+		t.Fatalf("Expected only the large purchase to be flagged, got %+v", anomalies)
+	}
+	anomaly := anomalies[0]
+	if anomaly.Amount != money.MustParse("-900") || anomaly.Median != money.MustParse("-100") || anomaly.BaselineSize != 51 {
+		t.Errorf("Unexpected anomaly %+v", anomaly)
+	}
+	// The week before the window, January 2 2023, is left out; the MAD of the rest is 5
+	if anomaly.Score != -107.92 {
+		t.Errorf("Expected a score of 0.6745 * -800 / 5, got %v", anomaly.Score)
+	}
+	want := "-900.00 is 9.0 times the median -100.00 of the 51 earlier transactions in Groceries on Checking within 12 months (robust z-score -107.9)"
+	if anomaly.Explanation != want {
+		t.Errorf("Expected explanation %q, got %q", want, anomaly.Explanation)
+	}
+
+	// A baseline window shorter than the minimum number of transactions judges nothing
+	if anomalies := transactionAnomalies(group[len(group)-6:], start, AnomalyOptions{}.withDefaults()); len(anomalies) != 0 {
+		t.Errorf("Expected no anomalies without a baseline, got %+v", anomalies)
+	}
+}
+
+func TestMonthlyAnomalies(t *testing.T) {
+	group := weeklyGroceries()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	anomalies := monthlyAnomalies(group, start, end, AnomalyOptions{}.withDefaults())
+	if len(anomalies) != 1 {
+		t.Fatalf("Expected January to be flagged, got %+v", anomalies)
+	}
+	anomaly := anomalies[0]
+	if !anomaly.Month.Equal(start) || anomaly.Total != money.MustParse("-1000") || anomaly.BaselineSize != 12 {
+		t.Errorf("Unexpected anomaly %+v", anomaly)
+	}
+
+	// Without the large purchase January is below the usual spending so far, which is not flagged
+	group[len(group)-2].Amount = money.MustParse("-100")
+	if anomalies := monthlyAnomalies(group, start, end, AnomalyOptions{Threshold: SensitivityThresholds["high"]}.withDefaults()); len(anomalies) != 0 {
+		t.Errorf("Expected no anomalies, got %+v", anomalies)
+	}
+}
+
+func TestRobustScore(t *testing.T) {
+	amounts := func(values ...string) []money.Amount {
+		var result []money.Amount
+		for _, v := range values {
+			result = append(result, money.MustParse(v))
+		}
+		return result
+	}
+
+	// Median 10, absolute deviations 0, 0, 0, 10: the MAD is zero, the mean absolute deviation 2.5
+	score, median, ok := robustScore(money.MustParse("20"), amounts("10", "10", "10", "20"))
+	if !ok || median != money.MustParse("10") || score < 3.19 || score > 3.2 {
+		t.Errorf("Expected a score of 1000 / (1.2533 * 250), got %v, %v, %v", score, median, ok)
+	}
+
+	if _, _, ok := robustScore(money.MustParse("20"), amounts("10", "10", "10")); ok {
+		t.Error("Expected identical amounts not to score")
+	}
+}
+
+func TestQueryOps_DetectAnomalies_NeedsDateRange(t *testing.T) {
+	queryOps, _ := setupMockQueryOps(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, filter := range map[string]repository.TransactionFilter{
+		"no end date": {StartDate: &start},
+		"reversed":    {StartDate: &start, EndDate: &time.Time{}},
+	} {
+		if _, err := queryOps.DetectAnomalies(context.Background(), filter, AnomalyOptions{}); !errors.Is(err, repository.ErrInvalidFilter) {
+			t.Errorf("%s: expected ErrInvalidFilter, got %v", name, err)
+		}
+	}
+}
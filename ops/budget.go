@@ -0,0 +1,260 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/calendar"
+	"sample-mcp/pkg/money"
+)
+
+// BudgetPeriods are the periods a budget can have, named like the cash flow intervals
+var BudgetPeriods = []string{
+	string(repository.IntervalWeek),
+	string(repository.IntervalMonth),
+	string(repository.IntervalQuarter),
+	string(repository.IntervalYear),
+}
+
+// NewBudget holds the fields of a budget to create
+type NewBudget struct {
+	CategoryID uint
+	Period     string
+	Amount     money.Amount
+	Rollover   bool
+	StartDate  time.Time
+}
+
+// BudgetStatus compares the spending in the category of a budget with the budget,
+// for the period containing the day asked about. Spent is the money that left the category,
+// its negated net amount, up to and including that day.
+type BudgetStatus struct {
+	BudgetID     uint         `json:"budget_id"`
+	CategoryID   uint         `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Period       string       `json:"period"`
+	PeriodStart  time.Time    `json:"period_start"`
+	PeriodEnd    time.Time    `json:"period_end"`
+	Amount       money.Amount `json:"amount"`
+	// CarriedOver is the remainder of the earlier periods of a rollover budget, negative when they were overspent
+	CarriedOver money.Amount `json:"carried_over"`
+	Available   money.Amount `json:"available"`
+	Spent       money.Amount `json:"spent"`
+	Remaining   money.Amount `json:"remaining"`
+	// ProjectedSpend extrapolates the spending so far to the whole period at the same daily rate
+	ProjectedSpend money.Amount `json:"projected_spend"`
+	OverBudget     bool         `json:"over_budget"`
+	ProjectedOver  bool         `json:"projected_over"`
+}
+
+// GetBudgetStatus compares the budgets of the categories, every budget when none are given, with the spending
+// of their period containing the day. Budgets starting after that period are left out.
+// Only transactions within the caller's scope count as spending.
+func (q *QueryOps) GetBudgetStatus(ctx context.Context, date time.Time, categoryIDs []uint) ([]BudgetStatus, error) {
+	budgets, err := q.budgetRepo.FindByCategoryIDs(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	date = calendar.Day(date)
+
+	// The budgets of one period length share the current period, so their spending is summed in one query
+	spent := make(map[string]map[uint]money.Amount)
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		start := periodStart(date, budget.Period)
+		first := periodStart(budget.StartDate, budget.Period)
+		if first.After(start) {
+			continue
+		}
+
+		if _, ok := spent[budget.Period]; !ok {
+			totals, err := q.transactionRepo.NetByCategory(ctx, repository.TransactionFilter{
+				CategoryIDs: budgetCategories(budgets, budget.Period),
+				StartDate:   &start,
+				EndDate:     &date,
+			})
+			if err != nil {
+				return nil, err
+			}
+			spent[budget.Period] = totals
+		}
+
+		status := BudgetStatus{
+			BudgetID:    budget.BudgetID,
+			CategoryID:  budget.CategoryID,
+			Period:      budget.Period,
+			PeriodStart: start,
+			PeriodEnd:   nextPeriod(start, budget.Period).AddDate(0, 0, -1),
+			Amount:      budget.Amount,
+			Spent:       -spent[budget.Period][budget.CategoryID],
+		}
+		if budget.Category != nil {
+			status.CategoryName = budget.Category.Name
+		}
+		if budget.Rollover && first.Before(start) {
+			carried, err := q.carriedOver(ctx, budget, first, start)
+			if err != nil {
+				return nil, err
+			}
+			status.CarriedOver = carried
+		}
+
+		status.Available = status.Amount + status.CarriedOver
+		status.Remaining = status.Available - status.Spent
+		status.ProjectedSpend, err = projectSpend(status.Spent, start, date, status.PeriodEnd)
+		if err != nil {
+			return nil, err
+		}
+		status.OverBudget = status.Spent > status.Available
+		status.ProjectedOver = status.ProjectedSpend > status.Available
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// carriedOver is the budget of the periods from first up to current less the spending in them.
+// It equals adding up the remainder of each period, without a query per period.
+func (q *QueryOps) carriedOver(ctx context.Context, budget entity.Budget, first, current time.Time) (money.Amount, error) {
+	end := current.AddDate(0, 0, -1)
+	totals, err := q.transactionRepo.NetByCategory(ctx, repository.TransactionFilter{
+		CategoryIDs: []uint{budget.CategoryID},
+		StartDate:   &first,
+		EndDate:     &end,
+	})
+	if err != nil {
+		return 0, err
+	}
+	periods := money.Amount(periodsBetween(first, current, budget.Period))
+	return periods*budget.Amount + totals[budget.CategoryID], nil
+}
+
+// budgetCategories returns the categories of the budgets with the period
+func budgetCategories(budgets []entity.Budget, period string) []uint {
+	var ids []uint
+	for _, budget := range budgets {
+		if budget.Period == period {
+			ids = append(ids, budget.CategoryID)
+		}
+	}
+	return ids
+}
+
+// projectSpend extrapolates the spending from the start of the period up to the day to the whole period
+func projectSpend(spent money.Amount, start, date, end time.Time) (money.Amount, error) {
+	elapsed := int64(date.Sub(start).Hours()/24) + 1
+	total := int64(end.Sub(start).Hours()/24) + 1
+	return money.Round(spent.Decimal().Mul(decimal.NewFromInt(total)).Div(decimal.NewFromInt(elapsed)))
+}
+
+// periodStart returns the first day of the period containing the date; weeks start on Monday
+func periodStart(date time.Time, period string) time.Time {
+	date = calendar.Day(date)
+	switch repository.CashFlowInterval(period) {
+	case repository.IntervalWeek:
+		return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+	case repository.IntervalQuarter:
+		return time.Date(date.Year(), (date.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case repository.IntervalYear:
+		return time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// nextPeriod returns the first day of the period after the one starting on start
+func nextPeriod(start time.Time, period string) time.Time {
+	switch repository.CashFlowInterval(period) {
+	case repository.IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case repository.IntervalQuarter:
+		return start.AddDate(0, 3, 0)
+	case repository.IntervalYear:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// periodsBetween counts the periods from the one starting on first up to the one starting on current
+func periodsBetween(first, current time.Time, period string) int {
+	months := (current.Year()-first.Year())*12 + int(current.Month()-first.Month())
+	switch repository.CashFlowInterval(period) {
+	case repository.IntervalWeek:
+		return int(current.Sub(first).Hours()/24) / 7
+	case repository.IntervalQuarter:
+		return months / 3
+	case repository.IntervalYear:
+		return months / 12
+	default:
+		return months
+	}
+}
+
+// CreateBudget creates a budget after checking that its category exists and has no budget of the same period.
+// Budgets are shared by every account scope, so a caller restricted to a scope cannot create them.
+func (c *CommandOps) CreateBudget(ctx context.Context, in NewBudget, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionCreate, dryRun); err != nil {
+		return nil, err
+	}
+	if _, scoped := repository.ScopeFromContext(ctx); scoped {
+		return nil, fmt.Errorf("%w: budgets are shared by every account and cannot be created within an account scope",
+			repository.ErrOutOfScope)
+	}
+	if !slices.Contains(BudgetPeriods, in.Period) {
+		return nil, fmt.Errorf("%w: period must be one of %v", ErrInvalidCommand, BudgetPeriods)
+	}
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidCommand)
+	}
+	if in.Amount >= maxAmount {
+		return nil, fmt.Errorf("%w: amount must be less than %s", ErrInvalidCommand, maxAmount)
+	}
+	if in.StartDate.IsZero() {
+		return nil, fmt.Errorf("%w: start date is required", ErrInvalidCommand)
+	}
+	if err := c.checkCategory(ctx, in.CategoryID); err != nil {
+		return nil, err
+	}
+	_, err := c.budgetRepo.FindByCategoryAndPeriod(ctx, in.CategoryID, in.Period)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("%w: category %d already has a %s budget", ErrInvalidCommand, in.CategoryID, in.Period)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	budget := &entity.Budget{
+		CategoryID: in.CategoryID,
+		Period:     in.Period,
+		Amount:     in.Amount,
+		Rollover:   in.Rollover,
+		StartDate:  calendar.Day(in.StartDate),
+	}
+	result := &WriteResult{
+		Action: ActionCreate,
+		DryRun: dryRun,
+		Budget: budget,
+		Changes: []FieldChange{
+			{Field: "category_id", After: in.CategoryID},
+			{Field: "period", After: in.Period},
+			{Field: "amount", After: in.Amount},
+			{Field: "rollover", After: in.Rollover},
+			{Field: "start_date", After: budget.StartDate.Format(time.DateOnly)},
+		},
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := c.budgetRepo.Create(ctx, budget); err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+const selectNetByCategory = `SELECT transactions.category_id, SUM(transactions.amount) AS total FROM "transactions" ` +
+	`WHERE transactions.category_id IN ($1) AND transactions.transaction_date >= $2 AND transactions.transaction_date <= $3 ` +
+	`GROUP BY "transactions"."category_id"`
+
+// TestBudgetPeriods verifies the period arithmetic of each budget period
+func TestBudgetPeriods(t *testing.T) {
+	wednesday := time.Date(2024, 5, 15, 13, 0, 0, 0, time.UTC)
+	tests := []struct {
+		period  string
+		start   string
+		next    string
+		periods int
+	}{
+		{period: "week", start: "2024-05-13", next: "2024-05-20", periods: 19},
+		{period: "month", start: "2024-05-01", next: "2024-06-01", periods: 4},
+		{period: "quarter", start: "2024-04-01", next: "2024-07-01", periods: 1},
+		{period: "year", start: "2024-01-01", next: "2025-01-01", periods: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			start := periodStart(wednesday, tt.period)
+			if got := start.Format(time.DateOnly); got != tt.start {
+				t.Errorf("Expected the period to start on %s, got %s", tt.start, got)
+			}
+			if got := nextPeriod(start, tt.period).Format(time.DateOnly); got != tt.next {
+				t.Errorf("Expected the next period to start on %s, got %s", tt.next, got)
+			}
+			first := periodStart(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), tt.period)
+			if got := periodsBetween(first, start, tt.period); got != tt.periods {
+				t.Errorf("Expected %d periods since January 3, got %d", tt.periods, got)
+			}
+		})
+	}
+}
+
+// TestQueryOps_GetBudgetStatus verifies the carried over remainder of a rollover budget and the projection
+// of the spending so far, and that budgets starting after the period are left out
+func TestQueryOps_GetBudgetStatus(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "budgets" ORDER BY budget_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"budget_id", "category_id", "period", "amount", "rollover", "start_date"}).
+			AddRow(1, 3, "month", "500.00", true, date(10)).
+			AddRow(2, 5, "week", "50.00", false, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" IN ($1,$2)`)).
+		WithArgs(3, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).
+			AddRow(3, "Groceries", "Expense").
+			AddRow(5, "Dining", "Expense"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectNetByCategory)).
+		WithArgs(uint(3), march, asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "total"}).AddRow(3, "-200.00"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectNetByCategory)).
+		WithArgs(uint(3), date(1), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "total"}).AddRow(3, "-1100.00"))
+
+	statuses, err := queryOps.GetBudgetStatus(context.Background(), asOf.Add(18*time.Hour), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(statuses) != 1 {
+		t.Fatalf("Expected the budget starting next week to be left out, got %+v", statuses)
+	}
+	status := statuses[0]
+	if status.CategoryName != "Groceries" || !status.PeriodStart.Equal(march) ||
+		status.PeriodEnd.Format(time.DateOnly) != "2024-03-31" {
+		t.Errorf("Unexpected period %+v", status)
+	}
+	// Two earlier periods of 500 less 1100 spent in them
+	if status.CarriedOver != money.MustParse("-100") || status.Available != money.MustParse("400") {
+		t.Errorf("Expected 100 overspent to carry over, got %+v", status)
+	}
+	if status.Spent != money.MustParse("200") || status.Remaining != money.MustParse("200") || status.OverBudget {
+		t.Errorf("Unexpected spending %+v", status)
+	}
+	// 200 in 10 of 31 days
+	if status.ProjectedSpend != money.MustParse("620") || !status.ProjectedOver {
+		t.Errorf("Unexpected projection %+v", status)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestCommandOps_CreateBudget_Invalid verifies the validation of new budgets before any query
+func TestCommandOps_CreateBudget_Invalid(t *testing.T) {
+	commandOps, err := NewCommandOps(WithWritePolicy(WritePolicy{Enabled: true}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for name, budget := range map[string]NewBudget{
+		"unknown period":  {CategoryID: 1, Period: "day", Amount: money.MustParse("10"), StartDate: date(1)},
+		"negative amount": {CategoryID: 1, Period: "month", Amount: money.MustParse("-10"), StartDate: date(1)},
+		"no start date":   {CategoryID: 1, Period: "month", Amount: money.MustParse("10")},
+	} {
+		if _, err := commandOps.CreateBudget(context.Background(), budget, false); !errors.Is(err, ErrInvalidCommand) {
+			t.Errorf("%s: expected ErrInvalidCommand, got %v", name, err)
+		}
+	}
+}
+
+// TestCommandOps_CreateBudget_Scoped verifies that budgets, which every account scope shares, are only created
+// without a scope
+func TestCommandOps_CreateBudget_Scoped(t *testing.T) {
+	commandOps, err := NewCommandOps(WithWritePolicy(WritePolicy{Enabled: true}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ctx := repository.WithScope(context.Background(), &repository.Scope{AccountIDs: []uint{1}})
+
+	budget := NewBudget{CategoryID: 1, Period: "month", Amount: money.MustParse("10"), StartDate: date(1)}
+	if _, err := commandOps.CreateBudget(ctx, budget, true); !errors.Is(err, repository.ErrOutOfScope) {
+		t.Errorf("Expected ErrOutOfScope, got %v", err)
+	}
+}
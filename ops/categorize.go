@@ -0,0 +1,294 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"sample-mcp/categorize"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// RuleCondition is a property of an example transaction a categorization rule can match
+type RuleCondition string
+
+const (
+	// MatchCounterparty matches descriptions starting with the counterparty the example's description starts with
+	MatchCounterparty RuleCondition = "counterparty"
+	// MatchDescription matches descriptions containing the example's whole description
+	MatchDescription RuleCondition = "description"
+	// MatchAccount matches the transactions of the example's account
+	MatchAccount RuleCondition = "account"
+	// MatchAmount matches amounts of the example's sign within a tolerance of its amount
+	MatchAmount RuleCondition = "amount"
+)
+
+// RuleConditions are the properties of an example transaction a categorization rule can match
+var RuleConditions = []RuleCondition{MatchCounterparty, MatchDescription, MatchAccount, MatchAmount}
+
+// maxRuleName is the length of the name column of the categorization_rules table
+const maxRuleName = 100
+
+// NewRule holds the fields of a categorization rule to create; nil conditions match every transaction
+type NewRule struct {
+	Name                string
+	Priority            int
+	CategoryID          uint
+	AccountID           *uint
+	DescriptionContains *string
+	DescriptionPattern  *string
+	Counterparty        *string
+	MinAmount           *money.Amount
+	MaxAmount           *money.Amount
+}
+
+// RuleExample describes a categorization rule by a transaction it should match
+type RuleExample struct {
+	TransactionID uint
+	// CategoryID is the category the rule assigns, the example's category when zero
+	CategoryID uint
+	// Match lists the properties of the example the rule matches
+	Match []RuleCondition
+	// AmountTolerance is how far, in percent of the example's amount, the amounts MatchAmount matches may differ from it
+	AmountTolerance int
+	// Name names the rule, after its counterparty, description or example when empty
+	Name     string
+	Priority int
+}
+
+// RuleRun reports the transactions a run of the categorization rules moved to another category,
+// or would have moved in a dry run
+type RuleRun struct {
+	DryRun bool `json:"dry_run"`
+	// Examined counts the transactions the run looked at, Matched those a rule matched and Changed those of them
+	// that were in another category than the rule's
+	Examined int `json:"examined"`
+	Matched  int `json:"matched"`
+	Changed  int `json:"changed"`
+	// Rules are the rules that changed transactions, in the order they are tried
+	Rules []RuleChanges `json:"rules"`
+}
+
+// RuleChanges counts the transactions a categorization rule moved to its category
+type RuleChanges struct {
+	RuleID       uint   `json:"rule_id"`
+	Name         string `json:"name"`
+	CategoryID   uint   `json:"category_id"`
+	Category     string `json:"category"`
+	Transactions int    `json:"transactions"`
+}
+
+// ListCategorizationRules retrieves the categorization rules in the order they are tried
+func (q *QueryOps) ListCategorizationRules(ctx context.Context) ([]entity.CategorizationRule, error) {
+	return q.categorizationRuleRepo.FindAllByPriority(ctx)
+}
+
+// CreateCategorizationRule creates a categorization rule after checking its conditions and that its category and
+// account exist. Rules of every account categorize the transactions of all account scopes, so a caller restricted
+// to a scope must name an account.
+func (c *CommandOps) CreateCategorizationRule(ctx context.Context, in NewRule, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionCreate, dryRun); err != nil {
+		return nil, err
+	}
+	rule := &entity.CategorizationRule{
+		Name:                strings.TrimSpace(in.Name),
+		Priority:            in.Priority,
+		CategoryID:          in.CategoryID,
+		AccountID:           in.AccountID,
+		DescriptionContains: in.DescriptionContains,
+		DescriptionPattern:  in.DescriptionPattern,
+		Counterparty:        in.Counterparty,
+		MinAmount:           in.MinAmount,
+		MaxAmount:           in.MaxAmount,
+	}
+	if err := categorize.Validate(*rule); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommand, strings.TrimPrefix(err.Error(), categorize.ErrInvalidRule.Error()+": "))
+	}
+	if _, scoped := repository.ScopeFromContext(ctx); scoped && in.AccountID == nil {
+		return nil, fmt.Errorf("%w: a rule of every account cannot be created within an account scope, give an account", ErrInvalidCommand)
+	}
+	if err := c.checkCategory(ctx, in.CategoryID); err != nil {
+		return nil, err
+	}
+	if in.AccountID != nil {
+		if err := c.checkAccount(ctx, *in.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &WriteResult{
+		Action: ActionCreate,
+		DryRun: dryRun,
+		Rule:   rule,
+		Changes: []FieldChange{
+			{Field: "name", After: rule.Name},
+			{Field: "priority", After: rule.Priority},
+			{Field: "category_id", After: rule.CategoryID},
+		},
+	}
+	for _, condition := range []struct {
+		field string
+		value interface{}
+		set   bool
+	}{
+		{"account_id", rule.AccountID, rule.AccountID != nil},
+		{"description_contains", rule.DescriptionContains, rule.DescriptionContains != nil},
+		{"description_pattern", rule.DescriptionPattern, rule.DescriptionPattern != nil},
+		{"counterparty", rule.Counterparty, rule.Counterparty != nil},
+		{"min_amount", rule.MinAmount, rule.MinAmount != nil},
+		{"max_amount", rule.MaxAmount, rule.MaxAmount != nil},
+	} {
+		if condition.set {
+			result.Changes = append(result.Changes, FieldChange{Field: condition.field, After: condition.value})
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := c.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create categorization rule: %w", err)
+	}
+	return result, nil
+}
+
+// CreateCategorizationRuleFromTransaction creates a categorization rule matching the properties of an example
+// transaction the example lists, see CreateCategorizationRule
+func (c *CommandOps) CreateCategorizationRuleFromTransaction(ctx context.Context, example RuleExample, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionCreate, dryRun); err != nil {
+		return nil, err
+	}
+	if len(example.Match) == 0 {
+		return nil, fmt.Errorf("%w: at least one property of the transaction to match is required", ErrInvalidCommand)
+	}
+	if example.AmountTolerance < 0 || example.AmountTolerance > 100 {
+		return nil, fmt.Errorf("%w: amount tolerance must be between 0 and 100 percent", ErrInvalidCommand)
+	}
+	transaction, err := c.transactionRepo.FindByID(ctx, example.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var description string
+	if transaction.Description != nil {
+		description = strings.Join(strings.Fields(*transaction.Description), " ")
+	}
+	rule := NewRule{Name: example.Name, Priority: example.Priority, CategoryID: example.CategoryID}
+	if rule.CategoryID == 0 {
+		rule.CategoryID = transaction.CategoryID
+	}
+	for _, condition := range example.Match {
+		switch condition {
+		case MatchCounterparty:
+			counterparty := categorize.CounterpartyOf(description)
+			if counterparty == "" {
+				return nil, fmt.Errorf("%w: the description of transaction %d does not start with a counterparty, "+
+					"match its description instead", ErrInvalidCommand, transaction.TransactionID)
+			}
+			rule.Counterparty = &counterparty
+		case MatchDescription:
+			if description == "" {
+				return nil, fmt.Errorf("%w: transaction %d has no description", ErrInvalidCommand, transaction.TransactionID)
+			}
+			rule.DescriptionContains = &description
+		case MatchAccount:
+			rule.AccountID = &transaction.AccountID
+		case MatchAmount:
+			tolerance := money.FromCents(transaction.Amount.Abs().Cents() * int64(example.AmountTolerance) / 100)
+			minAmount, maxAmount := transaction.Amount-tolerance, transaction.Amount+tolerance
+			rule.MinAmount, rule.MaxAmount = &minAmount, &maxAmount
+		default:
+			return nil, fmt.Errorf("%w: cannot match the %q of a transaction, only one of %v", ErrInvalidCommand, condition, RuleConditions)
+		}
+	}
+	if strings.TrimSpace(rule.Name) == "" {
+		rule.Name = exampleRuleName(rule, transaction.TransactionID)
+	}
+	return c.CreateCategorizationRule(ctx, rule, dryRun)
+}
+
+// exampleRuleName names a rule created from an example after its counterparty or description
+func exampleRuleName(rule NewRule, transactionID uint) string {
+	var name string
+	switch {
+	case rule.Counterparty != nil:
+		name = *rule.Counterparty
+	case rule.DescriptionContains != nil:
+		name = *rule.DescriptionContains
+	default:
+		return fmt.Sprintf("Like transaction %d", transactionID)
+	}
+	if utf8.RuneCountInString(name) > maxRuleName {
+		name = string([]rune(name)[:maxRuleName])
+	}
+	return name
+}
+
+// ApplyCategorizationRules runs the categorization rules over the recorded transactions the filter selects, moving
+// each transaction a rule matches to that rule's category; transactions no rule matches keep theirs. A dry run
+// only counts the transactions that would move. The transactions are read a page at a time and moved in one
+// database transaction.
+func (c *CommandOps) ApplyCategorizationRules(ctx context.Context, filter repository.TransactionFilter, dryRun bool) (*RuleRun, error) {
+	if err := c.policy.allows(ActionUpdate, dryRun); err != nil {
+		return nil, err
+	}
+	stored, err := c.ruleRepo.FindAllByPriority(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := categorize.Compile(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &RuleRun{DryRun: dryRun, Rules: []RuleChanges{}}
+	changes := make(map[uint]*RuleChanges)
+	moves := make(map[uint][]uint)
+	page := repository.PageRequest{Limit: repository.MaxPageSize}
+	for rules.Len() > 0 {
+		result, err := c.transactionRepo.Search(ctx, filter, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range result.Items {
+			run.Examined++
+			rule := rules.Match(t)
+			if rule == nil {
+				continue
+			}
+			run.Matched++
+			if rule.CategoryID == t.CategoryID {
+				continue
+			}
+			run.Changed++
+			moves[rule.CategoryID] = append(moves[rule.CategoryID], t.TransactionID)
+			if changes[rule.RuleID] == nil {
+				changes[rule.RuleID] = &RuleChanges{RuleID: rule.RuleID, Name: rule.Name, CategoryID: rule.CategoryID}
+				if rule.Category != nil {
+					changes[rule.RuleID].Category = rule.Category.Name
+				}
+			}
+			changes[rule.RuleID].Transactions++
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+	for _, rule := range stored {
+		if change := changes[rule.RuleID]; change != nil {
+			run.Rules = append(run.Rules, *change)
+		}
+	}
+	if dryRun || run.Changed == 0 {
+		return run, nil
+	}
+
+	if _, err := c.transactionRepo.UpdateCategories(ctx, moves); err != nil {
+		return nil, fmt.Errorf("failed to recategorize transactions: %w", err)
+	}
+	return run, nil
+}
@@ -0,0 +1,150 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// setupMockCommandOps creates a CommandOps with the write policy on a mocked Postgres database
+func setupMockCommandOps(t *testing.T, policy WritePolicy) (*CommandOps, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, DriverName: "postgres"}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm connection: %v", err)
+	}
+	commandOps, err := NewCommandOps(WithCommandGormDB(gormDB), WithWritePolicy(policy))
+	if err != nil {
+		t.Fatalf("Failed to create command ops: %v", err)
+	}
+	return commandOps, mock
+}
+
+// expectStreamingRule expects the categorization rule filing payments to Netflix under Streaming
+func expectStreamingRule(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categorization_rules" ORDER BY priority DESC, rule_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "name", "priority", "category_id", "counterparty"}).
+			AddRow(1, "Streaming", 0, 7, "Netflix"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(7, "Streaming"))
+}
+
+// TestCommandOps_ApplyCategorizationRules verifies that only the transactions a rule matches in another category
+// than the rule's are moved, in one database transaction
+func TestCommandOps_ApplyCategorizationRules(t *testing.T) {
+	commandOps, mock := setupMockCommandOps(t, WritePolicy{Enabled: true})
+
+	expectStreamingRule(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) ORDER BY transaction_date ASC,transaction_id ASC LIMIT $2`)).
+		WithArgs(1, repository.MaxPageSize+1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description"}).
+			AddRow(7, 1, 3, "-15.99", date(5), "NETFLIX.COM 866-579-7172").
+			AddRow(8, 1, 7, "-15.99", date(12), "Netflix").
+			AddRow(9, 1, 3, "-30.00", date(15), "Market"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name"}).AddRow(1, "Checking"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" IN ($1,$2)`)).
+		WithArgs(3, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(3, "Groceries").AddRow(7, "Streaming"))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "category_id"=$1,"updated_at"=$2 WHERE transaction_id IN ($3)`)).
+		WithArgs(7, sqlmock.AnyArg(), 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	run, err := commandOps.ApplyCategorizationRules(context.Background(), repository.TransactionFilter{AccountIDs: []uint{1}}, false)
+	if err != nil {
+		t.Fatalf("Error applying the rules: %v", err)
+	}
+	if run.Examined != 3 || run.Matched != 2 || run.Changed != 1 {
+		t.Errorf("Unexpected run %+v", run)
+	}
+	if len(run.Rules) != 1 || run.Rules[0].RuleID != 1 || run.Rules[0].Category != "Streaming" || run.Rules[0].Transactions != 1 {
+		t.Errorf("Unexpected rule changes %+v", run.Rules)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestCommandOps_CreateCategorizationRuleFromTransaction verifies the conditions taken from an example transaction
+func TestCommandOps_CreateCategorizationRuleFromTransaction(t *testing.T) {
+	commandOps, mock := setupMockCommandOps(t, WritePolicy{})
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE "transactions"."transaction_id" = $1`)).
+		WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description"}).
+			AddRow(7, 1, 7, "-15.99", date(5), "NETFLIX.COM  866-579-7172"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" = $1`)).
+		WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name"}).AddRow(7, "Streaming"))
+
+	result, err := commandOps.CreateCategorizationRuleFromTransaction(context.Background(), RuleExample{
+		TransactionID:   7,
+		Match:           []RuleCondition{MatchCounterparty, MatchAmount},
+		AmountTolerance: 10,
+	}, true)
+	if err != nil {
+		t.Fatalf("Error creating the rule: %v", err)
+	}
+	rule := result.Rule
+	if !result.DryRun || rule.Name != "NETFLIX.COM" || rule.CategoryID != 7 || rule.AccountID != nil {
+		t.Errorf("Unexpected rule %+v", rule)
+	}
+	if rule.Counterparty == nil || *rule.Counterparty != "NETFLIX.COM" {
+		t.Errorf("Expected the counterparty NETFLIX.COM, got %v", rule.Counterparty)
+	}
+	if rule.MinAmount == nil || *rule.MinAmount != money.MustParse("-17.58") ||
+		rule.MaxAmount == nil || *rule.MaxAmount != money.MustParse("-14.40") {
+		t.Errorf("Expected amounts from -17.58 to -14.40, got %v to %v", rule.MinAmount, rule.MaxAmount)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestCommandOps_CreateCategorizationRule_Invalid verifies the validation of new rules before any query
+func TestCommandOps_CreateCategorizationRule_Invalid(t *testing.T) {
+	commandOps, mock := setupMockCommandOps(t, WritePolicy{Enabled: true})
+	counterparty, pattern := "Netflix", "(netflix"
+	scoped := repository.WithScope(context.Background(), &repository.Scope{AccountIDs: []uint{1}})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		rule    NewRule
+		message string
+	}{
+		{"no condition", context.Background(), NewRule{Name: "Everything", CategoryID: 7},
+			"invalid command: at least one condition is required"},
+		{"invalid pattern", context.Background(), NewRule{Name: "Streaming", CategoryID: 7, DescriptionPattern: &pattern},
+			"invalid command: description_pattern is not a regular expression: error parsing regexp: missing closing ): `(?i)(netflix`"},
+		{"every account within a scope", scoped, NewRule{Name: "Streaming", CategoryID: 7, Counterparty: &counterparty},
+			"invalid command: a rule of every account cannot be created within an account scope, give an account"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := commandOps.CreateCategorizationRule(tt.ctx, tt.rule, false)
+			if !errors.Is(err, ErrInvalidCommand) || err.Error() != tt.message {
+				t.Errorf("Expected %q, got %v", tt.message, err)
+			}
+		})
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,370 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/importer"
+	"sample-mcp/pkg/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrWritesDisabled is returned when the write policy does not allow the operation
+	ErrWritesDisabled = errors.New("writes are disabled by the write policy")
+	// ErrInvalidCommand is returned when a write operation is rejected by validation
+	ErrInvalidCommand = errors.New("invalid command")
+)
+
+// maxAmount is the exclusive bound of transaction amounts, given by the NUMERIC(10, 2) column
+var maxAmount = money.FromCents(1e10)
+
+// WritePolicy decides which write operations are allowed.
+// The zero value allows none, so deployments are read-only unless writes are enabled explicitly.
+type WritePolicy struct {
+	// Enabled allows creating, updating and importing transactions, creating budgets and categorization rules
+	// and applying the rules to recorded transactions, and storing the detected recurring payments
+	Enabled bool
+	// AllowDelete additionally allows deleting transactions
+	AllowDelete bool
+}
+
+// allows reports whether the policy permits the action; dry runs never write and are always permitted
+func (p WritePolicy) allows(action Action, dryRun bool) error {
+	switch {
+	case dryRun:
+		return nil
+	case !p.Enabled:
+		return ErrWritesDisabled
+	case action == ActionDelete && !p.AllowDelete:
+		return fmt.Errorf("%w: deleting transactions is not allowed", ErrWritesDisabled)
+	default:
+		return nil
+	}
+}
+
+// Action is the kind of change made by a write operation
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// FieldChange is the before and after value of a single field
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// WriteResult describes the outcome of a write operation on a transaction, a budget or a categorization rule.
+// For dry runs it describes the change that would have been made.
+type WriteResult struct {
+	Action      Action                     `json:"action"`
+	DryRun      bool                       `json:"dry_run"`
+	Transaction *entity.Transaction        `json:"transaction,omitempty"`
+	Budget      *entity.Budget             `json:"budget,omitempty"`
+	Rule        *entity.CategorizationRule `json:"rule,omitempty"`
+	Changes     []FieldChange              `json:"changes"`
+}
+
+// NewTransaction holds the fields of a transaction to record
+type NewTransaction struct {
+	AccountID       uint
+	CategoryID      uint
+	Amount          money.Amount
+	TransactionDate time.Time
+	Description     *string
+}
+
+// CommandOps provides validated write operations on transactions, budgets, categorization rules and recurring payments
+type CommandOps struct {
+	accountRepo     *repository.AccountRepository
+	categoryRepo    *repository.CategoryRepository
+	transactionRepo *repository.TransactionRepository
+	budgetRepo      *repository.BudgetRepository
+	ruleRepo        *repository.CategorizationRuleRepository
+	// recurringPaymentRepo stores the recurring payments detected by RefreshRecurringPayments
+	recurringPaymentRepo *repository.RecurringPaymentRepository
+	importer             *importer.Importer
+	policy               WritePolicy
+}
+
+// CommandOption defines a function that configures CommandOps
+type CommandOption func(*CommandOps) error
+
+// WithCommandRepositories sets the repositories directly
+func WithCommandRepositories(
+	accountRepo *repository.AccountRepository,
+	categoryRepo *repository.CategoryRepository,
+	transactionRepo *repository.TransactionRepository,
+) CommandOption {
+	return func(c *CommandOps) error {
+		c.accountRepo = accountRepo
+		c.categoryRepo = categoryRepo
+		c.transactionRepo = transactionRepo
+		return nil
+	}
+}
+
+// WithCommandGormDB creates repositories from a gorm.DB instance
+func WithCommandGormDB(db *gorm.DB) CommandOption {
+	return func(c *CommandOps) error {
+		c.accountRepo = repository.NewAccountRepository(db)
+		c.categoryRepo = repository.NewCategoryRepository(db)
+		c.transactionRepo = repository.NewTransactionRepository(db)
+		c.budgetRepo = repository.NewBudgetRepository(db)
+		c.ruleRepo = repository.NewCategorizationRuleRepository(db)
+		c.recurringPaymentRepo = repository.NewRecurringPaymentRepository(db)
+		c.importer = importer.New(db)
+		return nil
+	}
+}
+
+// WithCommandBudgetRepository sets the repository budgets are created in
+func WithCommandBudgetRepository(budgetRepo *repository.BudgetRepository) CommandOption {
+	return func(c *CommandOps) error {
+		c.budgetRepo = budgetRepo
+		return nil
+	}
+}
+
+// WithCommandRuleRepository sets the repository categorization rules are created in and applied from
+func WithCommandRuleRepository(ruleRepo *repository.CategorizationRuleRepository) CommandOption {
+	return func(c *CommandOps) error {
+		c.ruleRepo = ruleRepo
+		return nil
+	}
+}
+
+// WithCommandRecurringPaymentRepository sets the repository the detected recurring payments are stored in
+func WithCommandRecurringPaymentRepository(recurringPaymentRepo *repository.RecurringPaymentRepository) CommandOption {
+	return func(c *CommandOps) error {
+		c.recurringPaymentRepo = recurringPaymentRepo
+		return nil
+	}
+}
+
+// WithCommandImporter sets the importer bank statements are imported with
+func WithCommandImporter(importer *importer.Importer) CommandOption {
+	return func(c *CommandOps) error {
+		c.importer = importer
+		return nil
+	}
+}
+
+// WithWritePolicy sets the policy deciding which writes are allowed
+func WithWritePolicy(policy WritePolicy) CommandOption {
+	return func(c *CommandOps) error {
+		c.policy = policy
+		return nil
+	}
+}
+
+// NewCommandOps creates a new CommandOps instance with the provided options.
+// Without WithWritePolicy every write is rejected.
+func NewCommandOps(options ...CommandOption) (*CommandOps, error) {
+	c := &CommandOps{}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// RecordTransaction creates a transaction after checking that its account and category exist
+func (c *CommandOps) RecordTransaction(ctx context.Context, in NewTransaction, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionCreate, dryRun); err != nil {
+		return nil, err
+	}
+	if in.Amount == 0 {
+		return nil, fmt.Errorf("%w: amount must not be zero", ErrInvalidCommand)
+	}
+	if in.Amount.Abs() >= maxAmount {
+		return nil, fmt.Errorf("%w: amount must be less than %s", ErrInvalidCommand, maxAmount)
+	}
+	if in.TransactionDate.IsZero() {
+		return nil, fmt.Errorf("%w: transaction date is required", ErrInvalidCommand)
+	}
+	if err := c.checkAccount(ctx, in.AccountID); err != nil {
+		return nil, err
+	}
+	if err := c.checkCategory(ctx, in.CategoryID); err != nil {
+		return nil, err
+	}
+
+	transaction := &entity.Transaction{
+		AccountID:       in.AccountID,
+		CategoryID:      in.CategoryID,
+		Amount:          in.Amount,
+		TransactionDate: in.TransactionDate,
+		Description:     in.Description,
+	}
+	result := &WriteResult{
+		Action:      ActionCreate,
+		DryRun:      dryRun,
+		Transaction: transaction,
+		Changes: []FieldChange{
+			{Field: "account_id", After: in.AccountID},
+			{Field: "category_id", After: in.CategoryID},
+			{Field: "amount", After: in.Amount},
+			{Field: "transaction_date", After: in.TransactionDate.Format(time.DateOnly)},
+			{Field: "description", After: in.Description},
+		},
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := c.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to record transaction: %w", err)
+	}
+	return result, nil
+}
+
+// ImportStatements imports the lines of the statements of a file as transactions, skipping those recorded before,
+// and stores their ledger balances. Either every new line is imported or none.
+func (c *CommandOps) ImportStatements(ctx context.Context, statements []importer.Statement, options importer.Options) (*importer.Result, error) {
+	if err := c.policy.allows(ActionCreate, options.DryRun); err != nil {
+		return nil, err
+	}
+	result, err := c.importer.ImportStatements(ctx, statements, options)
+	if errors.Is(err, importer.ErrInvalidStatement) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommand, err)
+	}
+	return result, err
+}
+
+// RecategorizeTransaction moves a transaction to another category
+func (c *CommandOps) RecategorizeTransaction(ctx context.Context, transactionID, categoryID uint, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionUpdate, dryRun); err != nil {
+		return nil, err
+	}
+	if err := c.checkCategory(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	return c.update(ctx, transactionID, dryRun, func(transaction *entity.Transaction) []FieldChange {
+		if transaction.CategoryID == categoryID {
+			return nil
+		}
+		change := FieldChange{Field: "category_id", Before: transaction.CategoryID, After: categoryID}
+		transaction.CategoryID = categoryID
+		return []FieldChange{change}
+	})
+}
+
+// EditTransactionDescription replaces the description of a transaction; an empty description clears it
+func (c *CommandOps) EditTransactionDescription(ctx context.Context, transactionID uint, description string, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionUpdate, dryRun); err != nil {
+		return nil, err
+	}
+
+	var newDescription *string
+	if description != "" {
+		newDescription = &description
+	}
+
+	return c.update(ctx, transactionID, dryRun, func(transaction *entity.Transaction) []FieldChange {
+		if equalDescriptions(transaction.Description, newDescription) {
+			return nil
+		}
+		change := FieldChange{Field: "description", Before: transaction.Description, After: newDescription}
+		transaction.Description = newDescription
+		return []FieldChange{change}
+	})
+}
+
+// DeleteTransaction deletes a transaction
+func (c *CommandOps) DeleteTransaction(ctx context.Context, transactionID uint, dryRun bool) (*WriteResult, error) {
+	if err := c.policy.allows(ActionDelete, dryRun); err != nil {
+		return nil, err
+	}
+
+	transaction, err := c.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	result := &WriteResult{
+		Action:      ActionDelete,
+		DryRun:      dryRun,
+		Transaction: transaction,
+		Changes: []FieldChange{
+			{Field: "account_id", Before: transaction.AccountID},
+			{Field: "category_id", Before: transaction.CategoryID},
+			{Field: "amount", Before: transaction.Amount},
+			{Field: "transaction_date", Before: transaction.TransactionDate.Format(time.DateOnly)},
+			{Field: "description", Before: transaction.Description},
+		},
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := c.transactionRepo.DeleteByID(ctx, transactionID); err != nil {
+		return nil, fmt.Errorf("failed to delete transaction: %w", err)
+	}
+	return result, nil
+}
+
+// update loads a transaction, applies the change and saves it unless it is a dry run or nothing changed
+func (c *CommandOps) update(
+	ctx context.Context,
+	transactionID uint,
+	dryRun bool,
+	apply func(*entity.Transaction) []FieldChange,
+) (*WriteResult, error) {
+	transaction, err := c.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := apply(transaction)
+	result := &WriteResult{Action: ActionUpdate, DryRun: dryRun, Transaction: transaction, Changes: changes}
+	if dryRun || len(changes) == 0 {
+		return result, nil
+	}
+
+	if err := c.transactionRepo.Update(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+	return result, nil
+}
+
+// checkAccount verifies that the account exists within the caller's scope
+func (c *CommandOps) checkAccount(ctx context.Context, accountID uint) error {
+	if _, err := c.accountRepo.FindByID(ctx, accountID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: account %d does not exist", ErrInvalidCommand, accountID)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkCategory verifies that the category exists
+func (c *CommandOps) checkCategory(ctx context.Context, categoryID uint) error {
+	if _, err := c.categoryRepo.FindByID(ctx, categoryID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: category %d does not exist", ErrInvalidCommand, categoryID)
+		}
+		return err
+	}
+	return nil
+}
+
+func equalDescriptions(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,50 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestWritePolicy verifies which actions each write policy allows
+func TestWritePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  WritePolicy
+		action  Action
+		dryRun  bool
+		allowed bool
+	}{
+		{name: "read-only rejects updates", policy: WritePolicy{}, action: ActionUpdate},
+		{name: "read-only allows dry runs", policy: WritePolicy{}, action: ActionDelete, dryRun: true, allowed: true},
+		{name: "enabled allows creates", policy: WritePolicy{Enabled: true}, action: ActionCreate, allowed: true},
+		{name: "enabled rejects deletes", policy: WritePolicy{Enabled: true}, action: ActionDelete},
+		{name: "deletes need both flags", policy: WritePolicy{AllowDelete: true}, action: ActionDelete},
+		{name: "allow delete", policy: WritePolicy{Enabled: true, AllowDelete: true}, action: ActionDelete, allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.allows(tt.action, tt.dryRun)
+			if tt.allowed && err != nil {
+				t.Errorf("Expected %s to be allowed, got %v", tt.action, err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrWritesDisabled) {
+				t.Errorf("Expected ErrWritesDisabled for %s, got %v", tt.action, err)
+			}
+		})
+	}
+}
+
+// TestNewCommandOps_ReadOnlyByDefault verifies that writes are rejected without a write policy
+func TestNewCommandOps_ReadOnlyByDefault(t *testing.T) {
+	commandOps, err := NewCommandOps()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = commandOps.EditTransactionDescription(context.Background(), 1, "Rent", false)
+	if !errors.Is(err, ErrWritesDisabled) {
+		t.Errorf("Expected ErrWritesDisabled, got %v", err)
+	}
+}
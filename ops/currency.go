@@ -0,0 +1,244 @@
+package ops
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/calendar"
+	"sample-mcp/pkg/money"
+)
+
+// ErrNoExchangeRate is returned when an amount cannot be converted because no rate of its currency pair
+// is effective on its transaction date
+var ErrNoExchangeRate = errors.New("no exchange rate")
+
+// inverseRatePlaces is the precision of rates derived by inverting a rate of the opposite direction
+const inverseRatePlaces = 12
+
+// ConvertedBalance is the balance of an account in its own currency and in a reporting currency
+type ConvertedBalance struct {
+	AccountID        uint         `json:"account_id"`
+	Name             string       `json:"name"`
+	AccountCurrency  string       `json:"account_currency"`
+	Balance          money.Amount `json:"balance"`
+	Currency         string       `json:"currency"`
+	ConvertedBalance money.Amount `json:"converted_balance"`
+}
+
+// ConvertedSummary is the total of the transactions in a category in a reporting currency
+type ConvertedSummary struct {
+	CategoryName string       `json:"category_name"`
+	Currency     string       `json:"currency"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	Count        int64        `json:"count"`
+}
+
+// GetAccountBalancesIn calculates the balances of the accounts, every account in scope when none are given,
+// converted to the reporting currency with the rate effective on each transaction date
+func (q *QueryOps) GetAccountBalancesIn(ctx context.Context, currency string, accountIDs ...uint) ([]ConvertedBalance, error) {
+	to, err := money.ParseCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := q.accountsByID(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := q.transactionRepo.DailyTotals(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := q.converter(to)
+	balances := make(map[uint]money.Amount, len(accounts))
+	converted := make(map[uint]decimal.Decimal, len(accounts))
+	for _, total := range totals {
+		value, err := conv.convert(ctx, total.TotalAmount, total.Currency, total.TransactionDate)
+		if err != nil {
+			return nil, err
+		}
+		balances[total.AccountID] += total.TotalAmount
+		converted[total.AccountID] = converted[total.AccountID].Add(value)
+	}
+
+	result := make([]ConvertedBalance, 0, len(accounts))
+	for _, account := range accounts {
+		// Conversions are summed exactly and rounded once, so the total does not depend on the number of days
+		amount, err := money.Round(converted[account.AccountID])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ConvertedBalance{
+			AccountID:        account.AccountID,
+			Name:             account.Name,
+			AccountCurrency:  normalizeCurrency(account.Currency),
+			Balance:          balances[account.AccountID],
+			Currency:         to,
+			ConvertedBalance: amount,
+		})
+	}
+	return result, nil
+}
+
+// GetTransactionSummaryByCategoryIn summarizes the transactions of the accounts, every account in scope
+// when none are given, grouped by category and converted to the reporting currency with the rate
+// effective on each transaction date
+func (q *QueryOps) GetTransactionSummaryByCategoryIn(ctx context.Context, currency string, accountIDs ...uint) ([]ConvertedSummary, error) {
+	to, err := money.ParseCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := q.transactionRepo.DailyTotals(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := q.converter(to)
+	converted := make(map[string]decimal.Decimal)
+	counts := make(map[string]int64)
+	for _, total := range totals {
+		value, err := conv.convert(ctx, total.TotalAmount, total.Currency, total.TransactionDate)
+		if err != nil {
+			return nil, err
+		}
+		converted[total.CategoryName] = converted[total.CategoryName].Add(value)
+		counts[total.CategoryName] += total.Count
+	}
+
+	result := make([]ConvertedSummary, 0, len(converted))
+	for name, value := range converted {
+		amount, err := money.Round(value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ConvertedSummary{CategoryName: name, Currency: to, TotalAmount: amount, Count: counts[name]})
+	}
+	slices.SortFunc(result, func(a, b ConvertedSummary) int {
+		return strings.Compare(a.CategoryName, b.CategoryName)
+	})
+	return result, nil
+}
+
+// accountsByID retrieves the accounts with the given IDs, or every account in scope when none are given,
+// ordered by ID. A missing ID is reported as gorm.ErrRecordNotFound.
+func (q *QueryOps) accountsByID(ctx context.Context, ids []uint) ([]entity.Account, error) {
+	if len(ids) == 0 {
+		accounts, err := q.accountRepo.FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(accounts, func(a, b entity.Account) int {
+			return cmp.Compare(a.AccountID, b.AccountID)
+		})
+		return accounts, nil
+	}
+
+	accounts, err := q.accountRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(accounts, func(a entity.Account) bool { return a.AccountID == id }) {
+			return nil, fmt.Errorf("account %d: %w", id, gorm.ErrRecordNotFound)
+		}
+	}
+	return accounts, nil
+}
+
+// converter returns a converter to the reporting currency for a single query
+func (q *QueryOps) converter(to string) *converter {
+	return &converter{repo: q.exchangeRateRepo, to: to, tables: make(map[string]rateTable)}
+}
+
+// converter converts amounts into a reporting currency, loading the rates of each currency pair once
+type converter struct {
+	repo   *repository.ExchangeRateRepository
+	to     string
+	tables map[string]rateTable
+}
+
+// convert converts an amount in the currency from with the rate effective on the date.
+// The result is exact; callers round once after summing.
+func (c *converter) convert(ctx context.Context, amount money.Amount, from string, date time.Time) (decimal.Decimal, error) {
+	from = normalizeCurrency(from)
+	if from == c.to {
+		return amount.Decimal(), nil
+	}
+
+	table, ok := c.tables[from]
+	if !ok {
+		var rates []entity.ExchangeRate
+		if c.repo != nil {
+			var err error
+			rates, err = c.repo.FindByPair(ctx, from, c.to)
+			if err != nil {
+				return decimal.Decimal{}, err
+			}
+		}
+		table = newRateTable(rates, from, c.to)
+		c.tables[from] = table
+	}
+
+	rate, ok := table.at(date)
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("%w from %s to %s on %s", ErrNoExchangeRate, from, c.to, date.Format(time.DateOnly))
+	}
+	return amount.Decimal().Mul(rate), nil
+}
+
+// rateTable holds the rates of one currency pair in one direction, ordered by date
+type rateTable struct {
+	dates []time.Time
+	rates []decimal.Decimal
+}
+
+// newRateTable builds the table converting from one currency to another out of the rates of the pair
+// in either direction. Where both directions have a rate on the same date, the direct rate is used.
+func newRateTable(rates []entity.ExchangeRate, from, to string) rateTable {
+	byDate := make(map[time.Time]decimal.Decimal, len(rates))
+	for _, rate := range rates {
+		if normalizeCurrency(rate.BaseCurrency) == to && normalizeCurrency(rate.QuoteCurrency) == from {
+			byDate[calendar.Day(rate.RateDate)] = decimal.NewFromInt(1).DivRound(rate.Rate, inverseRatePlaces)
+		}
+	}
+	for _, rate := range rates {
+		if normalizeCurrency(rate.BaseCurrency) == from && normalizeCurrency(rate.QuoteCurrency) == to {
+			byDate[calendar.Day(rate.RateDate)] = rate.Rate
+		}
+	}
+
+	var table rateTable
+	for date := range byDate {
+		table.dates = append(table.dates, date)
+	}
+	slices.SortFunc(table.dates, func(a, b time.Time) int { return a.Compare(b) })
+	for _, date := range table.dates {
+		table.rates = append(table.rates, byDate[date])
+	}
+	return table
+}
+
+// at returns the rate effective on a date, the latest one on or before it
+func (t rateTable) at(date time.Time) (decimal.Decimal, bool) {
+	date = calendar.Day(date)
+	i := sort.Search(len(t.dates), func(i int) bool { return t.dates[i].After(date) })
+	if i == 0 {
+		return decimal.Decimal{}, false
+	}
+	return t.rates[i-1], true
+}
+
+// normalizeCurrency strips the padding of CHAR columns and upper cases a stored currency code
+func normalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
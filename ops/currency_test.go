@@ -0,0 +1,195 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func date(day int) time.Time {
+	return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+// setupMockQueryOps creates a QueryOps on a mocked Postgres database
+func setupMockQueryOps(t *testing.T) (*QueryOps, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, DriverName: "postgres"}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm connection: %v", err)
+	}
+	queryOps, err := NewQueryOps(WithGormDB(gormDB))
+	if err != nil {
+		t.Fatalf("Failed to create query ops: %v", err)
+	}
+	return queryOps, mock
+}
+
+var dailyTotalColumns = []string{"transaction_date", "account_id", "currency", "category_name", "total_amount", "count"}
+
+// TestRateTable verifies that the rate effective on a date is the latest one on or before it,
+// and that rates quoted in the opposite direction are inverted
+func TestRateTable(t *testing.T) {
+	table := newRateTable([]entity.ExchangeRate{
+		{RateDate: date(2), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: decimal.RequireFromString("1.10")},
+		{RateDate: date(5), BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: decimal.RequireFromString("0.8")},
+		{RateDate: date(9), BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: decimal.RequireFromString("0.5")},
+		{RateDate: date(9), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: decimal.RequireFromString("2.01")},
+	}, "EUR", "USD")
+
+	tests := []struct {
+		on   time.Time
+		want string
+		ok   bool
+	}{
+		{on: date(1)},
+		{on: date(2), want: "1.1", ok: true},
+		{on: date(4).Add(15 * time.Hour), want: "1.1", ok: true},
+		{on: date(5), want: "1.25", ok: true},
+		{on: date(9), want: "2.01", ok: true},
+		{on: date(31), want: "2.01", ok: true},
+	}
+	for _, tt := range tests {
+		rate, ok := table.at(tt.on)
+		if ok != tt.ok {
+			t.Errorf("%s: expected ok %v, got %v", tt.on, tt.ok, ok)
+			continue
+		}
+		if ok && !rate.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("%s: expected rate %s, got %s", tt.on, tt.want, rate)
+		}
+	}
+}
+
+// TestQueryOps_GetAccountBalancesIn converts each day of a EUR account with the rate of that day
+// and leaves the USD account as it is
+func TestQueryOps_GetAccountBalancesIn(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE account_id IN ($1,$2) ORDER BY account_id`)).
+		WithArgs(uint(1), uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "account_type", "currency"}).
+			AddRow(1, "Checking", "Checking", "USD").
+			AddRow(2, "Girokonto", "Checking", "EUR"))
+	mock.ExpectQuery(`SELECT transactions.transaction_date, .* FROM "transactions"`).
+		WithArgs(uint(1), uint(2)).
+		WillReturnRows(sqlmock.NewRows(dailyTotalColumns).
+			AddRow(date(2), 1, "USD", "Salary", "1000.00", 1).
+			AddRow(date(2), 2, "EUR", "Salary", "100.00", 1).
+			AddRow(date(3), 2, "EUR", "Groceries", "-10.01", 2).
+			AddRow(date(6), 2, "EUR", "Groceries", "-0.03", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates"`)).
+		WithArgs("EUR", "USD", "USD", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base_currency", "quote_currency", "rate"}).
+			AddRow(date(1), "EUR", "USD", "1.1").
+			AddRow(date(5), "EUR", "USD", "1.5"))
+
+	balances, err := queryOps.GetAccountBalancesIn(context.Background(), "usd", 1, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(balances) != 2 {
+		t.Fatalf("Expected 2 balances, got %d", len(balances))
+	}
+	if balances[0].ConvertedBalance != money.MustParse("1000") || balances[0].Currency != "USD" {
+		t.Errorf("Expected the USD account unchanged, got %+v", balances[0])
+	}
+	// 100 * 1.1 - 10.01 * 1.1 - 0.03 * 1.5 = 98.944, rounded once at the end
+	if balances[1].Balance != money.MustParse("89.96") || balances[1].ConvertedBalance != money.MustParse("98.94") {
+		t.Errorf("Expected 89.96 EUR converted to 98.94 USD, got %+v", balances[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestQueryOps_GetAccountBalancesIn_UnknownAccount verifies that a missing account is reported as not found
+func TestQueryOps_GetAccountBalancesIn_UnknownAccount(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE account_id IN ($1)`)).
+		WithArgs(uint(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+
+	_, err := queryOps.GetAccountBalancesIn(context.Background(), "USD", 9)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected gorm.ErrRecordNotFound, got %v", err)
+	}
+}
+
+// TestQueryOps_GetTransactionSummaryByCategoryIn sums categories across accounts of different currencies
+func TestQueryOps_GetTransactionSummaryByCategoryIn(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+
+	mock.ExpectQuery(`SELECT transactions.transaction_date, .* FROM "transactions"`).
+		WillReturnRows(sqlmock.NewRows(dailyTotalColumns).
+			AddRow(date(2), 1, "USD", "Groceries", "-22.00", 2).
+			AddRow(date(2), 2, "EUR", "Groceries", "-10.00", 1).
+			AddRow(date(3), 1, "USD", "Fuel", "-40.00", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base_currency", "quote_currency", "rate"}).
+			AddRow(date(1), "USD", "EUR", "0.8"))
+
+	summaries, err := queryOps.GetTransactionSummaryByCategoryIn(context.Background(), "EUR")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []ConvertedSummary{
+		{CategoryName: "Fuel", Currency: "EUR", TotalAmount: money.MustParse("-32"), Count: 1},
+		{CategoryName: "Groceries", Currency: "EUR", TotalAmount: money.MustParse("-27.60"), Count: 3},
+	}
+	if len(summaries) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, summaries)
+	}
+	for i := range want {
+		if summaries[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], summaries[i])
+		}
+	}
+}
+
+// TestQueryOps_GetTransactionSummaryByCategoryIn_NoRate verifies that amounts dated before the first rate
+// of their pair fail instead of being converted with a later rate
+func TestQueryOps_GetTransactionSummaryByCategoryIn_NoRate(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+
+	mock.ExpectQuery(`SELECT transactions.transaction_date, .* FROM "transactions"`).
+		WillReturnRows(sqlmock.NewRows(dailyTotalColumns).
+			AddRow(date(2), 2, "EUR", "Groceries", "-10.00", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date", "base_currency", "quote_currency", "rate"}).
+			AddRow(date(3), "EUR", "USD", "1.1"))
+
+	_, err := queryOps.GetTransactionSummaryByCategoryIn(context.Background(), "USD")
+	if !errors.Is(err, ErrNoExchangeRate) {
+		t.Fatalf("Expected ErrNoExchangeRate, got %v", err)
+	}
+	if want := "no exchange rate from EUR to USD on 2024-01-02"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+// TestQueryOps_GetTransactionSummaryByCategoryIn_InvalidCurrency verifies that the currency is checked first
+func TestQueryOps_GetTransactionSummaryByCategoryIn_InvalidCurrency(t *testing.T) {
+	queryOps, _ := setupMockQueryOps(t)
+
+	_, err := queryOps.GetTransactionSummaryByCategoryIn(context.Background(), "euro")
+	if !errors.Is(err, money.ErrInvalidCurrency) {
+		t.Errorf("Expected money.ErrInvalidCurrency, got %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package ops
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// ExportFormat is a file format transactions are exported in
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+	ExportXLSX   ExportFormat = "xlsx"
+)
+
+// ErrInvalidExport is returned for unsupported export formats and for exports the format cannot hold
+var ErrInvalidExport = errors.New("invalid export")
+
+// maxXLSXRows is the number of rows of a worksheet, the header included
+const maxXLSXRows = 1 << 20
+
+// MediaType returns the MIME type of files in the format
+func (f ExportFormat) MediaType() string {
+	switch f {
+	case ExportCSV:
+		return "text/csv"
+	case ExportNDJSON:
+		return "application/x-ndjson"
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// exportColumns are the columns of the CSV and XLSX exports, named like the fields of ExportedTransaction
+var exportColumns = []string{
+	"transaction_id", "date", "account_id", "account", "currency", "category_id", "category", "category_type",
+	"amount", "description", "external_id",
+}
+
+// ExportedTransaction is a transaction as exported, with the names of its account and category
+type ExportedTransaction struct {
+	TransactionID uint         `json:"transaction_id"`
+	Date          string       `json:"date"`
+	AccountID     uint         `json:"account_id"`
+	Account       string       `json:"account"`
+	Currency      string       `json:"currency"`
+	CategoryID    uint         `json:"category_id"`
+	Category      string       `json:"category"`
+	CategoryType  string       `json:"category_type"`
+	Amount        money.Amount `json:"amount"`
+	Description   string       `json:"description"`
+	ExternalID    string       `json:"external_id,omitempty"`
+}
+
+// exportedTransaction flattens a transaction loaded with its account and category
+func exportedTransaction(t entity.Transaction) ExportedTransaction {
+	row := ExportedTransaction{
+		TransactionID: t.TransactionID,
+		Date:          t.TransactionDate.Format(time.DateOnly),
+		AccountID:     t.AccountID,
+		CategoryID:    t.CategoryID,
+		Amount:        t.Amount,
+	}
+	if t.Account != nil {
+		row.Account = t.Account.Name
+		row.Currency = t.Account.Currency
+	}
+	if t.Category != nil {
+		row.Category = t.Category.Name
+		row.CategoryType = t.Category.CategoryType
+	}
+	if t.Description != nil {
+		row.Description = *t.Description
+	}
+	if t.ExternalID != nil {
+		row.ExternalID = *t.ExternalID
+	}
+	return row
+}
+
+// exportWriter writes the transactions of an export in one format
+type exportWriter interface {
+	write(row ExportedTransaction) error
+	// close completes the file; nothing may be written after it
+	close() error
+}
+
+// ExportTransactions writes the transactions matching the filter to w in the format, ordered like the filter sorts
+// them, and returns how many it wrote. The transactions are read a page at a time with their account and category,
+// so that an export of any size only holds a page in memory; w receives the file as it is written.
+func (q *QueryOps) ExportTransactions(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	format ExportFormat,
+	w io.Writer,
+) (int, error) {
+	out, err := newExportWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	page := repository.PageRequest{Limit: repository.MaxPageSize}
+	for {
+		result, err := q.transactionRepo.Search(ctx, filter, page)
+		if err != nil {
+			return count, err
+		}
+		for _, t := range result.Items {
+			if err := out.write(exportedTransaction(t)); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+	return count, out.close()
+}
+
+// newExportWriter starts a file in the format, writing its header
+func newExportWriter(format ExportFormat, w io.Writer) (exportWriter, error) {
+	switch format {
+	case ExportCSV:
+		return newCSVExportWriter(w)
+	case ExportNDJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		return &ndjsonExportWriter{encoder: encoder}, nil
+	case ExportXLSX:
+		return newXLSXExportWriter(w)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidExport, format)
+	}
+}
+
+// csvExportWriter writes a CSV file with a header row
+type csvExportWriter struct {
+	csv *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) (*csvExportWriter, error) {
+	out := &csvExportWriter{csv: csv.NewWriter(w)}
+	if err := out.csv.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *csvExportWriter) write(row ExportedTransaction) error {
+	return c.csv.Write([]string{
+		strconv.FormatUint(uint64(row.TransactionID), 10),
+		row.Date,
+		strconv.FormatUint(uint64(row.AccountID), 10),
+		csvText(row.Account),
+		row.Currency,
+		strconv.FormatUint(uint64(row.CategoryID), 10),
+		csvText(row.Category),
+		row.CategoryType,
+		row.Amount.String(),
+		csvText(row.Description),
+		csvText(row.ExternalID),
+	})
+}
+
+func (c *csvExportWriter) close() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+// csvText keeps spreadsheets from evaluating text as a formula by prefixing text that starts like one with a quote
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// ndjsonExportWriter writes a JSON object per line
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonExportWriter) write(row ExportedTransaction) error {
+	return n.encoder.Encode(row)
+}
+
+func (n *ndjsonExportWriter) close() error {
+	return nil
+}
+
+// The parts of an XLSX workbook besides its worksheet. Style 1 formats dates as YYYY-MM-DD and style 2 amounts
+// with two decimals.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Transactions" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd"/></numFmts>` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+// xlsxEpoch is day 0 of the serial dates of spreadsheets, which count 1900 as a leap year
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxExportWriter writes an XLSX workbook with a single worksheet, whose rows are written to the ZIP archive as
+// they come; the text is written inline rather than into a shared strings table that would have to be held in memory
+type xlsxExportWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	rows    int
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`+
+		`</sheetView></sheetViews><sheetData>`); err != nil {
+		return nil, err
+	}
+
+	out := &xlsxExportWriter{archive: archive, sheet: sheet}
+	header := make([]xlsxCell, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = xlsxText(column)
+	}
+	return out, out.writeRow(header)
+}
+
+// xlsxCell is a cell of a row: text, or a number with a style
+type xlsxCell struct {
+	text   string
+	number string
+	style  int
+}
+
+func xlsxText(s string) xlsxCell {
+	return xlsxCell{text: s}
+}
+
+func xlsxNumber(n uint) xlsxCell {
+	return xlsxCell{number: strconv.FormatUint(uint64(n), 10)}
+}
+
+func (x *xlsxExportWriter) write(row ExportedTransaction) error {
+	if x.rows >= maxXLSXRows {
+		return fmt.Errorf("%w: a worksheet holds at most %d transactions", ErrInvalidExport, maxXLSXRows-1)
+	}
+	date, err := time.Parse(time.DateOnly, row.Date)
+	if err != nil {
+		return err
+	}
+	return x.writeRow([]xlsxCell{
+		xlsxNumber(row.TransactionID),
+		{number: strconv.FormatInt((date.Unix()-xlsxEpoch.Unix())/(24*60*60), 10), style: 1},
+		xlsxNumber(row.AccountID),
+		xlsxText(row.Account),
+		xlsxText(row.Currency),
+		xlsxNumber(row.CategoryID),
+		xlsxText(row.Category),
+		xlsxText(row.CategoryType),
+		{number: row.Amount.String(), style: 2},
+		xlsxText(row.Description),
+		xlsxText(row.ExternalID),
+	})
+}
+
+// writeRow writes the next row of the worksheet
+func (x *xlsxExportWriter) writeRow(cells []xlsxCell) error {
+	x.rows++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.rows)
+	for i, cell := range cells {
+		ref := string(rune('A'+i)) + strconv.Itoa(x.rows)
+		switch {
+		case cell.number != "":
+			fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, cell.number)
+		case cell.text != "":
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(&b, []byte(cell.text)); err != nil {
+				return err
+			}
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+func (x *xlsxExportWriter) close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.archive.Close()
+}
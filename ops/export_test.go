@@ -0,0 +1,143 @@
+package ops
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/money"
+)
+
+// expectExportPage expects the single page of transactions of account 1 with their account and category
+func expectExportPage(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE transactions.account_id IN ($1) ORDER BY transaction_date ASC,transaction_id ASC LIMIT $2`)).
+		WithArgs(1, repository.MaxPageSize+1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "category_id", "amount", "transaction_date", "description", "external_id"}).
+			AddRow(7, 1, 4, "-1234.50", date(5), "=HYPERLINK(\"x\") & rent", nil).
+			AddRow(8, 1, 5, "2500.00", date(15), "Salary \"January\"", "FIT-8"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "name", "currency"}).AddRow(1, "Girokonto", "EUR"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE "categories"."category_id" IN ($1,$2)`)).
+		WithArgs(4, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "category_type"}).
+			AddRow(4, "Rent", "Expense").
+			AddRow(5, "Salary", "Income"))
+}
+
+func TestQueryOps_ExportTransactions_CSV(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+	expectExportPage(mock)
+
+	var out bytes.Buffer
+	count, err := queryOps.ExportTransactions(context.Background(), repository.TransactionFilter{AccountIDs: []uint{1}}, ExportCSV, &out)
+	if err != nil {
+		t.Fatalf("Error exporting: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 transactions, got %d", count)
+	}
+	want := "transaction_id,date,account_id,account,currency,category_id,category,category_type,amount,description,external_id\n" +
+		"7,2024-01-05,1,Girokonto,EUR,4,Rent,Expense,-1234.50,\"'=HYPERLINK(\"\"x\"\") & rent\",\n" +
+		"8,2024-01-15,1,Girokonto,EUR,5,Salary,Income,2500.00,\"Salary \"\"January\"\"\",FIT-8\n"
+	if out.String() != want {
+		t.Errorf("Unexpected CSV:\n%s", out.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestQueryOps_ExportTransactions_NDJSON(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+	expectExportPage(mock)
+
+	var out bytes.Buffer
+	if _, err := queryOps.ExportTransactions(context.Background(), repository.TransactionFilter{AccountIDs: []uint{1}}, ExportNDJSON, &out); err != nil {
+		t.Fatalf("Error exporting: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected a line per transaction, got %q", out.String())
+	}
+	var row ExportedTransaction
+	if err := json.Unmarshal([]byte(lines[1]), &row); err != nil {
+		t.Fatalf("Line is not JSON: %v", err)
+	}
+	if row.TransactionID != 8 || row.Date != "2024-01-15" || row.Category != "Salary" || row.CategoryType != "Income" ||
+		row.Amount != money.MustParse("2500") || row.ExternalID != "FIT-8" {
+		t.Errorf("Unexpected row %+v", row)
+	}
+	if !strings.Contains(lines[0], `"description":"=HYPERLINK(\"x\") & rent"`) {
+		t.Errorf("Expected the description unchanged and unescaped, got %s", lines[0])
+	}
+}
+
+// TestQueryOps_ExportTransactions_XLSX verifies the parts of the workbook and the cells of the worksheet
+func TestQueryOps_ExportTransactions_XLSX(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+	expectExportPage(mock)
+
+	var out bytes.Buffer
+	if _, err := queryOps.ExportTransactions(context.Background(), repository.TransactionFilter{AccountIDs: []uint{1}}, ExportXLSX, &out); err != nil {
+		t.Fatalf("Error exporting: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("Export is not a ZIP archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if parts[name] == "" {
+			t.Errorf("Missing part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, cell := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">transaction_id</t></is></c>`,
+		`<c r="B2" s="1"><v>45296</v></c>`,
+		`<c r="I2" s="2"><v>-1234.50</v></c>`,
+		`<c r="J2" t="inlineStr"><is><t xml:space="preserve">=HYPERLINK(&#34;x&#34;) &amp; rent</t></is></c>`,
+		`<c r="K3" t="inlineStr"><is><t xml:space="preserve">FIT-8</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, cell) {
+			t.Errorf("Expected %s in the worksheet", cell)
+		}
+	}
+	if strings.Contains(sheet, `r="K2"`) {
+		t.Errorf("Expected no cell for the missing external ID")
+	}
+	if !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Errorf("Worksheet is not complete")
+	}
+}
+
+func TestQueryOps_ExportTransactions_UnsupportedFormat(t *testing.T) {
+	queryOps, mock := setupMockQueryOps(t)
+
+	_, err := queryOps.ExportTransactions(context.Background(), repository.TransactionFilter{}, "pdf", io.Discard)
+	if !errors.Is(err, ErrInvalidExport) {
+		t.Errorf("Expected ErrInvalidExport, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sample-mcp/db/repository"
 	"sample-mcp/db/repository/plain"
 	"sample-mcp/pkg/db"
+	"sample-mcp/pkg/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,6 +17,14 @@ type QueryOps struct {
 	accountRepo     *repository.AccountRepository
 	categoryRepo    *repository.CategoryRepository
 	transactionRepo *repository.TransactionRepository
+	// exchangeRateRepo is only needed to convert between currencies
+	exchangeRateRepo *repository.ExchangeRateRepository
+	// budgetRepo is only needed to compare spending with budgets
+	budgetRepo *repository.BudgetRepository
+	// recurringPaymentRepo holds the recurring payments stored by RefreshRecurringPayments
+	recurringPaymentRepo *repository.RecurringPaymentRepository
+	// categorizationRuleRepo is only needed to list the categorization rules
+	categorizationRuleRepo *repository.CategorizationRuleRepository
 }
 
 // QueryOption defines a function that configures QueryOps
@@ -41,6 +50,42 @@ func WithGormDB(db *gorm.DB) QueryOption {
 		q.accountRepo = repository.NewAccountRepository(db)
 		q.categoryRepo = repository.NewCategoryRepository(db)
 		q.transactionRepo = repository.NewTransactionRepository(db)
+		q.exchangeRateRepo = repository.NewExchangeRateRepository(db)
+		q.budgetRepo = repository.NewBudgetRepository(db)
+		q.recurringPaymentRepo = repository.NewRecurringPaymentRepository(db)
+		q.categorizationRuleRepo = repository.NewCategorizationRuleRepository(db)
+		return nil
+	}
+}
+
+// WithExchangeRateRepository sets the repository of the exchange rates used by the currency conversions
+func WithExchangeRateRepository(exchangeRateRepo *repository.ExchangeRateRepository) QueryOption {
+	return func(q *QueryOps) error {
+		q.exchangeRateRepo = exchangeRateRepo
+		return nil
+	}
+}
+
+// WithBudgetRepository sets the repository of the budgets spending is compared with
+func WithBudgetRepository(budgetRepo *repository.BudgetRepository) QueryOption {
+	return func(q *QueryOps) error {
+		q.budgetRepo = budgetRepo
+		return nil
+	}
+}
+
+// WithRecurringPaymentRepository sets the repository the stored recurring payments are read from
+func WithRecurringPaymentRepository(recurringPaymentRepo *repository.RecurringPaymentRepository) QueryOption {
+	return func(q *QueryOps) error {
+		q.recurringPaymentRepo = recurringPaymentRepo
+		return nil
+	}
+}
+
+// WithCategorizationRuleRepository sets the repository of the categorization rules
+func WithCategorizationRuleRepository(categorizationRuleRepo *repository.CategorizationRuleRepository) QueryOption {
+	return func(q *QueryOps) error {
+		q.categorizationRuleRepo = categorizationRuleRepo
 		return nil
 	}
 }
@@ -94,11 +139,21 @@ func (q *QueryOps) SearchAccounts(ctx context.Context, keyword string) ([]entity
 	return q.accountRepo.FindByNameLike(ctx, keyword)
 }
 
+// SearchAccountsPage retrieves a page of the accounts with names containing the keyword
+func (q *QueryOps) SearchAccountsPage(ctx context.Context, keyword string, page repository.PageRequest) (*repository.Page[entity.Account], error) {
+	return q.accountRepo.FindByNameLikePage(ctx, keyword, page)
+}
+
 // GetAllAccounts retrieves all accounts
 func (q *QueryOps) GetAllAccounts(ctx context.Context) ([]entity.Account, error) {
 	return q.accountRepo.FindAll(ctx)
 }
 
+// GetAllAccountsPage retrieves a page of all accounts
+func (q *QueryOps) GetAllAccountsPage(ctx context.Context, page repository.PageRequest) (*repository.Page[entity.Account], error) {
+	return q.accountRepo.FindAllPage(ctx, page)
+}
+
 // GetCategoryByID retrieves a category by its ID
 func (q *QueryOps) GetCategoryByID(ctx context.Context, categoryID uint) (*entity.Category, error) {
 	return q.categoryRepo.FindByID(ctx, categoryID)
@@ -109,16 +164,31 @@ func (q *QueryOps) GetCategoriesByType(ctx context.Context, categoryType string)
 	return q.categoryRepo.FindByType(ctx, categoryType)
 }
 
+// GetCategoriesByTypePage retrieves a page of the categories of a type
+func (q *QueryOps) GetCategoriesByTypePage(ctx context.Context, categoryType string, page repository.PageRequest) (*repository.Page[entity.Category], error) {
+	return q.categoryRepo.FindByTypePage(ctx, categoryType, page)
+}
+
 // SearchCategories searches for categories with names containing the keyword
 func (q *QueryOps) SearchCategories(ctx context.Context, keyword string) ([]entity.Category, error) {
 	return q.categoryRepo.FindByNameLike(ctx, keyword)
 }
 
+// SearchCategoriesPage retrieves a page of the categories with names containing the keyword
+func (q *QueryOps) SearchCategoriesPage(ctx context.Context, keyword string, page repository.PageRequest) (*repository.Page[entity.Category], error) {
+	return q.categoryRepo.FindByNameLikePage(ctx, keyword, page)
+}
+
 // GetAllCategories retrieves all categories
 func (q *QueryOps) GetAllCategories(ctx context.Context) ([]entity.Category, error) {
 	return q.categoryRepo.FindAll(ctx)
 }
 
+// GetAllCategoriesPage retrieves a page of all categories
+func (q *QueryOps) GetAllCategoriesPage(ctx context.Context, page repository.PageRequest) (*repository.Page[entity.Category], error) {
+	return q.categoryRepo.FindAllPage(ctx, page)
+}
+
 // GetTransactionByID retrieves a transaction by its ID
 func (q *QueryOps) GetTransactionByID(ctx context.Context, transactionID uint) (*entity.Transaction, error) {
 	return q.transactionRepo.FindByID(ctx, transactionID)
@@ -129,11 +199,25 @@ func (q *QueryOps) GetTransactionsByAccountID(ctx context.Context, accountID uin
 	return q.transactionRepo.FindByAccountID(ctx, accountID)
 }
 
+// GetTransactionsByAccountIDPage retrieves a page of the transactions of an account
+func (q *QueryOps) GetTransactionsByAccountIDPage(ctx context.Context, accountID uint, page repository.PageRequest) (*repository.Page[entity.Transaction], error) {
+	return q.transactionRepo.FindByAccountIDPage(ctx, accountID, page)
+}
+
 // GetTransactionsByDateRange retrieves transactions within a date range
 func (q *QueryOps) GetTransactionsByDateRange(ctx context.Context, start, end time.Time) ([]entity.Transaction, error) {
 	return q.transactionRepo.FindByDateRange(ctx, start, end)
 }
 
+// GetTransactionsByDateRangePage retrieves a page of the transactions within a date range
+func (q *QueryOps) GetTransactionsByDateRangePage(
+	ctx context.Context,
+	start, end time.Time,
+	page repository.PageRequest,
+) (*repository.Page[entity.Transaction], error) {
+	return q.transactionRepo.FindByDateRangePage(ctx, start, end, page)
+}
+
 // GetTransactionsByAccountAndDateRange retrieves transactions for an account within a date range
 func (q *QueryOps) GetTransactionsByAccountAndDateRange(
 	ctx context.Context,
@@ -143,21 +227,126 @@ func (q *QueryOps) GetTransactionsByAccountAndDateRange(
 	return q.transactionRepo.FindByAccountAndDateRange(ctx, accountID, start, end)
 }
 
+// GetTransactionsByAccountAndDateRangePage retrieves a page of the transactions of an account within a date range
+func (q *QueryOps) GetTransactionsByAccountAndDateRangePage(
+	ctx context.Context,
+	accountID uint,
+	start, end time.Time,
+	page repository.PageRequest,
+) (*repository.Page[entity.Transaction], error) {
+	return q.transactionRepo.FindByAccountAndDateRangePage(ctx, accountID, start, end, page)
+}
+
 // SearchTransactionsByDescription searches for transactions with descriptions containing the keyword
 func (q *QueryOps) SearchTransactionsByDescription(ctx context.Context, keyword string) ([]entity.Transaction, error) {
 	return q.transactionRepo.FindByDescriptionLike(ctx, keyword)
 }
 
-// GetAccountBalance calculates the balance for an account
-func (q *QueryOps) GetAccountBalance(ctx context.Context, accountID uint) (float64, error) {
+// SearchTransactionsByDescriptionPage retrieves a page of the transactions with descriptions containing the keyword
+func (q *QueryOps) SearchTransactionsByDescriptionPage(
+	ctx context.Context,
+	keyword string,
+	page repository.PageRequest,
+) (*repository.Page[entity.Transaction], error) {
+	return q.transactionRepo.FindByDescriptionLikePage(ctx, keyword, page)
+}
+
+// SearchTransactions retrieves a page of the transactions matching every condition of the filter
+func (q *QueryOps) SearchTransactions(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	page repository.PageRequest,
+) (*repository.Page[entity.Transaction], error) {
+	return q.transactionRepo.Search(ctx, filter, page)
+}
+
+// searchAll loads every transaction matching the filter page by page, for analyses that need the whole history
+func searchAll(
+	ctx context.Context,
+	transactionRepo *repository.TransactionRepository,
+	filter repository.TransactionFilter,
+) ([]entity.Transaction, error) {
+	page := repository.PageRequest{Limit: repository.MaxPageSize}
+	var transactions []entity.Transaction
+	for {
+		result, err := transactionRepo.Search(ctx, filter, page)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, result.Items...)
+		if result.NextCursor == "" {
+			return transactions, nil
+		}
+		page.Cursor = result.NextCursor
+	}
+}
+
+// GetCashFlow sums the income, expenses and net amount of the transactions matching the filter per period,
+// optionally split by category or account
+func (q *QueryOps) GetCashFlow(
+	ctx context.Context,
+	filter repository.TransactionFilter,
+	interval repository.CashFlowInterval,
+	groupBy repository.CashFlowGrouping,
+) ([]plain.CashFlow, error) {
+	return q.transactionRepo.CashFlow(ctx, filter, interval, groupBy)
+}
+
+// GetCategoryBreakdown summarizes the transactions matching the filter per category, split into income and expenses
+func (q *QueryOps) GetCategoryBreakdown(ctx context.Context, filter repository.TransactionFilter) (*plain.CategoryBreakdown, error) {
+	return q.transactionRepo.CategoryBreakdown(ctx, filter)
+}
+
+// GetAccountBalance calculates the balance for an account.
+// An account that does not exist or lies outside the caller's scope is reported as gorm.ErrRecordNotFound.
+func (q *QueryOps) GetAccountBalance(ctx context.Context, accountID uint) (money.Amount, error) {
+	if err := q.checkAccount(ctx, accountID); err != nil {
+		return 0, err
+	}
 	return q.transactionRepo.SumByAccountID(ctx, accountID)
 }
 
-// GetTransactionCount gets the number of transactions for an account
+// GetBalanceAsOf calculates the balance of an account at the end of a day.
+// An account that does not exist or lies outside the caller's scope is reported as gorm.ErrRecordNotFound.
+func (q *QueryOps) GetBalanceAsOf(ctx context.Context, accountID uint, date time.Time) (money.Amount, error) {
+	if err := q.checkAccount(ctx, accountID); err != nil {
+		return 0, err
+	}
+	return q.transactionRepo.SumByAccountIDAsOf(ctx, accountID, date)
+}
+
+// GetRunningBalancesPage retrieves a page of the transactions of an account, oldest first,
+// each with the balance of the account right after it; start and end are optional
+func (q *QueryOps) GetRunningBalancesPage(
+	ctx context.Context,
+	accountID uint,
+	start, end *time.Time,
+	page repository.PageRequest,
+) (*repository.Page[plain.TransactionBalance], error) {
+	return q.transactionRepo.FindRunningBalancesPage(ctx, accountID, start, end, page)
+}
+
+// GetDailyBalances calculates the end-of-day balance of an account for every day of a date range
+func (q *QueryOps) GetDailyBalances(ctx context.Context, accountID uint, start, end time.Time) ([]plain.DailyBalance, error) {
+	return q.transactionRepo.DailyBalances(ctx, accountID, start, end)
+}
+
+// GetTransactionCount gets the number of transactions for an account.
+// An account that does not exist or lies outside the caller's scope is reported as gorm.ErrRecordNotFound.
 func (q *QueryOps) GetTransactionCount(ctx context.Context, accountID uint) (int64, error) {
+	if err := q.checkAccount(ctx, accountID); err != nil {
+		return 0, err
+	}
 	return q.transactionRepo.CountByAccountID(ctx, accountID)
 }
 
+// checkAccount verifies that the account exists within the caller's scope, so that an inaccessible account
+// is not mistaken for one without transactions
+func (q *QueryOps) checkAccount(ctx context.Context, accountID uint) error {
+	_, err := q.accountRepo.FindByID(ctx, accountID)
+	return err
+}
+
 // GetLatestTransactions gets the latest transactions for an account
 func (q *QueryOps) GetLatestTransactions(ctx context.Context, accountID uint, limit int) ([]entity.Transaction, error) {
 	return q.transactionRepo.FindLatestForAccount(ctx, accountID, limit)
@@ -172,3 +361,8 @@ func (q *QueryOps) GetTransactionSummaryByCategory(ctx context.Context, accountI
 func (q *QueryOps) GetAllTransactions(ctx context.Context) ([]entity.Transaction, error) {
 	return q.transactionRepo.FindAll(ctx)
 }
+
+// GetAllTransactionsPage retrieves a page of all transactions
+func (q *QueryOps) GetAllTransactionsPage(ctx context.Context, page repository.PageRequest) (*repository.Page[entity.Transaction], error) {
+	return q.transactionRepo.FindAllPage(ctx, page)
+}
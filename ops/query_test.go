@@ -1,8 +1,14 @@
 package ops
 
 import (
+	"context"
+	"errors"
+	"regexp"
 	"sample-mcp/db/repository"
 	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
 )
 
 // TestNewQueryOps verifies that the QueryOps struct can be created
@@ -33,6 +39,46 @@ func TestNewQueryOps(t *testing.T) {
 	}
 }
 
+// TestQueryOps_OutOfScopeAccount verifies that the balances and the transaction count of an account outside the
+// caller's scope are reported as not found instead of as an empty account
+func TestQueryOps_OutOfScopeAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, q *QueryOps) error
+	}{
+		{name: "GetAccountBalance", call: func(ctx context.Context, q *QueryOps) error {
+			_, err := q.GetAccountBalance(ctx, 9)
+			return err
+		}},
+		{name: "GetBalanceAsOf", call: func(ctx context.Context, q *QueryOps) error {
+			_, err := q.GetBalanceAsOf(ctx, 9, date(31))
+			return err
+		}},
+		{name: "GetTransactionCount", call: func(ctx context.Context, q *QueryOps) error {
+			_, err := q.GetTransactionCount(ctx, 9)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryOps, mock := setupMockQueryOps(t)
+			ctx := repository.WithScope(context.Background(), &repository.Scope{AccountIDs: []uint{1}})
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE accounts.account_id IN ($1) AND "accounts"."account_id" = $2`)).
+				WithArgs(1, 9, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+
+			if err := tt.call(ctx, queryOps); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("Expected gorm.ErrRecordNotFound, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
 // TestQueryOpsDocumentation verifies that the QueryOps struct has the expected methods
 // This is a documentation test that doesn't actually run any code
 func TestQueryOpsDocumentation(t *testing.T) {
@@ -0,0 +1,368 @@
+package ops
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"sample-mcp/db/entity"
+	"sample-mcp/db/repository"
+	"sample-mcp/pkg/calendar"
+	"sample-mcp/pkg/money"
+)
+
+const (
+	// RecurringLookback is how far back transactions are analyzed, enough for annual payments to recur
+	RecurringLookback = 3 * 365 * 24 * time.Hour
+	// MinRecurringOccurrences is the number of occurrences it takes to call a payment recurring
+	MinRecurringOccurrences = 3
+	// maxDescriptionKey is the length of the description_key column
+	maxDescriptionKey = 255
+)
+
+// unusualAmountFactor is how many times the typical amount an occurrence must exceed to be unusually large
+var unusualAmountFactor = decimal.NewFromFloat(1.5)
+
+// Frequency is how often a recurring payment recurs
+type Frequency string
+
+const (
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyAnnual  Frequency = "annual"
+)
+
+// frequencySpec describes the intervals of a frequency in days
+type frequencySpec struct {
+	frequency Frequency
+	days      float64
+	// tolerance is how many days an interval may deviate from days, per period it spans
+	tolerance float64
+	// grace is how many days a payment may be late before it counts as missed
+	grace int
+	next  func(time.Time) time.Time
+}
+
+var frequencySpecs = []frequencySpec{
+	{frequency: FrequencyWeekly, days: 7, tolerance: 1, grace: 3, next: func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }},
+	{frequency: FrequencyMonthly, days: 30.44, tolerance: 4, grace: 7, next: func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+	{frequency: FrequencyAnnual, days: 365.25, tolerance: 10, grace: 30, next: func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+}
+
+func specOf(frequency string) (frequencySpec, bool) {
+	for _, spec := range frequencySpecs {
+		if string(spec.frequency) == frequency {
+			return spec, true
+		}
+	}
+	return frequencySpec{}, false
+}
+
+// RecurringOccurrence is a transaction of a recurring payment
+type RecurringOccurrence struct {
+	TransactionID uint         `json:"transaction_id"`
+	Date          time.Time    `json:"date"`
+	Amount        money.Amount `json:"amount"`
+}
+
+// RecurringPaymentStatus is a recurring payment with the occurrences that stand out as of a day.
+// MissedOccurrences counts the gaps in the history and the due dates that passed without a payment;
+// Overdue is set when the next payment is missing beyond the grace period of the frequency.
+type RecurringPaymentStatus struct {
+	entity.RecurringPayment
+	MissedOccurrences  int                   `json:"missed_occurrences"`
+	Overdue            bool                  `json:"overdue"`
+	UnusualOccurrences []RecurringOccurrence `json:"unusual_occurrences"`
+}
+
+// Flagged reports whether the payment was missed or had an unusually large occurrence
+func (s RecurringPaymentStatus) Flagged() bool {
+	return s.MissedOccurrences > 0 || s.Overdue || len(s.UnusualOccurrences) > 0
+}
+
+// detectedPayment is a recurring payment found by the analysis, with the history gaps and large occurrences it saw
+// and the account and category of its occurrences, kept apart so that storing the payment leaves them alone
+type detectedPayment struct {
+	payment  entity.RecurringPayment
+	gaps     int
+	unusual  []RecurringOccurrence
+	account  *entity.Account
+	category *entity.Category
+}
+
+// AnalyzeRecurringPayments detects recurring payments among the transactions of the accounts, every account in
+// scope when none are given, from RecurringLookback before the day up to the day. It only reads: the payments
+// detected replace the stored ones of the same account, category and description, and stored payments last seen
+// after the day are left out, so an analysis of a past day is not mixed with later ones. It returns every
+// payment of the accounts ordered by next due date, including stored ones that stopped recurring,
+// with the misses and unusually large occurrences as of the day.
+func (q *QueryOps) AnalyzeRecurringPayments(ctx context.Context, date time.Time, accountIDs []uint) ([]RecurringPaymentStatus, error) {
+	date = calendar.Day(date)
+	detected, err := detectRecurringPaymentsAsOf(ctx, q.transactionRepo, date, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[recurringKey]*detectedPayment, len(detected))
+	for i := range detected {
+		found[keyOf(detected[i].payment)] = &detected[i]
+	}
+
+	stored, err := q.recurringPaymentRepo.FindByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]RecurringPaymentStatus, 0, len(stored)+len(detected))
+	for _, payment := range stored {
+		if d, ok := found[keyOf(payment)]; ok {
+			d.payment.RecurringPaymentID = payment.RecurringPaymentID
+			d.payment.CreatedAt = payment.CreatedAt
+			d.payment.UpdatedAt = payment.UpdatedAt
+			continue
+		}
+		if payment.LastDate.After(date) {
+			continue
+		}
+		statuses = append(statuses, newRecurringPaymentStatus(payment, date))
+	}
+	for _, d := range detected {
+		d.payment.Account, d.payment.Category = d.account, d.category
+		status := newRecurringPaymentStatus(d.payment, date)
+		status.MissedOccurrences += d.gaps
+		status.UnusualOccurrences = d.unusual
+		statuses = append(statuses, status)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].NextDueDate.Before(statuses[j].NextDueDate)
+	})
+	return statuses, nil
+}
+
+// newRecurringPaymentStatus checks a payment for due dates missed as of the day
+func newRecurringPaymentStatus(payment entity.RecurringPayment, date time.Time) RecurringPaymentStatus {
+	status := RecurringPaymentStatus{RecurringPayment: payment, UnusualOccurrences: []RecurringOccurrence{}}
+	if spec, ok := specOf(payment.Frequency); ok {
+		overdue := overduePeriods(spec, calendar.Day(payment.NextDueDate), date)
+		status.MissedOccurrences = overdue
+		status.Overdue = overdue > 0
+	}
+	return status
+}
+
+// RecurringRefresh is the outcome of storing the recurring payments detected as of a day.
+// For dry runs it lists the payments that would have been stored.
+type RecurringRefresh struct {
+	DryRun   bool                      `json:"dry_run"`
+	Date     time.Time                 `json:"date"`
+	Payments []entity.RecurringPayment `json:"payments"`
+}
+
+// RefreshRecurringPayments detects the recurring payments of the accounts as of today, every account in scope
+// when none are given, and stores them, updating those stored before. Only today's analysis is stored,
+// so that the stored payments never go back in time.
+func (c *CommandOps) RefreshRecurringPayments(ctx context.Context, accountIDs []uint, dryRun bool) (*RecurringRefresh, error) {
+	if err := c.policy.allows(ActionUpdate, dryRun); err != nil {
+		return nil, err
+	}
+	date := calendar.Day(time.Now().UTC())
+	detected, err := detectRecurringPaymentsAsOf(ctx, c.transactionRepo, date, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+	refresh := &RecurringRefresh{DryRun: dryRun, Date: date, Payments: make([]entity.RecurringPayment, len(detected))}
+	for i, d := range detected {
+		refresh.Payments[i] = d.payment
+	}
+	if dryRun {
+		return refresh, nil
+	}
+	if err := c.recurringPaymentRepo.Upsert(ctx, refresh.Payments); err != nil {
+		return nil, err
+	}
+	return refresh, nil
+}
+
+// detectRecurringPaymentsAsOf detects the recurring payments among the transactions of the accounts
+// from RecurringLookback before the day up to the day
+func detectRecurringPaymentsAsOf(
+	ctx context.Context,
+	transactionRepo *repository.TransactionRepository,
+	date time.Time,
+	accountIDs []uint,
+) ([]detectedPayment, error) {
+	start := date.Add(-RecurringLookback)
+	transactions, err := searchAll(ctx, transactionRepo, repository.TransactionFilter{AccountIDs: accountIDs, StartDate: &start, EndDate: &date})
+	if err != nil {
+		return nil, err
+	}
+	return detectRecurringPayments(transactions), nil
+}
+
+// recurringKey groups the transactions of one recurring payment
+type recurringKey struct {
+	accountID      uint
+	categoryID     uint
+	descriptionKey string
+}
+
+func keyOf(payment entity.RecurringPayment) recurringKey {
+	return recurringKey{payment.AccountID, payment.CategoryID, payment.DescriptionKey}
+}
+
+// detectRecurringPayments groups transactions by account, category and normalized description,
+// and keeps the groups recurring at a regular frequency. The transactions must be ordered by date.
+func detectRecurringPayments(transactions []entity.Transaction) []detectedPayment {
+	groups := make(map[recurringKey][]entity.Transaction)
+	var keys []recurringKey
+	for _, transaction := range transactions {
+		if transaction.Description == nil {
+			continue
+		}
+		description := normalizeDescription(*transaction.Description)
+		if description == "" {
+			continue
+		}
+		key := recurringKey{transaction.AccountID, transaction.CategoryID, description}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], transaction)
+	}
+
+	var detected []detectedPayment
+	for _, key := range keys {
+		if d, ok := detectRecurrence(key, groups[key]); ok {
+			detected = append(detected, d)
+		}
+	}
+	return detected
+}
+
+// detectRecurrence decides whether the occurrences of a group recur at a frequency: their median interval
+// must match it, and at least two thirds of the intervals must be whole periods within the tolerance.
+// Intervals of several periods are gaps where occurrences were missed.
+func detectRecurrence(key recurringKey, occurrences []entity.Transaction) (detectedPayment, bool) {
+	if len(occurrences) < MinRecurringOccurrences {
+		return detectedPayment{}, false
+	}
+
+	intervals := make([]float64, len(occurrences)-1)
+	for i := 1; i < len(occurrences); i++ {
+		intervals[i-1] = calendar.Day(occurrences[i].TransactionDate).Sub(calendar.Day(occurrences[i-1].TransactionDate)).Hours() / 24
+	}
+	median := medianOf(intervals)
+
+	for _, spec := range frequencySpecs {
+		if math.Abs(median-spec.days) > spec.tolerance {
+			continue
+		}
+		regular, gaps := 0, 0
+		for _, interval := range intervals {
+			if periods, ok := spec.periods(interval); ok {
+				regular++
+				gaps += periods - 1
+			}
+		}
+		if regular*3 < len(intervals)*2 {
+			return detectedPayment{}, false
+		}
+		return newDetectedPayment(key, spec, occurrences, gaps), true
+	}
+	return detectedPayment{}, false
+}
+
+// periods returns how many whole periods the interval spans, if it is within the tolerance of that many periods
+func (s frequencySpec) periods(interval float64) (int, bool) {
+	periods := int(interval/s.days + 0.5)
+	if periods < 1 || math.Abs(interval-float64(periods)*s.days) > float64(periods)*s.tolerance {
+		return 0, false
+	}
+	return periods, true
+}
+
+func newDetectedPayment(key recurringKey, spec frequencySpec, occurrences []entity.Transaction, gaps int) detectedPayment {
+	amounts := make([]money.Amount, len(occurrences))
+	for i, occurrence := range occurrences {
+		amounts[i] = occurrence.Amount
+	}
+	typical := medianAmount(amounts)
+	first, last := occurrences[0], occurrences[len(occurrences)-1]
+
+	d := detectedPayment{
+		payment: entity.RecurringPayment{
+			AccountID:      key.accountID,
+			CategoryID:     key.categoryID,
+			DescriptionKey: key.descriptionKey,
+			Description:    *last.Description,
+			Frequency:      string(spec.frequency),
+			TypicalAmount:  typical,
+			Occurrences:    len(occurrences),
+			FirstDate:      calendar.Day(first.TransactionDate),
+			LastDate:       calendar.Day(last.TransactionDate),
+			LastAmount:     last.Amount,
+			NextDueDate:    spec.next(calendar.Day(last.TransactionDate)),
+		},
+		gaps:     gaps,
+		unusual:  []RecurringOccurrence{},
+		account:  last.Account,
+		category: last.Category,
+	}
+	limit := typical.Abs().Decimal().Mul(unusualAmountFactor)
+	for _, occurrence := range occurrences {
+		if occurrence.Amount.Abs().Decimal().GreaterThan(limit) {
+			d.unusual = append(d.unusual, RecurringOccurrence{
+				TransactionID: occurrence.TransactionID,
+				Date:          calendar.Day(occurrence.TransactionDate),
+				Amount:        occurrence.Amount,
+			})
+		}
+	}
+	return d
+}
+
+// overduePeriods counts the due dates from next on whose grace period ended before the day
+func overduePeriods(spec frequencySpec, next, date time.Time) int {
+	count := 0
+	for next.AddDate(0, 0, spec.grace).Before(date) {
+		count++
+		next = spec.next(next)
+	}
+	return count
+}
+
+// normalizeDescription reduces a description to its lower case words, dropping the digits and punctuation
+// of reference numbers and dates so that the occurrences of a payment share a key
+func normalizeDescription(description string) string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	key := strings.Join(words, " ")
+	if runes := []rune(key); len(runes) > maxDescriptionKey {
+		key = strings.TrimSpace(string(runes[:maxDescriptionKey]))
+	}
+	return key
+}
+
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// medianAmount returns the median amount; of an even number of amounts, the mean of the middle two to the cent
+func medianAmount(amounts []money.Amount) money.Amount {
+	sorted := append([]money.Amount(nil), amounts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,118 @@
+package ops
+
+import (
+	"testing"
+	"time"
+
+	"sample-mcp/db/entity"
+	"sample-mcp/pkg/money"
+)
+
+func occurrence(id uint, categoryID uint, description string, day time.Time, amount string) entity.Transaction {
+	return entity.Transaction{
+		TransactionID:   id,
+		AccountID:       1,
+		CategoryID:      categoryID,
+		Description:     &description,
+		TransactionDate: day,
+		Amount:          money.MustParse(amount),
+	}
+}
+
+// TestDetectRecurringPayments verifies that a monthly payment is detected across a missed month and with one
+// unusually large occurrence, while irregular and too rare payments are not
+func TestDetectRecurringPayments(t *testing.T) {
+	on := func(month time.Month, day int) time.Time { return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC) }
+	transactions := []entity.Transaction{
+		occurrence(1, 2, "MORTGAGE PAYMENT #1001", on(1, 15), "-100"),
+		occurrence(2, 3, "Coffee", on(1, 16), "-4"),
+		occurrence(3, 3, "Coffee", on(1, 20), "-4"),
+		occurrence(4, 2, "Mortgage payment #1002", on(2, 14), "-100"),
+		occurrence(5, 3, "Coffee", on(2, 4), "-4"),
+		occurrence(6, 4, "Gym", on(2, 10), "-30"),
+		occurrence(7, 2, "Mortgage Payment #1003", on(3, 15), "-100"),
+		occurrence(8, 3, "Coffee", on(3, 6), "-4"),
+		occurrence(9, 4, "Gym", on(3, 10), "-30"),
+		{TransactionID: 10, AccountID: 1, CategoryID: 5, TransactionDate: on(3, 20), Amount: money.MustParse("-5")},
+		occurrence(11, 2, "Mortgage payment #1005", on(5, 15), "-180"),
+		occurrence(12, 2, "Mortgage payment #1006", on(6, 16), "-100"),
+	}
+
+	detected := detectRecurringPayments(transactions)
+	if len(detected) != 1 {
+		t.Fatalf("Expected only the mortgage to recur, got %+v", detected)
+	}
+	d := detected[0]
+	payment := d.payment
+	if payment.DescriptionKey != "mortgage payment" || payment.Description != "Mortgage payment #1006" ||
+		payment.Frequency != string(FrequencyMonthly) || payment.Occurrences != 5 {
+		t.Errorf("Unexpected payment %+v", payment)
+	}
+	if payment.TypicalAmount != money.MustParse("-100") || payment.LastAmount != money.MustParse("-100") {
+		t.Errorf("Unexpected amounts %+v", payment)
+	}
+	if !payment.FirstDate.Equal(on(1, 15)) || !payment.LastDate.Equal(on(6, 16)) || !payment.NextDueDate.Equal(on(7, 16)) {
+		t.Errorf("Unexpected dates %+v", payment)
+	}
+	if d.gaps != 1 {
+		t.Errorf("Expected the missed April payment to count as a gap, got %d", d.gaps)
+	}
+	if len(d.unusual) != 1 || d.unusual[0].TransactionID != 11 {
+		t.Errorf("Expected the May payment to be unusually large, got %+v", d.unusual)
+	}
+}
+
+// TestDetectRecurringPayments_Frequencies verifies the frequency picked for weekly and annual payments
+func TestDetectRecurringPayments_Frequencies(t *testing.T) {
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		frequency Frequency
+		step      func(i int) time.Time
+	}{
+		{frequency: FrequencyWeekly, step: func(i int) time.Time { return start.AddDate(0, 0, 7*i+i%2) }},
+		{frequency: FrequencyAnnual, step: func(i int) time.Time { return start.AddDate(i, 0, -3*i) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.frequency), func(t *testing.T) {
+			var transactions []entity.Transaction
+			for i := 0; i < 3; i++ {
+				transactions = append(transactions, occurrence(uint(i+1), 2, "Premium", tt.step(i), "-50"))
+			}
+			detected := detectRecurringPayments(transactions)
+			if len(detected) != 1 || detected[0].payment.Frequency != string(tt.frequency) {
+				t.Errorf("Expected a %s payment, got %+v", tt.frequency, detected)
+			}
+		})
+	}
+}
+
+func TestNormalizeDescription(t *testing.T) {
+	for in, want := range map[string]string{
+		"Property Tax Payment 01/2020": "property tax payment",
+		"  NETFLIX.COM *12345  ":       "netflix com",
+		"Café au lait":                 "café au lait",
+		"#2020/01/15":                  "",
+	} {
+		if got := normalizeDescription(in); got != want {
+			t.Errorf("normalizeDescription(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestOverduePeriods verifies that a due date counts as missed only once its grace period has passed
+func TestOverduePeriods(t *testing.T) {
+	monthly, _ := specOf(string(FrequencyMonthly))
+	next := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	for asOf, want := range map[string]int{
+		"2024-03-01": 0,
+		"2024-03-17": 0,
+		"2024-03-18": 1,
+		"2024-04-18": 2,
+	} {
+		date, _ := time.Parse(time.DateOnly, asOf)
+		if got := overduePeriods(monthly, next, date); got != want {
+			t.Errorf("As of %s expected %d overdue periods, got %d", asOf, want, got)
+		}
+	}
+}
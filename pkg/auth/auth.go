@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Mode selects how HTTP requests are authenticated
+type Mode string
+
+const (
+	ModeNone   Mode = "none"
+	ModeAPIKey Mode = "api_key"
+	ModeJWT    Mode = "jwt"
+)
+
+// APIKeyHeader is the header accepted for API keys in addition to a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// ErrUnauthenticated is returned when a request carries no valid credentials
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Config represents the authentication configuration
+type Config struct {
+	Mode    Mode       `yaml:"mode" validate:"omitempty,oneof=none api_key jwt"`
+	APIKeys []APIKey   `yaml:"apiKeys"`
+	JWT     *JWTConfig `yaml:"jwt"`
+}
+
+// APIKey is a static key granted to a named subject and the accounts it may access
+type APIKey struct {
+	Key     string `yaml:"key" validate:"required,min=16"`
+	Subject string `yaml:"subject" validate:"required"`
+	Grant   `yaml:",inline"`
+}
+
+// JWTConfig configures validation of HS256/RS256 bearer tokens against a local JWKS file
+type JWTConfig struct {
+	JWKSFile string        `yaml:"jwksFile" validate:"required"`
+	Issuer   string        `yaml:"issuer"`
+	Audience string        `yaml:"audience"`
+	Leeway   time.Duration `yaml:"leeway"`
+}
+
+// Authenticator resolves the principal of an HTTP request
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// NewAuthenticator creates the Authenticator for the configured mode.
+// It returns nil when authentication is disabled.
+func NewAuthenticator(config *Config) (Authenticator, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	switch config.Mode {
+	case ModeNone, "":
+		return nil, nil
+	case ModeAPIKey:
+		return NewAPIKeyAuthenticator(config.APIKeys)
+	case ModeJWT:
+		if config.JWT == nil {
+			return nil, fmt.Errorf("jwt auth requires a jwt section")
+		}
+		return NewJWTAuthenticator(config.JWT)
+	default:
+		return nil, fmt.Errorf("unsupported auth mode: %s", config.Mode)
+	}
+}
+
+// Middleware rejects requests that fail authentication and stores the principal
+// on the request context for the next handler
+func Middleware(authenticator Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if authenticator == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticator.Authenticate(r)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), principal)))
+		})
+	}
+}
+
+type apiKeyAuthenticator struct {
+	keys map[[sha256.Size]byte]APIKey
+}
+
+// NewAPIKeyAuthenticator creates an Authenticator accepting the given static keys,
+// sent either as a bearer token or in the X-API-Key header
+func NewAPIKeyAuthenticator(keys []APIKey) (Authenticator, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("api_key auth requires at least one key")
+	}
+
+	a := &apiKeyAuthenticator{keys: make(map[[sha256.Size]byte]APIKey, len(keys))}
+	for _, key := range keys {
+		if key.Key == "" || key.Subject == "" {
+			return nil, fmt.Errorf("api keys need both a key and a subject")
+		}
+		a.keys[sha256.Sum256([]byte(key.Key))] = key
+	}
+	return a, nil
+}
+
+func (a *apiKeyAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		key = bearerToken(r)
+	}
+	if key == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	// Compare digests in constant time so the lookup does not leak key prefixes
+	digest := sha256.Sum256([]byte(key))
+	for known, apiKey := range a.keys {
+		if subtle.ConstantTimeCompare(digest[:], known[:]) == 1 {
+			return &Principal{Subject: apiKey.Subject, Method: MethodAPIKey, Grant: apiKey.Grant}, nil
+		}
+	}
+	return nil, ErrUnauthenticated
+}
+
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jsonWebKey is the subset of RFC 7517 fields needed for RSA and symmetric keys
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// verificationKey is a parsed JWKS entry
+type verificationKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+type jwtAuthenticator struct {
+	keys    []verificationKey
+	options []jwt.ParserOption
+}
+
+// NewJWTAuthenticator creates an Authenticator validating HS256 and RS256 bearer tokens
+// against the keys of a local JWKS file; "oct" keys verify HS256 and "RSA" keys verify RS256
+func NewJWTAuthenticator(config *JWTConfig) (Authenticator, error) {
+	keys, err := loadJWKS(config.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &jwtAuthenticator{keys: keys, options: options}, nil
+}
+
+// loadJWKS reads the signing keys of a JWKS file
+func loadJWKS(path string) ([]verificationKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file: %w", err)
+	}
+
+	var keys []verificationKey
+	for i, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key %d: %w", i, err)
+		}
+		keys = append(keys, verificationKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks file %s contains no signing keys", path)
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) parse() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		if len(n) == 0 || len(e) == 0 {
+			return nil, errors.New("missing modulus or exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret: %w", err)
+		}
+		if len(secret) < 32 {
+			return nil, errors.New("HS256 secrets must be at least 256 bits")
+		}
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	raw := bearerToken(r)
+	if raw == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, a.keyFunc, a.options...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+	grant, err := grantFromClaims(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	return &Principal{Subject: subject, Method: MethodJWT, Grant: grant, Claims: claims}, nil
+}
+
+// grantFromClaims reads the account grant from the all_accounts, account_ids and account_types claims
+func grantFromClaims(claims jwt.MapClaims) (Grant, error) {
+	var grant Grant
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return grant, err
+	}
+	if err := json.Unmarshal(data, &grant); err != nil {
+		return grant, fmt.Errorf("invalid account claims: %w", err)
+	}
+	return grant, nil
+}
+
+// keyFunc selects the JWKS keys matching the token's kid and algorithm
+func (a *jwtAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	kid, _ := token.Header["kid"].(string)
+
+	var set jwt.VerificationKeySet
+	for _, k := range a.keys {
+		if kid != "" && k.kid != kid {
+			continue
+		}
+		if k.alg != "" && k.alg != alg {
+			continue
+		}
+		switch k.key.(type) {
+		case *rsa.PublicKey:
+			if alg != jwt.SigningMethodRS256.Alg() {
+				continue
+			}
+		case []byte:
+			if alg != jwt.SigningMethodHS256.Alg() {
+				continue
+			}
+		}
+		set.Keys = append(set.Keys, k.key)
+	}
+
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("no key for kid %q and algorithm %s", kid, alg)
+	}
+	return set, nil
+}
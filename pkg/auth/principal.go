@@ -0,0 +1,40 @@
+package auth
+
+import "context"
+
+// Method identifies how a principal was authenticated
+type Method string
+
+const (
+	MethodAPIKey Method = "api_key"
+	MethodJWT    Method = "jwt"
+)
+
+// Grant is the set of accounts a principal may access.
+// Accounts are granted by ID or by account type; a zero Grant grants no accounts.
+type Grant struct {
+	AllAccounts  bool     `yaml:"allAccounts" json:"all_accounts,omitempty"`
+	AccountIDs   []uint   `yaml:"accountIds" json:"account_ids,omitempty"`
+	AccountTypes []string `yaml:"accountTypes" json:"account_types,omitempty"`
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string                 `json:"subject"`
+	Method  Method                 `json:"method"`
+	Grant   Grant                  `json:"grant"`
+	Claims  map[string]interface{} `json:"claims,omitempty"`
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
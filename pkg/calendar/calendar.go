@@ -0,0 +1,8 @@
+package calendar
+
+import "time"
+
+// Day truncates a time to midnight UTC of its date, so that dates read by different drivers compare by calendar day
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
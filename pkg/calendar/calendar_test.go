@@ -0,0 +1,16 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDay(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+
+	assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Day(time.Date(2024, 3, 5, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Day(time.Date(2024, 3, 5, 0, 30, 0, 0, berlin)),
+		"the date is kept in its own zone, not converted to UTC first")
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -18,13 +19,17 @@ const (
 	Mysql      DatabaseType = "MYSQL"
 	Postgresql DatabaseType = "POSTGRES"
 	MSSQL      DatabaseType = "MSSQL"
+	Sqlite     DatabaseType = "SQLITE"
 )
 
+// SqliteMemory is the DbName of an in-memory SQLite database; any other SQLite DbName is a file path
+const SqliteMemory = ":memory:"
+
 type ConnectionConfig struct {
-	DbType       DatabaseType  `yaml:"dbType" validate:"required,oneof=MYSQL POSTGRES MSSQL" mapstructure:"dbType"`
-	Host         string        `yaml:"host" validate:"required,min=1" mapstructure:"host"`
+	DbType       DatabaseType  `yaml:"dbType" validate:"required,oneof=MYSQL POSTGRES MSSQL SQLITE" mapstructure:"dbType"`
+	Host         string        `yaml:"host" validate:"required_unless=DbType SQLITE" mapstructure:"host"`
 	Port         int           `yaml:"port" mapstructure:"port"`
-	Username     string        `yaml:"username" validate:"required,min=1" mapstructure:"username"`
+	Username     string        `yaml:"username" validate:"required_unless=DbType SQLITE" mapstructure:"username"`
 	Password     string        `yaml:"password" mapstructure:"password"`
 	DbName       string        `yaml:"dbName" validate:"required,min=1" mapstructure:"dbName"`
 	Timeout      time.Duration `yaml:"timeout" mapstructure:"timeout" validate:"min=3s"`
@@ -38,11 +43,16 @@ func (c *ConnectionConfig) Dsn() string {
 	var dsn string
 	switch c.DbType {
 	case Mysql:
-		dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&timeout=%s", c.Username, c.Password, c.Host, c.Port, c.DbName, timeoutString)
+		dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&multiStatements=true&timeout=%s", c.Username, c.Password, c.Host, c.Port, c.DbName, timeoutString)
 	case Postgresql:
 		dsn = fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable connect_timeout=%d", c.Username, c.Password, c.Host, c.Port, c.DbName, int(c.Timeout.Seconds()))
 	case MSSQL:
 		dsn = fmt.Sprintf("sqlserver://%s:%s@%s:%d?database=%s&connectTimeout=%s&encrypt=disable", c.Username, c.Password, c.Host, c.Port, c.DbName, timeoutString)
+	case Sqlite:
+		if c.DbName == "" {
+			break
+		}
+		dsn = fmt.Sprintf("%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(%d)&_time_format=sqlite", c.DbName, c.Timeout.Milliseconds())
 	}
 
 	return dsn
@@ -61,6 +71,11 @@ func (c *ConnectionConfig) Pool() (*gorm.DB, error) {
 		dialector = postgres.Open(c.Dsn())
 	case MSSQL:
 		dialector = sqlserver.Open(c.Dsn())
+	case Sqlite:
+		var err error
+		if dialector, err = sqliteDialector(c.Dsn()); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", c.DbType)
 	}
@@ -79,6 +94,11 @@ func (c *ConnectionConfig) Pool() (*gorm.DB, error) {
 
 	sqlDB.SetMaxIdleConns(c.MaxIdleConns)
 	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	if c.DbType == Sqlite && c.DbName == SqliteMemory {
+		// Every connection to ":memory:" opens a new empty database, so the pool keeps exactly one
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetMaxOpenConns(1)
+	}
 
 	if err := sqlDB.Ping(); err != nil {
 		return nil, err
@@ -95,3 +115,13 @@ func (c *ConnectionConfig) SqlDB() (*sql.DB, error) {
 
 	return gormDB.DB()
 }
+
+// Ping checks that the database behind the pool is reachable
+func Ping(ctx context.Context, pool *gorm.DB) error {
+	sqlDB, err := pool.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
@@ -1,7 +1,12 @@
 package db
 
 import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 	"testing"
 	"time"
 )
@@ -39,6 +44,17 @@ func TestDatabaseConfigValidation(t *testing.T) {
 	if err := validate.Struct(invalidConfig); err == nil {
 		t.Error("validation of invalid database config passed unexpectedly")
 	}
+
+	sqliteConfig := &ConnectionConfig{
+		DbType:       Sqlite,
+		DbName:       SqliteMemory,
+		Timeout:      3 * time.Second,
+		MaxIdleConns: 1,
+		MaxOpenConns: 2,
+	}
+	if err := validate.Struct(sqliteConfig); err != nil {
+		t.Errorf("validation of sqlite config without host and username failed: %v", err)
+	}
 }
 
 func TestCustomValidationTags(t *testing.T) {
@@ -81,7 +97,7 @@ func TestConnectionConfig_Dsn(t *testing.T) {
 				DbName:   "test",
 				Timeout:  3 * time.Second,
 			},
-			wantDsn: "root:password@tcp(localhost:3306)/test?parseTime=true&timeout=3s",
+			wantDsn: "root:password@tcp(localhost:3306)/test?parseTime=true&multiStatements=true&timeout=3s",
 		},
 		{
 			name: "Postgres connection",
@@ -107,7 +123,25 @@ func TestConnectionConfig_Dsn(t *testing.T) {
 				DbName:   "test",
 				Timeout:  5 * time.Second,
 			},
-			wantDsn: "root:password@tcp(localhost:8080)/test?parseTime=true&timeout=5s",
+			wantDsn: "root:password@tcp(localhost:8080)/test?parseTime=true&multiStatements=true&timeout=5s",
+		},
+		{
+			name: "SQLite file",
+			config: ConnectionConfig{
+				DbType:  Sqlite,
+				DbName:  "data/mcp.db",
+				Timeout: 3 * time.Second,
+			},
+			wantDsn: "data/mcp.db?_pragma=foreign_keys(1)&_pragma=busy_timeout(3000)&_time_format=sqlite",
+		},
+		{
+			name: "SQLite in memory",
+			config: ConnectionConfig{
+				DbType:  Sqlite,
+				DbName:  SqliteMemory,
+				Timeout: 5 * time.Second,
+			},
+			wantDsn: ":memory:?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite",
 		},
 		{
 			name: "All configurations are empty",
@@ -133,3 +167,32 @@ func TestConnectionConfig_Dsn(t *testing.T) {
 		})
 	}
 }
+
+func TestPing(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	// gorm pings the connection when it is opened
+	mock.ExpectPing()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm database: %v", err)
+	}
+
+	mock.ExpectPing()
+	if err := Ping(context.Background(), gormDB); err != nil {
+		t.Errorf("Ping() returned unexpected error: %v", err)
+	}
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	if err := Ping(context.Background(), gormDB); err == nil {
+		t.Error("Ping() should return the database error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
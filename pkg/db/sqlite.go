@@ -0,0 +1,13 @@
+//go:build sqlite
+
+package db
+
+import (
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteDialector opens SQLite with a pure-Go driver, so no CGO toolchain is needed
+func sqliteDialector(dsn string) (gorm.Dialector, error) {
+	return sqlite.Open(dsn), nil
+}
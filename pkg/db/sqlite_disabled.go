@@ -0,0 +1,16 @@
+//go:build !sqlite
+
+package db
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// sqliteDialector reports that SQLite support was left out of the build.
+// The pure-Go SQLite engine adds several megabytes to the binary and is meant for local and test use,
+// so it is only compiled in with -tags sqlite.
+func sqliteDialector(string) (gorm.Dialector, error) {
+	return nil, errors.New("SQLite support is not compiled in, rebuild with -tags sqlite")
+}
@@ -0,0 +1,18 @@
+//go:build !sqlite
+
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPool_SqliteNotCompiledIn(t *testing.T) {
+	config := &ConnectionConfig{DbType: Sqlite, DbName: SqliteMemory, Timeout: 3 * time.Second}
+
+	_, err := config.Pool()
+	if err == nil || !strings.Contains(err.Error(), "-tags sqlite") {
+		t.Errorf("Expected an error naming the sqlite build tag, got %v", err)
+	}
+}
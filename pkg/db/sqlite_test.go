@@ -0,0 +1,30 @@
+//go:build sqlite
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPool_SqliteMemory(t *testing.T) {
+	config := &ConnectionConfig{DbType: Sqlite, DbName: SqliteMemory, Timeout: 3 * time.Second, MaxIdleConns: 5, MaxOpenConns: 10}
+
+	pool, err := config.Pool()
+	if err != nil {
+		t.Fatalf("Failed to open in-memory database: %v", err)
+	}
+
+	// The table must survive across statements, which requires the single shared connection
+	if err := pool.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if !pool.Migrator().HasTable("notes") {
+		t.Error("Expected the table to exist on the pooled connection")
+	}
+
+	if err := Ping(context.Background(), pool); err != nil {
+		t.Errorf("Expected ping to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCurrency is returned for currency codes that are not three letters
+var ErrInvalidCurrency = errors.New("invalid currency code")
+
+// ParseCurrency normalizes an ISO 4217 currency code such as "eur" to its upper case form "EUR".
+// Only the shape of the code is checked, so that any currency the rate table knows can be used.
+func ParseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", fmt.Errorf("%w %q", ErrInvalidCurrency, code)
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", fmt.Errorf("%w %q", ErrInvalidCurrency, code)
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,21 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCurrency(t *testing.T) {
+	for in, want := range map[string]string{"EUR": "EUR", "usd": "USD", " gbp ": "GBP"} {
+		got, err := ParseCurrency(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "EU", "EURO", "E1R", "€"} {
+		_, err := ParseCurrency(in)
+		assert.ErrorIs(t, err, ErrInvalidCurrency, in)
+	}
+}
@@ -0,0 +1,162 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// Scale is the number of decimal places of an Amount, matching the NUMERIC(10, 2) amount columns
+const Scale = 2
+
+// maxCents keeps amounts within the exact integer range of float64, for clients reading JSON numbers as doubles
+const maxCents = 1 << 53
+
+// ErrExcessPrecision is returned for amounts with more decimals than an Amount holds, such as 10.005.
+// They are rejected rather than rounded, so that what is stored is what was given.
+var ErrExcessPrecision = errors.New("more than two decimals")
+
+// Amount is an exact monetary amount in minor units (hundredths).
+// Sums of Amounts never drift, unlike float64, and they are read from and written to the database as decimal text.
+type Amount int64
+
+// FromCents creates an Amount from minor units
+func FromCents(cents int64) Amount {
+	return Amount(cents)
+}
+
+// FromDecimal converts a decimal with at most two decimals exactly, see ErrExcessPrecision
+func FromDecimal(d decimal.Decimal) (Amount, error) {
+	if !d.Equal(d.Round(Scale)) {
+		return 0, fmt.Errorf("amount %s has %w", d, ErrExcessPrecision)
+	}
+	return Round(d)
+}
+
+// Round rounds a decimal to the nearest minor unit, halves away from zero.
+// Use it for computed amounts such as averages and currency conversions, not for amounts given by users.
+func Round(d decimal.Decimal) (Amount, error) {
+	shifted := d.Round(Scale).Shift(Scale)
+	if shifted.Abs().GreaterThan(decimal.NewFromInt(maxCents)) {
+		return 0, fmt.Errorf("amount %s is out of range", d)
+	}
+	return Amount(shifted.IntPart()), nil
+}
+
+// FromFloat rounds a float to the nearest minor unit.
+// Use it only at boundaries that hand out floats, such as drivers returning REAL values.
+func FromFloat(f float64) (Amount, error) {
+	return Round(decimal.NewFromFloat(f))
+}
+
+// Parse reads a decimal string with at most two decimals, such as "-1208.93"
+func Parse(s string) (Amount, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if !d.Equal(d.Round(Scale)) {
+		return 0, fmt.Errorf("amount %q has %w", s, ErrExcessPrecision)
+	}
+	return Round(d)
+}
+
+// MustParse is like Parse but panics on invalid input; meant for constants and tests
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Cents returns the amount in minor units
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// Decimal returns the amount as an exact decimal
+func (a Amount) Decimal() decimal.Decimal {
+	return decimal.New(int64(a), -Scale)
+}
+
+// Float64 returns the nearest float; for display and charts only
+func (a Amount) Float64() float64 {
+	return float64(a) / 100
+}
+
+// Abs returns the absolute value of the amount
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// String formats the amount with exactly two decimals, e.g. -1208.90
+func (a Amount) String() string {
+	return a.Decimal().StringFixed(Scale)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or a decimal string
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("expected an amount")
+		}
+		s = n.String()
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value writes the amount as decimal text, which every supported database converts to its NUMERIC type exactly
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan reads NUMERIC values, which drivers return as text, integers or, for SQLite, floats.
+// NULL, as returned by SUM over no rows, scans as zero.
+func (a *Amount) Scan(src interface{}) error {
+	var (
+		parsed Amount
+		err    error
+	)
+	switch v := src.(type) {
+	case nil:
+		parsed = 0
+	case int64:
+		parsed, err = Parse(strconv.FormatInt(v, 10))
+	case float64:
+		parsed, err = FromFloat(v)
+	case []byte:
+		parsed, err = Parse(string(v))
+	case string:
+		parsed, err = Parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into an amount", src)
+	}
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,133 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+	}{
+		{in: "0", want: 0},
+		{in: "1208.93", want: 120893},
+		{in: "-103.7", want: -10370},
+		{in: "5", want: 500},
+		{in: "12.340", want: 1234},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	_, err := Parse("twelve")
+	assert.Error(t, err)
+	_, err = Parse("1e20")
+	assert.Error(t, err, "amounts beyond the exact float range are rejected")
+	for _, in := range []string{"10.005", "-0.005", "12.344"} {
+		_, err = Parse(in)
+		assert.ErrorIs(t, err, ErrExcessPrecision, in)
+	}
+}
+
+func TestFromDecimal(t *testing.T) {
+	a, err := FromDecimal(decimal.RequireFromString("-42.50"))
+	require.NoError(t, err)
+	assert.Equal(t, FromCents(-4250), a)
+
+	_, err = FromDecimal(decimal.RequireFromString("10.005"))
+	assert.ErrorIs(t, err, ErrExcessPrecision)
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+	}{
+		{in: "0.005", want: 1},
+		{in: "-0.005", want: -1},
+		{in: "12.344", want: 1234},
+		{in: "-1208.93", want: -120893},
+	}
+
+	for _, tt := range tests {
+		got, err := Round(decimal.RequireFromString(tt.in))
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	assert.Equal(t, "-1208.90", FromCents(-120890).String())
+	assert.Equal(t, "0.05", FromCents(5).String())
+	assert.Equal(t, "-0.05", FromCents(-5).String())
+	assert.Equal(t, "0.00", Amount(0).String())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount Amount `json:"amount"`
+	}{Amount: MustParse("-42.5")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": -42.50}`, string(data))
+
+	for _, in := range []string{`-42.5`, `"-42.50"`, `-42.50`} {
+		var a Amount
+		require.NoError(t, json.Unmarshal([]byte(in), &a), in)
+		assert.Equal(t, FromCents(-4250), a, in)
+	}
+
+	var a Amount
+	assert.Error(t, json.Unmarshal([]byte(`true`), &a))
+}
+
+func TestAmount_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		want Amount
+	}{
+		{name: "postgres numeric", src: "1250.50", want: 125050},
+		{name: "mysql decimal", src: []byte("-0.10"), want: -10},
+		{name: "integer", src: int64(12), want: 1200},
+		{name: "sqlite real", src: 0.1 + 0.2, want: 30},
+		{name: "null sum", src: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Amount(99)
+			require.NoError(t, a.Scan(tt.src))
+			assert.Equal(t, tt.want, a)
+		})
+	}
+
+	var a Amount
+	assert.Error(t, a.Scan(true))
+}
+
+func TestAmount_Value(t *testing.T) {
+	value, err := MustParse("-1208.93").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "-1208.93", value)
+}
+
+func TestAmount_SumIsExact(t *testing.T) {
+	// 0.10 + 0.20 drifts as float64 but not in minor units
+	var sum Amount
+	var floatSum float64
+	for i := 0; i < 1000; i++ {
+		sum += MustParse("0.10")
+		floatSum += 0.10
+	}
+	assert.Equal(t, MustParse("100"), sum)
+	assert.NotEqual(t, 100.0, floatSum)
+	assert.True(t, sum.Decimal().Equal(decimal.NewFromInt(100)))
+}
@@ -0,0 +1,443 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"sample-mcp/pkg/auth"
+)
+
+const (
+	// SessionHeader carries the MCP session ID assigned on initialize
+	SessionHeader = "Mcp-Session-Id"
+
+	maxBodySize = 1 << 20
+)
+
+// HealthCheck reports whether the server's dependencies are reachable
+type HealthCheck func(ctx context.Context) error
+
+// HTTPServer serves MCP tools over the streamable HTTP transport at /mcp,
+// the legacy HTTP+SSE transport at /sse and /message, and a /healthz endpoint
+type HTTPServer struct {
+	dispatcher     *dispatcher
+	logger         *log.Logger
+	health         HealthCheck
+	allowedOrigins map[string]bool
+	middleware     []func(http.Handler) http.Handler
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	done     chan struct{}
+	closed   bool
+}
+
+// session is the state of a connected client; events is only set for legacy SSE clients
+type session struct {
+	events chan []byte
+	// owner identifies the principal that opened the session; only its requests may use the session
+	owner string
+}
+
+// HTTPOption defines a function that configures an HTTPServer
+type HTTPOption func(*HTTPServer)
+
+// WithHealthCheck sets the check run by /healthz
+func WithHealthCheck(check HealthCheck) HTTPOption {
+	return func(s *HTTPServer) {
+		s.health = check
+	}
+}
+
+// WithLogger sets the logger used for transport errors
+func WithLogger(logger *log.Logger) HTTPOption {
+	return func(s *HTTPServer) {
+		s.logger = logger
+	}
+}
+
+// WithAllowedOrigins permits browser requests from the given origins in addition to the server's own host
+func WithAllowedOrigins(origins ...string) HTTPOption {
+	return func(s *HTTPServer) {
+		for _, origin := range origins {
+			s.allowedOrigins[strings.TrimSuffix(origin, "/")] = true
+		}
+	}
+}
+
+// WithMiddleware wraps the MCP endpoints, but not /healthz, with the given middleware,
+// for example to authenticate requests
+func WithMiddleware(middleware func(http.Handler) http.Handler) HTTPOption {
+	return func(s *HTTPServer) {
+		s.middleware = append(s.middleware, middleware)
+	}
+}
+
+// NewHTTPServer creates an HTTPServer for the tools of the given source
+func NewHTTPServer(name, version string, tools ToolSource, options ...HTTPOption) *HTTPServer {
+	s := &HTTPServer{
+		dispatcher:     &dispatcher{name: name, version: version, tools: tools},
+		logger:         log.New(io.Discard, "", 0),
+		allowedOrigins: make(map[string]bool),
+		sessions:       make(map[string]*session),
+		done:           make(chan struct{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Handler returns the HTTP handler serving all endpoints
+func (s *HTTPServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", s.wrap(s.handleMCP))
+	mux.Handle("/sse", s.wrap(s.handleSSE))
+	mux.Handle("/message", s.wrap(s.handleMessage))
+	mux.HandleFunc("/healthz", s.handleHealth)
+	return mux
+}
+
+// ListenAndServe serves on addr until ctx is cancelled, then shuts down gracefully,
+// waiting up to shutdownTimeout for in-flight requests to complete
+func (s *HTTPServer) ListenAndServe(ctx context.Context, addr string, shutdownTimeout time.Duration) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return s.Serve(ctx, listener, shutdownTimeout)
+}
+
+// Serve serves on an existing listener until ctx is cancelled
+func (s *HTTPServer) Serve(ctx context.Context, listener net.Listener, shutdownTimeout time.Duration) error {
+	httpServer := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          s.logger,
+	}
+	// Open SSE streams never finish on their own, so end them when shutdown starts
+	httpServer.RegisterOnShutdown(s.close)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down HTTP server: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *HTTPServer) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
+
+func (s *HTTPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := map[string]string{"status": "ok"}
+	code := http.StatusOK
+	if s.health != nil {
+		if err := s.health(r.Context()); err != nil {
+			// The endpoint is unauthenticated, so the cause is logged rather than returned
+			s.logger.Printf("Health check failed: %v", err)
+			status = map[string]string{"status": "unavailable"}
+			code = http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, code, status)
+}
+
+func (s *HTTPServer) handleMCP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.handlePost(w, r)
+	case http.MethodDelete:
+		id := r.Header.Get(SessionHeader)
+		if id == "" || !s.endSession(r, id) {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		// Server-initiated messages are not used, so there is no standalone GET stream
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *HTTPServer) handlePost(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(SessionHeader)
+	if sessionID != "" && s.session(r, sessionID) == nil {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	requests, batch, err := readRequests(w, r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(nil, ParseErrorCode, "parse error: %v", err))
+		return
+	}
+
+	var responses []*response
+	for _, req := range requests {
+		res := s.dispatcher.handle(r.Context(), req)
+		if res == nil {
+			continue
+		}
+		if req.Method == "initialize" && res.Error == nil && sessionID == "" {
+			sessionID = s.newSession(r, nil)
+			w.Header().Set(SessionHeader, sessionID)
+		}
+		responses = append(responses, res)
+	}
+
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	var body interface{} = responses[0]
+	if batch {
+		body = responses
+	}
+	if acceptsOnly(r, "text/event-stream") {
+		s.writeEvent(w, body)
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// handleSSE opens a legacy HTTP+SSE stream; the client posts messages to the announced endpoint
+// and receives the responses as events on the stream
+func (s *HTTPServer) handleSSE(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events := make(chan []byte, 16)
+	sessionID := s.newSession(r, events)
+	defer s.endSession(r, sessionID)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", url.QueryEscape(sessionID))
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case data := <-events:
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *HTTPServer) handleMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess := s.session(r, r.URL.Query().Get("sessionId"))
+	if sess == nil || sess.events == nil {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	requests, _, err := readRequests(w, r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("parse error: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, req := range requests {
+		res := s.dispatcher.handle(r.Context(), req)
+		if res == nil {
+			continue
+		}
+		data, err := json.Marshal(res)
+		if err != nil {
+			s.logger.Printf("Failed to encode response: %v", err)
+			continue
+		}
+		select {
+		case sess.events <- data:
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			http.Error(w, "server shutting down", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// wrap applies the origin check and the configured middleware to an MCP endpoint.
+// The tool handlers receive the request context, so values stored by the middleware reach them.
+func (s *HTTPServer) wrap(endpoint http.HandlerFunc) http.Handler {
+	var h http.Handler = endpoint
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		h = s.middleware[i](h)
+	}
+	return s.checkOrigin(h)
+}
+
+// checkOrigin rejects browser requests from foreign origins to prevent DNS rebinding attacks
+func (s *HTTPServer) checkOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && !s.allowedOrigins[origin] {
+			parsed, err := url.Parse(origin)
+			if err != nil || parsed.Host != r.Host {
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *HTTPServer) newSession(r *http.Request, events chan []byte) string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	id := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[id] = &session{events: events, owner: ownerOf(r)}
+	return id
+}
+
+// session returns the session with the ID if the request comes from the principal that opened it,
+// so that a caller knowing another's session ID cannot use it
+func (s *HTTPServer) session(r *http.Request, id string) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok || sess.owner != ownerOf(r) {
+		return nil
+	}
+	return sess
+}
+
+func (s *HTTPServer) endSession(r *http.Request, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok || sess.owner != ownerOf(r) {
+		return false
+	}
+	delete(s.sessions, id)
+	return true
+}
+
+// ownerOf identifies the principal of a request by how it was authenticated and its subject;
+// requests without a principal share the empty owner
+func ownerOf(r *http.Request) string {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		return ""
+	}
+	return string(principal.Method) + ":" + principal.Subject
+}
+
+func (s *HTTPServer) writeEvent(w http.ResponseWriter, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		s.logger.Printf("Failed to encode response: %v", err)
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+}
+
+// readRequests decodes a single JSON-RPC message or a batch of messages from the request body
+func readRequests(w http.ResponseWriter, r *http.Request) ([]*request, bool, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		return nil, false, err
+	}
+	body = bytes.TrimSpace(body)
+
+	if len(body) > 0 && body[0] == '[' {
+		var requests []*request
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, false, err
+		}
+		if len(requests) == 0 {
+			return nil, false, errors.New("empty batch")
+		}
+		return requests, true, nil
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, false, err
+	}
+	return []*request{&req}, false, nil
+}
+
+// acceptsOnly reports whether the client accepts the given media type but not JSON
+func acceptsOnly(r *http.Request, mediaType string) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, mediaType) &&
+		!strings.Contains(accept, "application/json") &&
+		!strings.Contains(accept, "*/*")
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
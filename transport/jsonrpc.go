@@ -0,0 +1,202 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/FreePeak/cortex/pkg/types"
+)
+
+// JSON-RPC error codes used by the MCP protocol
+const (
+	ParseErrorCode     = -32700
+	InvalidRequestCode = -32600
+	MethodNotFoundCode = -32601
+	InvalidParamsCode  = -32602
+	InternalErrorCode  = -32603
+)
+
+// LatestProtocolVersion is the newest MCP protocol revision served by the HTTP transport
+const LatestProtocolVersion = "2025-06-18"
+
+var supportedProtocolVersions = map[string]bool{
+	"2024-11-05":          true,
+	"2025-03-26":          true,
+	LatestProtocolVersion: true,
+}
+
+// ToolSource provides the tools served over a transport; it is implemented by handler.Registry
+type ToolSource interface {
+	Tools() []*types.Tool
+	Handler(name string) (server.ToolHandler, bool)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// isNotification reports whether the message expects no response
+func (r *request) isNotification() bool {
+	return len(r.ID) == 0
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorResponse(id json.RawMessage, code int, format string, args ...interface{}) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: fmt.Sprintf(format, args...)}}
+}
+
+// dispatcher answers MCP JSON-RPC requests from a ToolSource
+type dispatcher struct {
+	name    string
+	version string
+	tools   ToolSource
+}
+
+// handle processes a single message and returns nil for notifications
+func (d *dispatcher) handle(ctx context.Context, req *request) *response {
+	if req == nil {
+		return errorResponse(nil, InvalidRequestCode, "invalid JSON-RPC request")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, InvalidRequestCode, "invalid JSON-RPC request")
+	}
+	if req.isNotification() {
+		return nil
+	}
+
+	var (
+		result interface{}
+		err    *rpcError
+	)
+	switch req.Method {
+	case "initialize":
+		result, err = d.initialize(req.Params)
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = d.listTools()
+	case "tools/call":
+		result, err = d.callTool(ctx, req.Params)
+	default:
+		err = &rpcError{Code: MethodNotFoundCode, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+
+	if err != nil {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: err}
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (d *dispatcher) initialize(raw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, &rpcError{Code: InvalidParamsCode, Message: "invalid initialize params"}
+		}
+	}
+
+	// Answer with the client's revision when we support it, otherwise with our latest one
+	version := params.ProtocolVersion
+	if !supportedProtocolVersions[version] {
+		version = LatestProtocolVersion
+	}
+
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"serverInfo": map[string]string{
+			"name":    d.name,
+			"version": d.version,
+		},
+		"capabilities": map[string]interface{}{
+			"tools": map[string]bool{
+				"listChanged": false,
+			},
+		},
+	}, nil
+}
+
+func (d *dispatcher) listTools() interface{} {
+	tools := d.tools.Tools()
+	list := make([]map[string]interface{}, 0, len(tools))
+	for _, tool := range tools {
+		properties := make(map[string]interface{}, len(tool.Parameters))
+		required := []string{}
+		for _, param := range tool.Parameters {
+			property := map[string]interface{}{
+				"type":        param.Type,
+				"description": param.Description,
+			}
+			if param.Type == "array" && param.Items != nil {
+				property["items"] = param.Items
+			}
+			properties[param.Name] = property
+			if param.Required {
+				required = append(required, param.Name)
+			}
+		}
+
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+
+		list = append(list, map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": schema,
+		})
+	}
+
+	return map[string]interface{}{"tools": list}
+}
+
+func (d *dispatcher) callTool(ctx context.Context, raw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		Name      string                 `json:"name"`
+		Arguments map[string]interface{} `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil || params.Name == "" {
+		return nil, &rpcError{Code: InvalidParamsCode, Message: "missing or invalid 'name' parameter"}
+	}
+
+	handler, ok := d.tools.Handler(params.Name)
+	if !ok {
+		return nil, &rpcError{Code: InvalidParamsCode, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+	}
+	if params.Arguments == nil {
+		params.Arguments = map[string]interface{}{}
+	}
+
+	result, err := handler(ctx, server.ToolCallRequest{Name: params.Name, Parameters: params.Arguments})
+	if err != nil {
+		return nil, &rpcError{Code: InternalErrorCode, Message: fmt.Sprintf("error executing tool: %v", err)}
+	}
+	if result == nil {
+		result = map[string]interface{}{"content": []interface{}{}}
+	}
+	return result, nil
+}